@@ -0,0 +1,245 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package builder runs builder-gen from other programs, without going
+// through its command line.
+package builder
+
+import (
+	"os"
+
+	"k8s.io/gengo/args"
+
+	"github.com/galgotech/builder-gen/generators"
+)
+
+// DefaultOutputFileBaseName is the name, without extension, of the generated
+// files when Options.OutputFileBaseName is empty.
+const DefaultOutputFileBaseName = "zz_buildergen_generated"
+
+// Options configures a run of the generator. The zero value of each field
+// selects the default of the matching command line flag.
+type Options struct {
+	// InputDirs are the import paths or relative directories of the packages
+	// to generate builders for, "/..." suffixes included recursively.
+	InputDirs []string
+	// OutputBase is the directory the package paths are joined to.
+	OutputBase string
+	// OutputFileBaseName is the name of the generated files, without the
+	// ".go" extension.
+	OutputFileBaseName string
+	// TrimPathPrefix is removed from the package paths when writing.
+	TrimPathPrefix string
+	// GoHeaderFilePath is the file holding the license header of the
+	// generated files. Empty writes no license header.
+	GoHeaderFilePath string
+	// GeneratedBuildTag is the tag excluding the generated files from the
+	// parse, and negated in their build constraint.
+	GeneratedBuildTag string
+	// BuildConstraint replaces the "!<GeneratedBuildTag>" build constraint.
+	BuildConstraint string
+	// OmitBuildConstraint generates the files without a build constraint.
+	OmitBuildConstraint bool
+	// BuildTags select the source files of the input packages, their
+	// constraints propagated to the generated files.
+	BuildTags []string
+	// VerifyOnly fails if the existing files differ from the generated ones
+	// instead of writing them.
+	VerifyOnly bool
+	// DryRun prints a diff of the changes instead of writing the files.
+	DryRun bool
+	// Stdout prints the generated files instead of writing them.
+	Stdout bool
+
+	// InputGroups are other input packages generated in the same run with
+	// their own output file name, header and setter prefix.
+	InputGroups []generators.InputGroup
+	// SetterPrefix is prepended to the names of the builder methods named
+	// after the members.
+	SetterPrefix string
+	// ConstructorPrefix replaces the New prefix of the New<T>Builder
+	// constructors.
+	ConstructorPrefix string
+	// Initialisms are upper-cased in the names of the builder methods, those
+	// of golint when nil.
+	Initialisms []string
+
+	// YAMLPackage enables the New<T>BuilderFromYAML constructors with this
+	// YAML library.
+	YAMLPackage string
+	// JSONSetterNames names the builder methods after the json tags.
+	JSONSetterNames bool
+	// Strict fails on members the builders can't handle.
+	Strict bool
+	// AllArgsConstructors also generates New<T>(...) T constructors for the
+	// structs with only primitive members.
+	AllArgsConstructors bool
+	// Equal also generates Equal(other T) bool methods on the models.
+	Equal bool
+	// DeepCopy also generates DeepCopyInto and DeepCopy methods on the
+	// models.
+	DeepCopy bool
+	// IsZero also generates IsZero and IsEmpty methods on the models.
+	IsZero bool
+	// Getters also generates nil-safe Get<Member> getters on the models.
+	Getters bool
+	// AccumulateErrors records the errors of the setters in the builders.
+	AccumulateErrors bool
+	// NewCallErrors handles the errors of the methods of the new-call tags:
+	// "panic", the default, "record" or "ignore".
+	NewCallErrors string
+	// CopyOnWrite makes the setters return changed copies of the builders.
+	CopyOnWrite bool
+	// FlattenEmbedded forwards the setters of the embedded structs instead
+	// of returning their builders.
+	FlattenEmbedded bool
+	// ConditionalSetters also generates <setter>If variants of the setters.
+	ConditionalSetters bool
+	// HasMethods also generates Has<Member> methods on the builders.
+	HasMethods bool
+	// StructValidator validates the models carrying validate struct tags in
+	// BuildSafe.
+	StructValidator bool
+	// UnmarshalJSON makes the builders json.Unmarshalers.
+	UnmarshalJSON bool
+	// ImmutableBuild detaches the built models from the builders.
+	ImmutableBuild bool
+	// Kubernetes also generates the BuildAndCreate and BuildUnstructured
+	// methods of the builders of the controller-runtime client.Objects.
+	Kubernetes bool
+	// SmokeTests also generates a test per package exercising the builders.
+	SmokeTests bool
+	// JSONSchema also writes the JSON Schema of the structs getting builders
+	// of each package.
+	JSONSchema bool
+	// OptIn only generates builders for the types tagged +builder-gen=true,
+	// and those of the packages tagged +builder-gen=package.
+	OptIn bool
+	// IncludeTypes only generates builders for the types whose names match
+	// this regular expression.
+	IncludeTypes string
+	// ExcludeTypes skips the types whose names match this regular
+	// expression.
+	ExcludeTypes string
+	// SkipPackages are the glob patterns of the import paths of the input
+	// packages not generated, with the packages under them.
+	SkipPackages []string
+	// Closure also generates builders for the structs of other packages
+	// under OutputBase referred to by the inputs.
+	Closure bool
+	// OrderedMaps keeps the keys of the maps of nested builders in the order
+	// they were added.
+	OrderedMaps bool
+	// ConvertVersions also generates the ConvertTo<Version>Builder methods
+	// converting the models between the API versions of the inputs.
+	ConvertVersions bool
+	// GoVersion is the oldest Go version compiling the generated files, which
+	// they may use the features of.
+	GoVersion string
+	// CacheFile skips the packages which did not change since the last run.
+	CacheFile string
+	// Parallelism is the number of packages generated at once.
+	Parallelism int
+	// ConfigFile is the YAML or JSON file configuring how the builders set
+	// the members of the types it lists.
+	ConfigFile string
+	// Config configures the builders like the file of ConfigFile, which
+	// it excludes.
+	Config *generators.Config
+
+	// Generators are other gengo generators, like deepcopy-gen, run on the
+	// input packages once their builders are generated, without parsing
+	// them again.
+	Generators []generators.SharedGenerator
+}
+
+// Run generates the builders of the packages described by opts.
+func Run(opts Options) error {
+	_, err := RunSummary(opts)
+	return err
+}
+
+// RunSummary generates the builders of the packages described by opts, like
+// Run, and returns the counts of what it generated by package.
+func RunSummary(opts Options) (generators.Summary, error) {
+	arguments := args.Default().WithoutDefaultFlagParsing()
+	arguments.InputDirs = opts.InputDirs
+	arguments.OutputFileBaseName = DefaultOutputFileBaseName
+	if opts.OutputFileBaseName != "" {
+		arguments.OutputFileBaseName = opts.OutputFileBaseName
+	}
+	if opts.OutputBase != "" {
+		arguments.OutputBase = opts.OutputBase
+	}
+	arguments.TrimPathPrefix = opts.TrimPathPrefix
+	arguments.GoHeaderFilePath = os.DevNull
+	if opts.GoHeaderFilePath != "" {
+		arguments.GoHeaderFilePath = opts.GoHeaderFilePath
+	}
+	if opts.GeneratedBuildTag != "" {
+		arguments.GeneratedBuildTag = opts.GeneratedBuildTag
+	}
+	arguments.VerifyOnly = opts.VerifyOnly
+
+	arguments.CustomArgs = &generators.CustomArgs{
+		SetterPrefix:        opts.SetterPrefix,
+		ConstructorPrefix:   opts.ConstructorPrefix,
+		Initialisms:         opts.Initialisms,
+		InputGroups:         opts.InputGroups,
+		YAMLPackage:         opts.YAMLPackage,
+		JSONSetterNames:     opts.JSONSetterNames,
+		BuildConstraint:     opts.BuildConstraint,
+		OmitBuildConstraint: opts.OmitBuildConstraint,
+		BuildTags:           opts.BuildTags,
+		Strict:              opts.Strict,
+		DryRun:              opts.DryRun,
+		Stdout:              opts.Stdout,
+		AllArgsConstructors: opts.AllArgsConstructors,
+		Equal:               opts.Equal,
+		DeepCopy:            opts.DeepCopy,
+		IsZero:              opts.IsZero,
+		Getters:             opts.Getters,
+		AccumulateErrors:    opts.AccumulateErrors,
+		NewCallErrors:       opts.NewCallErrors,
+		CopyOnWrite:         opts.CopyOnWrite,
+		FlattenEmbedded:     opts.FlattenEmbedded,
+		ConditionalSetters:  opts.ConditionalSetters,
+		HasMethods:          opts.HasMethods,
+		StructValidator:     opts.StructValidator,
+		UnmarshalJSON:       opts.UnmarshalJSON,
+		ImmutableBuild:      opts.ImmutableBuild,
+		Kubernetes:          opts.Kubernetes,
+		SmokeTests:          opts.SmokeTests,
+		JSONSchema:          opts.JSONSchema,
+		OptIn:               opts.OptIn,
+		IncludeTypes:        opts.IncludeTypes,
+		ExcludeTypes:        opts.ExcludeTypes,
+		SkipPackages:        opts.SkipPackages,
+		Closure:             opts.Closure,
+		OrderedMaps:         opts.OrderedMaps,
+		ConvertVersions:     opts.ConvertVersions,
+		GoVersion:           opts.GoVersion,
+		CacheFile:           opts.CacheFile,
+		Parallelism:         opts.Parallelism,
+		ConfigFile:          opts.ConfigFile,
+		Config:              opts.Config,
+	}
+
+	if err := generators.Validate(arguments); err != nil {
+		return generators.Summary{}, err
+	}
+	customArgs := arguments.CustomArgs.(*generators.CustomArgs)
+	err := generators.Execute(arguments, opts.Generators...)
+	return customArgs.Summary(), err
+}
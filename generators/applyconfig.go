@@ -0,0 +1,146 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// With --kubernetes, the types of the packages with apply configurations, the
+// <Name>ApplyConfiguration structs of applyconfiguration-gen used by server-side
+// apply, are converted from and to them through their common JSON form: the
+// members of these types get a Set<Member>ApplyConfiguration setter, and the
+// builders of the Kubernetes objects a ToApplyConfiguration method.
+
+// Packages of the apply configurations of the Kubernetes API types, of
+// k8s.io/api/<group>/<version> under clientGoApplyConfigurations.
+const (
+	kubernetesAPIPackages       = "k8s.io/api/"
+	clientGoApplyConfigurations = "k8s.io/client-go/applyconfigurations/"
+)
+
+// jsonMarshalFunc encodes the apply configurations and the models converted
+// to each other.
+var jsonMarshalFunc = &types.Type{Name: types.Name{Package: "encoding/json", Name: "Marshal"}}
+
+// applyConfigurationOf returns, with --kubernetes, the apply configuration of
+// the struct t: the <Name>ApplyConfiguration of the package configured for its
+// package, or of client-go for the Kubernetes API types. It is nil for the
+// types without apply configuration, like the anonymous structs.
+func (g *genDeepCopy) applyConfigurationOf(t *types.Type) *types.Type {
+	if !g.customArgs.Kubernetes || t.Kind != types.Struct || inlineParent(t) != nil {
+		return nil
+	}
+	pkg, ok := g.customArgs.config.ApplyConfigurations[t.Name.Package]
+	switch {
+	case ok:
+	case t.Name.Package == typeMetaName.Package:
+		pkg = clientGoApplyConfigurations + "meta/v1"
+	case strings.HasPrefix(t.Name.Package, kubernetesAPIPackages):
+		pkg = clientGoApplyConfigurations + strings.TrimPrefix(t.Name.Package, kubernetesAPIPackages)
+	default:
+		return nil
+	}
+	return &types.Type{Name: types.Name{Package: pkg, Name: t.Name.Name + "ApplyConfiguration"}}
+}
+
+// applyConfigurationSetter writes, for the member m of t holding a struct
+// with an apply configuration or a pointer to it, the
+// Set<Member>ApplyConfiguration setter converting the apply configuration
+// input to the member, its nested builder included.
+func (g *genDeepCopy) applyConfigurationSetter(sw *generator.SnippetWriter, t *types.Type, m types.Member, argsMember generator.Args) {
+	umt := underlyingType(m.Type)
+	if umt.Kind == types.Pointer {
+		umt = underlyingType(umt.Elem)
+	}
+	config := g.applyConfigurationOf(umt)
+	name := "Set" + argsMember["base"].(string) + "ApplyConfiguration"
+	if config == nil || m.Embedded || g.handWritten(t, name) {
+		return
+	}
+
+	argsMember["applyConfiguration"] = config
+	argsMember["model"] = umt
+	argsMember["marshal"] = jsonMarshalFunc
+	argsMember["unmarshal"] = jsonUnmarshalFunc
+	sw.Do("// "+name+" sets $.name$ to the value of the apply configuration input.\n", argsMember)
+	g.failingSetter(sw, name+"(input *$.applyConfiguration|raw$)", argsMember)
+	sw.Do("data, err := $.marshal|raw$(input)\n", argsMember)
+	sw.Do("if err != nil {\n", argsMember)
+	g.failingSetterError(sw, argsMember)
+	sw.Do("}\n", argsMember)
+	sw.Do("var value $.model|raw$\n", argsMember)
+	sw.Do("if err := $.unmarshal|raw$(data, &value); err != nil {\n", argsMember)
+	g.failingSetterError(sw, argsMember)
+	sw.Do("}\n", argsMember)
+	g.clearOneof(sw, t, m)
+	switch {
+	case g.embedsBuilder(t, m, umt):
+		// Like in fromModel, the builders mixed in for values are not
+		// pointers.
+		argsMember["field"] = embeddedField(m)
+		switch {
+		case underlyingType(m.Type).Kind == types.Pointer:
+			g.builderFromModel(sw, "b."+embeddedField(m), false, "value", umt)
+		case !g.isLocalType(umt):
+			argsMember["fromModel"] = g.fromModelOf(umt)
+			sw.Do("b.$.field$ = *$.fromModel|raw$(value)\n", argsMember)
+		default:
+			sw.Do("b.$.field$.fromModel(value)\n", argsMember)
+		}
+	case g.memberBuilder(t, m, umt):
+		g.builderFromModel(sw, "b."+propertyName(m), false, "value", umt)
+	case underlyingType(m.Type).Kind == types.Pointer:
+		sw.Do("b.model.$.name$ = &value\n", argsMember)
+	default:
+		sw.Do("b.model.$.name$ = value\n", argsMember)
+	}
+	g.failingSetterEnd(sw)
+}
+
+// structMethodToApplyConfiguration writes, with --kubernetes, the
+// ToApplyConfiguration method of the builders of the Kubernetes objects with
+// an apply configuration, converting the built object to it.
+func (g *genDeepCopy) structMethodToApplyConfiguration(sw *generator.SnippetWriter, t *types.Type) {
+	config := g.applyConfigurationOf(t)
+	if config == nil || !isKubernetesObject(t) || g.handWritten(t, "ToApplyConfiguration") {
+		return
+	}
+
+	args := generator.Args{
+		"type":               t,
+		"build":              g.buildName(t),
+		"applyConfiguration": config,
+		"marshal":            jsonMarshalFunc,
+		"unmarshal":          jsonUnmarshalFunc,
+	}
+	sw.Do("// ToApplyConfiguration builds the object and converts it to its apply\n", args)
+	sw.Do("// configuration, for server-side apply.\n", args)
+	sw.Do("func (b *$.type|raw$Builder) ToApplyConfiguration() (*$.applyConfiguration|raw$, error) {\n", args)
+	sw.Do("model := b.$.build$()\n", args)
+	sw.Do("data, err := $.marshal|raw$(&model)\n", args)
+	sw.Do("if err != nil {\n", args)
+	sw.Do("return nil, err\n", args)
+	sw.Do("}\n", args)
+	sw.Do("config := &$.applyConfiguration|raw${}\n", args)
+	sw.Do("if err := $.unmarshal|raw$(data, config); err != nil {\n", args)
+	sw.Do("return nil, err\n", args)
+	sw.Do("}\n", args)
+	sw.Do("return config, nil\n", args)
+	sw.Do("}\n\n", args)
+}
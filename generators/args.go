@@ -0,0 +1,513 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"go/build/constraint"
+	"go/token"
+	"path"
+	"regexp"
+	"strconv"
+
+	"github.com/spf13/pflag"
+	"k8s.io/gengo/args"
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/types"
+)
+
+// YAML libraries supported by the generated New<T>BuilderFromYAML constructors.
+var yamlPackages = []string{
+	"sigs.k8s.io/yaml",
+	"gopkg.in/yaml.v3",
+}
+
+// Handlings of the errors returned by the methods called by the New<T>Builder
+// constructors, listed in the +builder-gen:new-call tags.
+const (
+	newCallErrorsPanic  = "panic"
+	newCallErrorsRecord = "record"
+	newCallErrorsIgnore = "ignore"
+)
+
+var newCallErrorsModes = []string{newCallErrorsPanic, newCallErrorsRecord, newCallErrorsIgnore}
+
+// CustomArgs is used by the go2idl framework to pass args specific to this
+// generator. Their JSON encoding is part of the fingerprint of the cached
+// packages, the fields tagged json:"-" only changing how the files are written
+// or being hashed once resolved for each package.
+type CustomArgs struct {
+	// SetterPrefix is prepended to the names of the builder methods named
+	// after the members.
+	SetterPrefix string `json:"-"`
+
+	// ConstructorPrefix replaces the New prefix of the New<T>Builder
+	// constructors.
+	ConstructorPrefix string
+
+	// Initialisms are upper-cased in the names of the builder methods, like
+	// ID for a member named Id. Nil uses the initialisms of golint.
+	Initialisms []string `json:"-"`
+
+	// InputGroups are other input packages generated in the same run with
+	// their own settings. Execute adds them to the parsed packages.
+	InputGroups []InputGroup `json:"-"`
+
+	// YAMLPackage is the import path of the YAML library used by the
+	// New<T>BuilderFromYAML constructors. Empty disables them.
+	YAMLPackage string
+
+	// JSONSetterNames names the builder methods after the json tag of the
+	// members instead of their Go names.
+	JSONSetterNames bool
+
+	// BuildConstraint replaces the default "!<build-tag>" constraint of the
+	// generated files.
+	BuildConstraint string
+
+	// OmitBuildConstraint generates the files without any build constraint.
+	OmitBuildConstraint bool
+
+	// BuildTags are the build tags the source files of the input packages are
+	// selected with. The generated files get the constraints of the files
+	// declaring their types.
+	BuildTags []string
+
+	// Strict fails the generation of the packages with members the builders
+	// can't handle, instead of only reporting them.
+	Strict bool
+
+	// DryRun prints the differences between the existing and the generated
+	// files instead of writing them.
+	DryRun bool `json:"-"`
+
+	// Stdout prints the generated files instead of writing them.
+	Stdout bool `json:"-"`
+
+	// CacheFile is the file remembering the inputs of the generated packages,
+	// the packages whose inputs did not change are not generated again.
+	CacheFile string `json:"-"`
+
+	// AllArgsConstructors also generates New<T>(...) T constructors taking
+	// all the members of the structs with only primitive members.
+	AllArgsConstructors bool
+
+	// Equal also generates Equal(other T) bool methods on the models of the
+	// builders.
+	Equal bool
+
+	// DeepCopy also generates DeepCopyInto and DeepCopy methods on the models
+	// of the builders, following the +k8s:deepcopy-gen tags, which Build uses
+	// with ImmutableBuild.
+	DeepCopy bool
+
+	// IsZero also generates IsZero and IsEmpty methods on the models of the
+	// builders, with which Build leaves the pointers to the empty nested
+	// models omitted by omitempty nil.
+	IsZero bool
+
+	// Getters also generates nil-safe Get<Member> getters on the models of
+	// the builders.
+	Getters bool
+
+	// AccumulateErrors makes the setters which may fail record their errors
+	// in the builder, returned by its Err and BuildSafe methods.
+	AccumulateErrors bool
+
+	// NewCallErrors is how the New<T>Builder constructors handle the errors
+	// returned by the methods of their +builder-gen:new-call tags: panic,
+	// record them in the builder with AccumulateErrors, or ignore them.
+	NewCallErrors string
+
+	// CopyOnWrite makes the setters return a changed copy of the builder
+	// instead of changing it, so the builders can be shared by goroutines.
+	CopyOnWrite bool
+
+	// FlattenEmbedded forwards the setters of all the members of the embedded
+	// structs to the outer builders, instead of the methods returning the
+	// embedded builders.
+	FlattenEmbedded bool
+
+	// ConditionalSetters also generates <setter>If(cond bool, input T)
+	// variants of the setters, only setting the members when cond is true.
+	ConditionalSetters bool
+
+	// HasMethods also generates Has<Member>() bool methods on the builders,
+	// reporting whether the members were set.
+	HasMethods bool
+
+	// StructValidator makes BuildSafe validate the models whose members
+	// carry validate struct tags with github.com/go-playground/validator.
+	StructValidator bool
+
+	// UnmarshalJSON also generates UnmarshalJSON methods on the builders,
+	// setting the members present in the documents.
+	UnmarshalJSON bool
+
+	// ImmutableBuild makes Build build a copy of the builder, so the built
+	// models don't share their slices, maps and nested models with it.
+	ImmutableBuild bool
+
+	// Kubernetes also generates, for the controller-runtime client.Objects,
+	// BuildAndCreate methods creating the built objects with a client and
+	// BuildUnstructured methods converting them to unstructured objects, and
+	// the conversions from and to the apply configurations.
+	Kubernetes bool
+
+	// SmokeTests also generates a test per package creating every builder,
+	// calling one method per member and building the model.
+	SmokeTests bool
+
+	// JSONSchema also writes the JSON Schema of the structs getting builders
+	// per package, next to the builders.
+	JSONSchema bool
+
+	// OptIn only generates builders for the types tagged +builder-gen=true,
+	// and those of the packages tagged +builder-gen=package.
+	OptIn bool
+
+	// IncludeTypes, a regular expression, only generates builders for the
+	// types whose names it matches, when set.
+	IncludeTypes string
+
+	// ExcludeTypes, a regular expression, skips the types whose names it
+	// matches, when set.
+	ExcludeTypes string
+
+	// SkipPackages are the glob patterns of the import paths of the input
+	// packages not generated, along with the packages under them.
+	SkipPackages []string
+
+	// Closure also generates builders for the structs of other packages
+	// reachable through the members of the generated types.
+	Closure bool
+
+	// OrderedMaps keeps the keys of the maps of nested builders in the order
+	// they were added, for Build to build the entries in that order and the
+	// <Member>Keys methods to return them.
+	OrderedMaps bool
+
+	// ConvertVersions also generates, for the structs of the input packages
+	// of the same directory named by API versions, ConvertTo<Version>Builder
+	// methods converting the built models to the other versions.
+	ConvertVersions bool
+
+	// GoVersion is the oldest Go version compiling the generated files, like
+	// 1.20, which the generated code may use the features of. Empty only
+	// uses the features of every version the generator supports.
+	GoVersion string
+
+	// Parallelism is the number of packages generated at once, GOMAXPROCS
+	// when zero.
+	Parallelism int `json:"-"`
+
+	// ConfigFile is the YAML or JSON file of the Config of the builders,
+	// read when Config is nil.
+	ConfigFile string `json:"-"`
+
+	// Config configures how the builders set the members of the configured
+	// types.
+	Config *Config `json:"-"`
+
+	report  warningReport
+	summary summaryReport
+	// enabledPackages are the packages tagged +builder-gen=package.
+	enabledPackages sets.String
+	// packageDefaults are the type tags of the doc.go of the packages, by
+	// package path and tag name.
+	packageDefaults map[string]map[string]string
+	// closurePackages are the packages Execute added to the inputs for
+	// --closure.
+	closurePackages sets.String
+	// includeTypes and excludeTypes are the compiled IncludeTypes and
+	// ExcludeTypes, set by Execute.
+	includeTypes, excludeTypes *regexp.Regexp
+	// sharedBuildTags are the tags excluding the files of the shared
+	// generators from the parse, set by Execute.
+	sharedBuildTags []string
+	// config and handlers are the Config read by Execute and the handlers of
+	// its configured types, by type name.
+	config   Config
+	handlers map[types.Name]typeHandler
+}
+
+// Warnings returns the members the generated builders could not handle,
+// across all the packages generated with these arguments.
+func (ca *CustomArgs) Warnings() []Warning {
+	return ca.report.list()
+}
+
+// Summary returns the counts of what the last run of Execute generated, by
+// package.
+func (ca *CustomArgs) Summary() Summary {
+	return ca.summary.summary()
+}
+
+// AddFlags adds the generator specific flags to the flag set.
+func (ca *CustomArgs) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&ca.SetterPrefix, "setter-prefix", ca.SetterPrefix,
+		"If set, prepend this prefix to the names of the builder methods named after the members, e.g. With.")
+	fs.StringVar(&ca.ConstructorPrefix, "constructor-prefix", ca.ConstructorPrefix,
+		"If set, replace the New prefix of the New<T>Builder constructors, and of the New<T>BuilderFrom... ones, by this exported prefix, e.g. A.")
+	fs.StringSliceVar(&ca.Initialisms, "initialisms", ca.Initialisms,
+		"Initialisms upper-cased in the names of the builder methods, e.g. ID,URL,HTTP. Defaults to those of golint, empty disables them.")
+	fs.Var(inputGroupsValue{&ca.InputGroups}, "input-group",
+		"Input packages generated with their own settings, as \"input-dirs=<dir>,<dir>;output-file-base-name=<name>;go-header-file=<path>;setter-prefix=<prefix>\". Repeat for several groups, the omitted fields take the value of the matching flag.")
+	fs.StringVar(&ca.YAMLPackage, "yaml-package", ca.YAMLPackage,
+		fmt.Sprintf("If set, generate New<T>BuilderFromYAML constructors using this YAML library. One of %v.", yamlPackages))
+	fs.BoolVar(&ca.JSONSetterNames, "json-setter-names", ca.JSONSetterNames,
+		"If true, name the builder methods after the camel-cased json tag of the members instead of their Go names.")
+	fs.StringVar(&ca.BuildConstraint, "build-constraint", ca.BuildConstraint,
+		"If set, use this //go:build expression in the generated files instead of \"!<build-tag>\".")
+	fs.BoolVar(&ca.OmitBuildConstraint, "omit-build-constraint", ca.OmitBuildConstraint,
+		"If true, generate the files without a build constraint.")
+	fs.StringSliceVar(&ca.BuildTags, "build-tags", ca.BuildTags,
+		"Build tags selecting the source files of the input packages, e.g. linux,integration. The generated files get the //go:build constraints of the files declaring their types.")
+	fs.BoolVar(&ca.Strict, "strict", ca.Strict,
+		"If true, fail when a member of a generated type can't be handled by its builder.")
+	fs.BoolVar(&ca.DryRun, "dry-run", ca.DryRun,
+		"If true, print a unified diff of the generated files against the existing ones instead of writing them.")
+	fs.BoolVar(&ca.Stdout, "stdout", ca.Stdout,
+		"If true, print the generated files, each preceded by a \"// file: <path>\" line, instead of writing them.")
+	fs.StringVar(&ca.CacheFile, "cache-file", ca.CacheFile,
+		"If set, remember the inputs of the generated packages in this file and skip the packages that did not change.")
+	fs.BoolVar(&ca.AllArgsConstructors, "all-args-constructors", ca.AllArgsConstructors,
+		"If true, also generate New<T>(...) T constructors taking all the members of the structs with only primitive members.")
+	fs.BoolVar(&ca.Equal, "equal", ca.Equal,
+		"If true, also generate Equal(other T) bool methods on the models, comparing pointers, slices and maps by their contents.")
+	fs.BoolVar(&ca.DeepCopy, "deep-copy", ca.DeepCopy,
+		"If true, also generate DeepCopyInto(out *T) and DeepCopy() *T methods on the models, like deepcopy-gen and following its +k8s:deepcopy-gen tags; with --immutable-build, Build deep-copies the built models with them.")
+	fs.BoolVar(&ca.IsZero, "is-zero", ca.IsZero,
+		"If true, also generate IsZero() bool and IsEmpty() bool methods on the models, checking all their members, nested structs included; Build then leaves the omitempty pointers to the empty nested models nil.")
+	fs.BoolVar(&ca.Getters, "getters", ca.Getters,
+		"If true, also generate Get<Member>() getters on the models returning the zero values for nil receivers, like those of the protobuf messages, the nested structs being returned by pointer so the getters chain.")
+	fs.BoolVar(&ca.AccumulateErrors, "accumulate-errors", ca.AccumulateErrors,
+		"If true, the setters which may fail record their errors in the builder, returned by its Err() error and BuildSafe() (T, error) methods, instead of returning them.")
+	fs.StringVar(&ca.NewCallErrors, "new-call-errors", ca.NewCallErrors,
+		"How the New<T>Builder constructors handle the errors returned by the methods of their +builder-gen:new-call tags: panic, record them in the builder with --accumulate-errors, or ignore. Defaults to panic.")
+	fs.BoolVar(&ca.CopyOnWrite, "copy-on-write", ca.CopyOnWrite,
+		"If true, the setters return a changed copy of the builder sharing the members they don't change, and the nested builders are set with update functions, so the builders can be shared by goroutines without locks.")
+	fs.BoolVar(&ca.FlattenEmbedded, "flatten-embedded", ca.FlattenEmbedded,
+		"If true, the builders forward the setters of all the members of the embedded structs, returning the outer builder, instead of the methods returning the embedded builders.")
+	fs.BoolVar(&ca.ConditionalSetters, "conditional-setters", ca.ConditionalSetters,
+		"If true, also generate <setter>If(cond bool, input T) variants of the setters, only setting the members when cond is true.")
+	fs.BoolVar(&ca.HasMethods, "has-methods", ca.HasMethods,
+		"If true, also generate Has<Member>() bool methods on the builders, reporting whether the members were set: not zero, or with nested builders created or added.")
+	fs.BoolVar(&ca.StructValidator, "struct-validator", ca.StructValidator,
+		"If true, BuildSafe() (T, error) validates the models whose members carry validate struct tags with "+validatorPackage+".")
+	fs.BoolVar(&ca.UnmarshalJSON, "unmarshal-json", ca.UnmarshalJSON,
+		"If true, make the builders json.Unmarshalers setting the members present in the documents over those already set.")
+	fs.BoolVar(&ca.ImmutableBuild, "immutable-build", ca.ImmutableBuild,
+		"If true, Build builds a copy of the builder, so the later calls of its methods don't change the built models.")
+	fs.BoolVar(&ca.Kubernetes, "kubernetes", ca.Kubernetes,
+		"If true, also generate BuildAndCreate(ctx, c, opts...) and BuildUnstructured() methods on the builders of the types implementing the client.Object of "+controllerClientPackage+".")
+	fs.BoolVar(&ca.SmokeTests, "smoke-tests", ca.SmokeTests,
+		"If true, also generate a "+smokeTestFileBaseName+".go test per package creating every builder, calling one method per member and building the model.")
+	fs.BoolVar(&ca.JSONSchema, "json-schema", ca.JSONSchema,
+		"If true, also write the JSON Schema of the structs getting builders of each package next to the builders, in a <output-file-base>"+schemaFileSuffix+" file.")
+	fs.BoolVar(&ca.OptIn, "opt-in", ca.OptIn,
+		"If true, only generate builders for the types tagged +builder-gen=true and those of the packages tagged +builder-gen=package in their doc.go.")
+	fs.StringVar(&ca.IncludeTypes, "include-types", ca.IncludeTypes,
+		"If set, only generate builders for the types whose names match this regular expression, unless tagged +builder-gen=false.")
+	fs.StringVar(&ca.ExcludeTypes, "exclude-types", ca.ExcludeTypes,
+		"If set, skip the types whose names match this regular expression, unless tagged +builder-gen=true.")
+	fs.StringSliceVar(&ca.SkipPackages, "skip-packages", ca.SkipPackages,
+		"Glob patterns of the import paths of the input packages not to generate, with the packages under them, e.g. example.com/project/vendor,example.com/project/*/experimental.")
+	fs.BoolVar(&ca.Closure, "closure", ca.Closure,
+		"If true, also generate builders for the structs of other non-standard packages under --output-base reachable through the members of the generated types.")
+	fs.BoolVar(&ca.OrderedMaps, "ordered-maps", ca.OrderedMaps,
+		"If true, the builders keep the keys of the maps of nested builders in the order they were added, building the entries in that order and returning the keys from <Member>Keys() methods.")
+	fs.BoolVar(&ca.ConvertVersions, "convert-versions", ca.ConvertVersions,
+		"If true, the builders of the structs of the input packages of the same directory named by API versions, like api/v1alpha1 and api/v1, also get ConvertTo<Version>Builder() methods converting the built models to the structs of the same name of the other versions.")
+	fs.StringVar(&ca.GoVersion, "go-version", ca.GoVersion,
+		"If set, the oldest Go version compiling the generated files, e.g. 1.20: the builders use any from 1.18 and errors.Join from 1.20, and compatible constructs otherwise.")
+	fs.IntVar(&ca.Parallelism, "parallelism", ca.Parallelism,
+		"Number of packages generated at once. Defaults to GOMAXPROCS.")
+	fs.StringVar(&ca.ConfigFile, "config", ca.ConfigFile,
+		"If set, the YAML or JSON file configuring how the builders set the members of the types it lists, e.g. with the dual setters of the union handler.")
+}
+
+// constructorPrefix returns the prefix of the New<T>Builder constructors.
+func (ca *CustomArgs) constructorPrefix() string {
+	if ca.ConstructorPrefix == "" {
+		return "New"
+	}
+	return ca.ConstructorPrefix
+}
+
+// goVersionPattern matches the values of --go-version, with an optional go
+// prefix and patch version.
+var goVersionPattern = regexp.MustCompile(`^(?:go)?1\.(\d+)(?:\.\d+)?$`)
+
+// goMinorVersion returns the minor version of --go-version, 0 when not set.
+func (ca *CustomArgs) goMinorVersion() (int, error) {
+	if ca.GoVersion == "" {
+		return 0, nil
+	}
+	match := goVersionPattern.FindStringSubmatch(ca.GoVersion)
+	if match == nil {
+		return 0, fmt.Errorf("invalid --go-version %q, must be like 1.20", ca.GoVersion)
+	}
+	return strconv.Atoi(match[1])
+}
+
+// goAtLeast reports whether the generated code may use the features of Go
+// 1.<minor>.
+func (ca *CustomArgs) goAtLeast(minor int) bool {
+	version, err := ca.goMinorVersion()
+	return err == nil && version >= minor
+}
+
+// typeFilters compiles the regular expressions of --include-types and
+// --exclude-types, nil when not set.
+func (ca *CustomArgs) typeFilters() (include, exclude *regexp.Regexp, err error) {
+	if ca.IncludeTypes != "" {
+		if include, err = regexp.Compile(ca.IncludeTypes); err != nil {
+			return nil, nil, fmt.Errorf("invalid --include-types %q: %v", ca.IncludeTypes, err)
+		}
+	}
+	if ca.ExcludeTypes != "" {
+		if exclude, err = regexp.Compile(ca.ExcludeTypes); err != nil {
+			return nil, nil, fmt.Errorf("invalid --exclude-types %q: %v", ca.ExcludeTypes, err)
+		}
+	}
+	return include, exclude, nil
+}
+
+// skipsPackage reports whether the package of the import path pkg, or one of
+// the directories above it, matches a pattern of --skip-packages.
+func (ca *CustomArgs) skipsPackage(pkg string) bool {
+	for dir := pkg; dir != "." && dir != "/"; dir = path.Dir(dir) {
+		for _, pattern := range ca.SkipPackages {
+			if match, _ := path.Match(pattern, dir); match {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// buildConstraintHeader returns the build constraint lines written before the
+// boilerplate of the generated files, and the constraints of the source files
+// of their types.
+func (ca *CustomArgs) buildConstraintHeader(generatedBuildTag string, sources ...constraint.Expr) ([]byte, error) {
+	var parsed constraint.Expr
+	if !ca.OmitBuildConstraint {
+		expr := ca.BuildConstraint
+		if expr == "" {
+			expr = "!" + generatedBuildTag
+		}
+		var err error
+		if parsed, err = constraint.Parse("//go:build " + expr); err != nil {
+			return nil, fmt.Errorf("invalid build constraint %q: %v", expr, err)
+		}
+	}
+	for _, source := range sources {
+		if parsed == nil {
+			parsed = source
+		} else {
+			parsed = &constraint.AndExpr{X: parsed, Y: source}
+		}
+	}
+	if parsed == nil {
+		return nil, nil
+	}
+	header := fmt.Sprintf("//go:build %s\n", parsed)
+	plusBuild, err := constraint.PlusBuildLines(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid build constraint %q: %v", parsed, err)
+	}
+	for _, line := range plusBuild {
+		header += line + "\n"
+	}
+	return []byte(header + "\n"), nil
+}
+
+// Validate checks the generator specific arguments.
+func Validate(arguments *args.GeneratorArgs) error {
+	customArgs, ok := arguments.CustomArgs.(*CustomArgs)
+	if !ok {
+		return fmt.Errorf("unexpected custom arguments %T", arguments.CustomArgs)
+	}
+	if customArgs.Parallelism < 0 {
+		return fmt.Errorf("--parallelism must not be negative, got %d", customArgs.Parallelism)
+	}
+	if customArgs.DryRun && customArgs.Stdout {
+		return fmt.Errorf("--dry-run and --stdout are mutually exclusive")
+	}
+	if customArgs.OmitBuildConstraint && customArgs.BuildConstraint != "" {
+		return fmt.Errorf("--build-constraint and --omit-build-constraint are mutually exclusive")
+	}
+	if _, err := customArgs.buildConstraintHeader(arguments.GeneratedBuildTag); err != nil {
+		return err
+	}
+	if customArgs.SetterPrefix != "" && !token.IsIdentifier(customArgs.SetterPrefix) {
+		return fmt.Errorf("--setter-prefix %q is not a Go identifier", customArgs.SetterPrefix)
+	}
+	if customArgs.ConstructorPrefix != "" && !(token.IsIdentifier(customArgs.ConstructorPrefix) && token.IsExported(customArgs.ConstructorPrefix)) {
+		return fmt.Errorf("--constructor-prefix %q is not an exported Go identifier", customArgs.ConstructorPrefix)
+	}
+	if _, _, err := customArgs.typeFilters(); err != nil {
+		return err
+	}
+	if _, err := customArgs.goMinorVersion(); err != nil {
+		return err
+	}
+	if customArgs.ConfigFile != "" && customArgs.Config != nil {
+		return fmt.Errorf("--config and the Config of the custom arguments are mutually exclusive")
+	}
+	if _, _, err := customArgs.readConfig(); err != nil {
+		return err
+	}
+	for _, pattern := range customArgs.SkipPackages {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid --skip-packages %q: %v", pattern, err)
+		}
+	}
+	for _, initialism := range customArgs.Initialisms {
+		if !token.IsIdentifier(initialism) {
+			return fmt.Errorf("--initialisms %q is not a Go identifier", initialism)
+		}
+	}
+	for _, group := range customArgs.InputGroups {
+		if len(group.InputDirs) == 0 {
+			return fmt.Errorf("input group %q has no input directories", group)
+		}
+		if group.SetterPrefix != "" && !token.IsIdentifier(group.SetterPrefix) {
+			return fmt.Errorf("setter prefix %q of the input group %q is not a Go identifier", group.SetterPrefix, group)
+		}
+	}
+	switch customArgs.NewCallErrors {
+	case "", newCallErrorsPanic, newCallErrorsIgnore:
+	case newCallErrorsRecord:
+		if !customArgs.AccumulateErrors {
+			return fmt.Errorf("--new-call-errors=%s requires --accumulate-errors", newCallErrorsRecord)
+		}
+	default:
+		return fmt.Errorf("unsupported --new-call-errors %q, must be one of %v", customArgs.NewCallErrors, newCallErrorsModes)
+	}
+	if customArgs.YAMLPackage != "" {
+		found := false
+		for _, p := range yamlPackages {
+			if p == customArgs.YAMLPackage {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("unsupported --yaml-package %q, must be one of %v", customArgs.YAMLPackage, yamlPackages)
+		}
+	}
+	return nil
+}
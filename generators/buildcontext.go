@@ -0,0 +1,92 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// A +builder-gen:build-hook=<Method> tag on a struct gives its builder a
+// BuildContext method, building the model and calling on it the hooks listed,
+// methods of the model taking a context.Context and returning an error, in
+// order. The context is checked before each hook, so a cancelled build stops
+// between them.
+
+// contextName is the type of the parameter of BuildContext and of the hooks.
+var contextName = types.Name{Package: "context", Name: "Context"}
+
+// checkBuildHooks returns an error if a hook of the +builder-gen:build-hook
+// tag of t is not a method of t, or of its pointer, taking a context.Context
+// and returning an error.
+func checkBuildHooks(t *types.Type) error {
+	for _, name := range extractTag(t, buildHookTagName) {
+		method, ok := t.Methods[name]
+		if ok && method.Signature != nil {
+			sig := method.Signature
+			if len(sig.Parameters) == 1 && sig.Parameters[0].Name == contextName && !sig.Variadic &&
+				len(sig.Results) == 1 && sig.Results[0].Name == (types.Name{Name: "error"}) {
+				continue
+			}
+		}
+		return fmt.Errorf("%v: the hook %s of the %s tag must be a method of %s taking a %v and returning an error", t, name, buildHookTagName, t.Name.Name, contextName)
+	}
+	return nil
+}
+
+// structMethodBuildContext writes BuildContext for the structs tagged
+// +builder-gen:build-hook, building the model with BuildSafe when the builder
+// has it, and returning the first error of the hooks.
+func (g *genDeepCopy) structMethodBuildContext(sw *generator.SnippetWriter, t *types.Type) error {
+	hooks := extractTag(t, buildHookTagName)
+	if len(hooks) == 0 || g.handWritten(t, "BuildContext") {
+		return nil
+	}
+	if err := checkBuildHooks(t); err != nil {
+		return err
+	}
+
+	args := generator.Args{
+		"type":    t,
+		"build":   g.buildName(t),
+		"context": &types.Type{Name: contextName},
+		"errorf":  errorfFunc,
+	}
+	sw.Do("// BuildContext builds the model and calls its hooks with ctx, returning\n", args)
+	sw.Do("// the first error.\n", args)
+	sw.Do("func (b *$.type|raw$Builder) BuildContext(ctx $.context|raw$) ($.type|raw$, error) {\n", args)
+	if g.customArgs.AccumulateErrors || hasValidation(t) || g.structValidated(t) {
+		sw.Do("model, err := b.BuildSafe()\n", args)
+		sw.Do("if err != nil {\n", args)
+		sw.Do("return model, err\n", args)
+		sw.Do("}\n", args)
+	} else {
+		sw.Do("model := b.$.build$()\n", args)
+	}
+	for _, hook := range hooks {
+		args["hook"] = hook
+		sw.Do("if err := ctx.Err(); err != nil {\n", args)
+		sw.Do("return model, err\n", args)
+		sw.Do("}\n", args)
+		sw.Do("if err := model.$.hook$(ctx); err != nil {\n", args)
+		sw.Do("return model, $.errorf|raw$(\"$.hook$: %w\", err)\n", args)
+		sw.Do("}\n", args)
+	}
+	sw.Do("return model, nil\n", args)
+	sw.Do("}\n\n", args)
+	return nil
+}
@@ -15,11 +15,21 @@
 package generators
 
 import (
+	"bytes"
 	"fmt"
+	"go/build"
+	"go/build/constraint"
+	"go/token"
 	"io"
+	"os"
+	"path"
 	"path/filepath"
+	"reflect"
+	"strconv"
 	"strings"
+	"unicode"
 
+	"golang.org/x/mod/modfile"
 	"k8s.io/gengo/args"
 	"k8s.io/gengo/examples/set-gen/sets"
 	"k8s.io/gengo/generator"
@@ -33,9 +43,86 @@ const (
 	tagEnabledName              = "builder-gen"
 	ignoreTagName               = tagEnabledName + ":ignore"
 	newMethodCallTagName        = tagEnabledName + ":new-call"
+	newFuncTagName              = tagEnabledName + ":new-func"
 	embeddedIgnoreMethodTagName = tagEnabledName + ":embedded-ignore-method"
+	embeddedValueTagName        = tagEnabledName + ":embedded-value"
+	mixinTagName                = tagEnabledName + ":mixin"
+	buildNameTagName            = tagEnabledName + ":build-name"
+	setterPrefixTagName         = tagEnabledName + ":setter-prefix"
+	buildHookTagName            = tagEnabledName + ":build-hook"
+	capTagName                  = tagEnabledName + ":cap"
+	boilerplateTagName          = tagEnabledName + ":boilerplate"
+	requiredTagName             = tagEnabledName + ":required"
+	jsonTagName                 = tagEnabledName + ":json"
+	encodingTagName             = tagEnabledName + ":encoding"
+	validateTagName             = tagEnabledName + ":validate"
+	oneofTagName                = tagEnabledName + ":oneof"
+	gvkTagName                  = tagEnabledName + ":gvk"
+	implementationsTagName      = tagEnabledName + ":implementations"
+
+	deepCopyInterfacesTagName = "k8s:deepcopy-gen:interfaces"
+
+	// encodingBase64 is the value of the encoding tag of the byte slices
+	// set from base64 strings.
+	encodingBase64 = "base64"
+
+	// tagValuePackage is the value of the enable tag in doc.go turning on the
+	// builders of all the types of the package.
+	tagValuePackage = "package"
+
+	// structTagName is the struct tag configuring members, `builder:"-"`
+	// skips the member.
+	structTagName = "builder"
 )
 
+// Kubernetes API machinery names used to detect API object types.
+var (
+	typeMetaName      = types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "TypeMeta"}
+	objectMetaName    = types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ObjectMeta"}
+	runtimeObjectName = types.Name{Package: "k8s.io/apimachinery/pkg/runtime", Name: "Object"}
+)
+
+// extractEnabledTag returns the value of the +builder-gen=true|false tag of t,
+// +builder-gen:true being the same as +builder-gen=true. ok is false when t
+// has no such tag.
+func extractEnabledTag(t *types.Type) (enabled, ok bool) {
+	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
+	tags := types.ExtractCommentTags("+", comments)
+	if values := tags[tagEnabledName]; len(values) > 0 {
+		return values[0] == "true", true
+	}
+	if _, ok := tags[tagEnabledName+":true"]; ok {
+		return true, true
+	}
+	return false, false
+}
+
+// extractPackageEnabledTag returns the value of the +builder-gen tag of the
+// doc.go of pkg, empty when there is none.
+func extractPackageEnabledTag(pkg *types.Package) (string, error) {
+	values := types.ExtractCommentTags("+", pkg.Comments)[tagEnabledName]
+	if len(values) == 0 {
+		return "", nil
+	}
+	if len(values) > 1 || values[0] != tagValuePackage {
+		return "", fmt.Errorf("Package %v: unsupported %s value: %q", pkg.Path, tagEnabledName, values)
+	}
+	return values[0], nil
+}
+
+// extractPackageBoilerplateTag returns the path of the boilerplate file tagged
+// in the doc.go of pkg, relative to its directory, empty when there is none.
+func extractPackageBoilerplateTag(pkg *types.Package) string {
+	values := types.ExtractCommentTags("+", pkg.Comments)[boilerplateTagName]
+	if len(values) == 0 || values[0] == "" {
+		return ""
+	}
+	if filepath.IsAbs(values[0]) {
+		return values[0]
+	}
+	return filepath.Join(pkg.SourcePath, values[0])
+}
+
 func extractIgnoreTag(t *types.Type) bool {
 	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
 	values := types.ExtractCommentTags("+", comments)[ignoreTagName]
@@ -45,14 +132,111 @@ func extractIgnoreTag(t *types.Type) bool {
 	return false
 }
 
+func extractMemberIgnoreTag(m types.Member) bool {
+	values := types.ExtractCommentTags("+", m.CommentLines)[ignoreTagName]
+	if len(values) > 0 {
+		return values[0] == "true"
+	}
+	return reflect.StructTag(m.Tags).Get(structTagName) == "-"
+}
+
+// extractMemberRequiredTag reports whether m is tagged +builder-gen:required,
+// making it an argument of the New<T>Builder constructor.
+func extractMemberRequiredTag(m types.Member) bool {
+	values := types.ExtractCommentTags("+", m.CommentLines)[requiredTagName]
+	return len(values) > 0 && values[0] != "false"
+}
+
+// extractMemberJSONTag reports whether m is tagged +builder-gen:json, its
+// interface holding decoded JSON documents.
+func extractMemberJSONTag(m types.Member) bool {
+	values := types.ExtractCommentTags("+", m.CommentLines)[jsonTagName]
+	return len(values) > 0 && values[0] != "false"
+}
+
+// extractMemberEncodingTag returns the value of the +builder-gen:encoding
+// tag of m, the encoding of the strings its byte slice is set from.
+func extractMemberEncodingTag(m types.Member) string {
+	values := types.ExtractCommentTags("+", m.CommentLines)[encodingTagName]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// extractMemberCapTag returns the capacity of the +builder-gen:cap tag of m,
+// the slices and maps of the member are allocated with, or 0 if untagged.
+func extractMemberCapTag(m types.Member) int {
+	values := types.ExtractCommentTags("+", m.CommentLines)[capTagName]
+	if len(values) == 0 {
+		return 0
+	}
+	n, _ := strconv.Atoi(values[0])
+	return n
+}
+
+// checkCapTags returns an error if a +builder-gen:cap tag of the members of t
+// is not a positive number, or tags a member which is not a slice or a map.
+func checkCapTags(t *types.Type) error {
+	for _, m := range builderMembers(t) {
+		values := types.ExtractCommentTags("+", m.CommentLines)[capTagName]
+		if len(values) == 0 {
+			continue
+		}
+		if n, err := strconv.Atoi(values[0]); err != nil || n <= 0 {
+			return fmt.Errorf("%v.%s: the capacity %q of the %s tag must be a positive number", t, m.Name, values[0], capTagName)
+		}
+		if kind := underlyingType(m.Type).Kind; kind != types.Slice && kind != types.Map {
+			return fmt.Errorf("%v.%s: the %s tag only applies to slices and maps", t, m.Name, capTagName)
+		}
+	}
+	return nil
+}
+
+// hasRequiredMembers reports whether a member of t is tagged required.
+func hasRequiredMembers(t *types.Type) bool {
+	for _, m := range builderMembers(t) {
+		if extractMemberRequiredTag(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// builderMembers returns the members of t handled by its builder.
+func builderMembers(t *types.Type) []types.Member {
+	result := make([]types.Member, 0, len(t.Members))
+	for _, m := range t.Members {
+		if extractMemberIgnoreTag(m) || isProtobufInternalMember(t, m) {
+			continue
+		}
+		m.Type = inlineMemberType(t, m)
+		result = append(result, m)
+	}
+	return result
+}
+
 func extractNewMethodCallTag(t *types.Type) []string {
 	return extractTag(t, newMethodCallTagName)
 }
 
+// extractNewFuncTag returns the function of the +builder-gen:new-func tag of
+// t, creating the models of its New<T>Builder constructor.
+func extractNewFuncTag(t *types.Type) string {
+	if values := extractTag(t, newFuncTagName); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
 func extractEmbbedIgnoreMethodTag(t *types.Type) []string {
 	return extractTag(t, embeddedIgnoreMethodTagName)
 }
 
+func extractDeepCopyInterfacesTag(t *types.Type) []string {
+	return extractTag(t, deepCopyInterfacesTagName)
+}
+
 func extractTag(t *types.Type, tagName string) []string {
 	var result []string
 	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
@@ -72,6 +256,37 @@ func extractTag(t *types.Type, tagName string) []string {
 	return result
 }
 
+// docLines returns the comment lines of a declaration without the "+" tags
+// and the surrounding blank lines.
+func docLines(lines []string) []string {
+	var result []string
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "+") {
+			continue
+		}
+		if len(result) == 0 && strings.TrimSpace(line) == "" {
+			continue
+		}
+		result = append(result, line)
+	}
+	for len(result) > 0 && strings.TrimSpace(result[len(result)-1]) == "" {
+		result = result[:len(result)-1]
+	}
+	return result
+}
+
+// writeDoc writes the lines as a doc comment. The lines are passed as
+// arguments so that "$" in the comments is not taken as a template.
+func writeDoc(sw *generator.SnippetWriter, lines []string) {
+	for _, line := range lines {
+		if line == "" {
+			sw.Do("//\n", generator.Args{})
+			continue
+		}
+		sw.Do("// $.line$\n", generator.Args{"line": line})
+	}
+}
+
 // TODO: This is created only to reduce number of changes in a single PR.
 // Remove it and use PublicNamer instead.
 func deepCopyNamer() *namer.NameStrategy {
@@ -97,16 +312,115 @@ func DefaultNameSystem() string {
 	return "public"
 }
 
+// Packages returns the packages to generate, with the signature expected by
+// args.GeneratorArgs.Execute. It exits on errors.
 func Packages(context *generator.Context, arguments *args.GeneratorArgs) generator.Packages {
+	packages, err := packages(context, arguments, "")
+	if err != nil {
+		klog.Fatalf("%v", err)
+	}
+	return packages
+}
+
+// packages returns the packages to generate from the universe of context.
+// With goos set, the universe is parsed for that GOOS and only the builders
+// of the types declared by the files specific to it are generated, into files
+// suffixed by it. Otherwise these types are left out.
+func packages(context *generator.Context, arguments *args.GeneratorArgs, goos string) (generator.Packages, error) {
 	boilerplate, err := arguments.LoadGoBoilerplate()
 	if err != nil {
-		klog.Fatalf("Failed loading boilerplate: %v", err)
+		return nil, fmt.Errorf("Failed loading boilerplate: %v", err)
+	}
+
+	customArgs, ok := arguments.CustomArgs.(*CustomArgs)
+	if !ok {
+		return nil, fmt.Errorf("Unexpected custom arguments %T", arguments.CustomArgs)
 	}
 
-	inputs := sets.NewString(context.Inputs...)
+	inputs := sets.NewString()
+	for _, i := range context.Inputs {
+		if customArgs.skipsPackage(i) {
+			klog.V(3).Infof("Package %q is skipped", i)
+			continue
+		}
+		inputs.Insert(i)
+	}
+	if err := customArgs.findEnabledPackages(context.Universe); err != nil {
+		return nil, err
+	}
+	var cl *closure
+	if customArgs.Closure {
+		cl = computeClosure(context.Universe, inputs.Difference(customArgs.closurePackages), customArgs.closurePackages.Has, customArgs.generates)
+	}
+	mixins := mixinTargets(context.Universe, inputs, customArgs.generates)
+	var versions map[string][]string
+	if customArgs.ConvertVersions {
+		versions = apiVersionSiblings(context.Universe, inputs)
+	}
 	packages := generator.Packages{}
-	header := append([]byte(fmt.Sprintf("//go:build !%s\n// +build !%s\n\n", arguments.GeneratedBuildTag, arguments.GeneratedBuildTag)), boilerplate...)
+	graph := newImportGraph(context.Universe)
+	var planned []string
+	constraintHeader, err := customArgs.buildConstraintHeader(arguments.GeneratedBuildTag)
+	if err != nil {
+		return nil, fmt.Errorf("Failed building the header: %v", err)
+	}
+	defaults := &packageSettings{
+		outputFileBaseName: arguments.OutputFileBaseName,
+		header:             append(append([]byte{}, constraintHeader...), boilerplate...),
+		setterPrefix:       customArgs.SetterPrefix,
+	}
+	allSettings := []*packageSettings{defaults}
+	var groups []*groupSettings
+	for _, group := range customArgs.InputGroups {
+		g, err := newGroupSettings(arguments, constraintHeader, defaults, group)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+		allSettings = append(allSettings, g.settings)
+	}
+
+	var cache *generationCache
+	if goos != "" {
+		// Execute writes the files of the platforms with the file type of
+		// the other files, keeping them out of the cache.
+	} else if customArgs.DryRun {
+		context.FileTypes[generator.GolangFileType] = newDryRunFile()
+	} else if customArgs.Stdout {
+		context.FileTypes[generator.GolangFileType] = newStdoutFile()
+	} else {
+		context.FileTypes[generator.GolangFileType] = newUnchangedFile()
+		if customArgs.CacheFile != "" {
+			if cache, err = loadGenerationCache(customArgs.CacheFile); err != nil {
+				return nil, fmt.Errorf("Failed loading the cache: %v", err)
+			}
+			for _, settings := range allSettings {
+				if settings.fingerprint, err = generatorFingerprint(customArgs, settings); err != nil {
+					return nil, fmt.Errorf("Failed hashing the generator: %v", err)
+				}
+			}
+			context.FileTypes[generator.GolangFileType] = &cachingFile{
+				FileType: context.FileTypes[generator.GolangFileType],
+				cache:    cache,
+				graph:    graph,
+				report:   &customArgs.report,
+			}
+		}
+	}
+	if customArgs.JSONSchema && goos == "" {
+		writer := context.FileTypes[generator.GolangFileType]
+		if caching, ok := writer.(*cachingFile); ok {
+			writer = caching.FileType
+		}
+		if writer, ok := writer.(contentWriter); ok {
+			context.FileTypes[schemaFileType] = &schemaFile{writer: writer}
+		}
+	}
 
+	sourceCtx := customArgs.sourceContext(arguments.GeneratedBuildTag)
+	if goos != "" {
+		sourceCtx.GOOS = goos
+	}
 	for i := range inputs {
 		klog.V(5).Infof("Considering pkg %q", i)
 
@@ -116,7 +430,117 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 			continue
 		}
 
+		inClosure := customArgs.closurePackages.Has(i)
+		if inClosure && !cl.packages.Has(i) {
+			continue
+		}
+		if customArgs.OptIn && !customArgs.hasGeneratedTypes(pkg) {
+			klog.V(3).Infof("Package %q has no types to generate", i)
+			continue
+		}
+
 		klog.V(3).Infof("Package %q needs generation", i)
+		settings := defaults
+		for _, g := range groups {
+			if g.contains(pkg.SourcePath) {
+				settings = g.settings
+				break
+			}
+		}
+		if boilerplatePath := extractPackageBoilerplateTag(pkg); boilerplatePath != "" {
+			klog.V(3).Infof("Package %q uses the boilerplate %s", i, boilerplatePath)
+			override := *settings
+			if override.header, err = loadHeader(arguments, constraintHeader, boilerplatePath); err != nil {
+				return nil, fmt.Errorf("Package %v: %v", i, err)
+			}
+			if cache != nil {
+				if override.fingerprint, err = generatorFingerprint(customArgs, &override); err != nil {
+					return nil, fmt.Errorf("Failed hashing the generator: %v", err)
+				}
+			}
+			settings = &override
+		}
+		platforms, err := platformTypes(pkg.SourcePath, sourceCtx)
+		if err != nil {
+			return nil, fmt.Errorf("Failed reading the platform files of %q: %v", i, err)
+		}
+		platform := sets.NewString()
+		for _, names := range platforms {
+			platform = platform.Union(names)
+		}
+		inFile := func(t *types.Type) bool {
+			return !platform.Has(t.Name.Name)
+		}
+		baseName := settings.outputFileBaseName
+		if goos != "" {
+			generated := false
+			for _, name := range platforms[goos].List() {
+				if t := pkg.Types[name]; t != nil && customArgs.generates(t) {
+					generated = true
+					break
+				}
+			}
+			if !generated {
+				continue
+			}
+			inFile = func(t *types.Type) bool {
+				return platforms[goos].Has(t.Name.Name)
+			}
+			override := *settings
+			override.outputFileBaseName += "_" + goos
+			settings = &override
+		}
+		outputFileName := settings.outputFileBaseName + ".go"
+		declared, err := handWrittenSymbols(pkg, outputFileName, sourceCtx)
+		if err != nil {
+			return nil, fmt.Errorf("Failed reading the declarations of %q: %v", i, err)
+		}
+		constraints, err := typeConstraints(pkg, sourceCtx, outputFileName)
+		if err != nil {
+			return nil, fmt.Errorf("Failed reading the build constraints of %q: %v", i, err)
+		}
+		files := constrainedFiles(pkg, constraints, func(t *types.Type) bool {
+			return customArgs.generates(t) && (!inClosure || cl.has(t)) && inFile(t)
+		})
+		var goosConstraint []constraint.Expr
+		if goos != "" {
+			// The output file name has a dot before its suffix, which the go
+			// command does not read as a GOOS.
+			goosConstraint = append(goosConstraint, &constraint.TagExpr{Tag: goos})
+		}
+		if len(files) > 0 || goos != "" {
+			override := *settings
+			// The headers of the settings all start with the constraint of
+			// the flags, replaced by the one adding the sources.
+			boilerplate := bytes.TrimPrefix(settings.header, constraintHeader)
+			header, err := customArgs.buildConstraintHeader(arguments.GeneratedBuildTag, goosConstraint...)
+			if err != nil {
+				return nil, fmt.Errorf("Package %v: %v", i, err)
+			}
+			override.header = append(header, boilerplate...)
+			override.headers = map[string][]byte{}
+			for _, f := range files {
+				klog.V(3).Infof("Package %q has types constrained by %v: %v", i, f.expr, f.types.List())
+				header, err := customArgs.buildConstraintHeader(arguments.GeneratedBuildTag, append([]constraint.Expr{f.expr}, goosConstraint...)...)
+				if err != nil {
+					return nil, fmt.Errorf("Package %v: %v", i, err)
+				}
+				if goos != "" {
+					f.name += "_" + goos
+				}
+				override.headers[baseName+"_"+f.name+".go"] = append(header, boilerplate...)
+			}
+			if cache != nil {
+				if override.fingerprint, err = generatorFingerprint(customArgs, &override); err != nil {
+					return nil, fmt.Errorf("Failed hashing the generator: %v", err)
+				}
+			}
+			settings = &override
+		}
+		constrained := sets.NewString()
+		for _, f := range files {
+			constrained = constrained.Union(f.types)
+		}
 		path := pkg.Path
 		// if the source path is within a /vendor/ directory (for example,
 		// k8s.io/kubernetes/vendor/k8s.io/apimachinery/pkg/apis/meta/v1), allow
@@ -131,51 +555,250 @@ func Packages(context *generator.Context, arguments *args.GeneratorArgs) generat
 				path = expandedPath
 			}
 		}
-		packages = append(packages,
-			&generator.DefaultPackage{
+		// The packages added by --closure are written next to their sources,
+		// which Execute checked are under the output base.
+		if inClosure {
+			if path, err = closureOutputPath(arguments.OutputBase, pkg.SourcePath); err != nil {
+				return nil, fmt.Errorf("Failed locating %q: %v", i, err)
+			}
+		}
+		if cache != nil {
+			var siblings []*types.Package
+			for _, version := range versions[pkg.Path] {
+				siblings = append(siblings, context.Universe[version])
+			}
+			hash := packageHash(settings.fingerprint, pkg, declared, cl, mixins, siblings)
+			if entry, ok := cache.lookup(pkg.Path, hash); ok && fileExists(outputFilePath(arguments, path, outputFileName)) &&
+				(!customArgs.SmokeTests || fileExists(outputFilePath(arguments, path, smokeTestFileBaseName+".go"))) &&
+				(!customArgs.JSONSchema || fileExists(outputFilePath(arguments, path, settings.outputFileBaseName+schemaFileSuffix))) &&
+				filesExist(arguments, path, settings.headers) {
+				klog.V(2).Infof("Package %q did not change, skipping it", i)
+				graph.preload(pkg.Path, entry.Imports)
+				if len(entry.Warnings) > 0 {
+					klog.Warning(formatWarnings(pkg.Path, entry.Warnings))
+					customArgs.report.add(entry.Warnings...)
+				}
+				customArgs.summary.cached(pkg.Path, len(entry.Warnings))
+				continue
+			}
+			cache.expect(path, pkg.Path, hash)
+		}
+		customArgs.summary.expect(path, pkg.Path)
+		planner := newGenDeepCopy(settings.outputFileBaseName, pkg.Path, customArgs, graph, declared, cl, mixins)
+		planner.universe = context.Universe
+		planner.versions = versions[pkg.Path]
+		graph.preload(pkg.Path, planner.plannedImports(pkg, func(t *types.Type) bool {
+			return customArgs.generates(t) && (!inClosure || cl.has(t)) && inFile(t)
+		}))
+		planned = append(planned, pkg.Path)
+		pkgGenerators := func(outputFileBaseName string) (*genDeepCopy, []generator.Generator) {
+			gen := newGenDeepCopy(outputFileBaseName, pkg.Path, customArgs, graph, declared, cl, mixins)
+			gen.setterPrefix = settings.setterPrefix
+			gen.platform = goos
+			gen.versions = versions[pkg.Path]
+			generators := []generator.Generator{gen}
+			if customArgs.Equal {
+				generators = append(generators, newGenEqual(outputFileBaseName, pkg.Path, customArgs, declared))
+			}
+			if customArgs.DeepCopy {
+				generators = append(generators, newGenModelDeepCopy(outputFileBaseName, pkg.Path, customArgs, declared))
+			}
+			if customArgs.IsZero {
+				generators = append(generators, newGenIsZero(outputFileBaseName, pkg.Path, customArgs, declared))
+			}
+			if customArgs.Getters {
+				generators = append(generators, newGenGetters(outputFileBaseName, pkg.Path, customArgs, declared))
+			}
+			return gen, generators
+		}
+		packages = append(packages, &constrainedPackage{
+			DefaultPackage: generator.DefaultPackage{
 				PackageName: strings.Split(filepath.Base(pkg.Path), ".")[0],
 				PackagePath: path,
-				HeaderText:  header,
+				HeaderText:  settings.header,
 				GeneratorFunc: func(c *generator.Context) (generators []generator.Generator) {
-					return []generator.Generator{
-						NewGenDeepCopy(arguments.OutputFileBaseName, pkg.Path),
+					gen, generators := pkgGenerators(settings.outputFileBaseName)
+					if customArgs.SmokeTests && goos == "" {
+						generators = append(generators, newGenSmokeTest(pkg.Path, gen))
+					}
+					if len(files) > 0 {
+						for i := range generators {
+							generators[i] = &fileGenerator{Generator: generators[i], types: constrained, exclude: true}
+						}
 					}
+					if customArgs.JSONSchema && goos == "" {
+						generators = append(generators, newGenJSONSchema(settings.outputFileBaseName, pkg.Path, gen))
+					}
+					// The generators of the files of the constrained types
+					// run after those of the main file, whose declarations
+					// they reuse.
+					for _, f := range files {
+						fileGen, fileGenerators := pkgGenerators(baseName + "_" + f.name)
+						fileGen.main = gen
+						for _, g := range fileGenerators {
+							generators = append(generators, &fileGenerator{Generator: g, types: f.types})
+						}
+					}
+					return generators
 				},
 				FilterFunc: func(c *generator.Context, t *types.Type) bool {
-					return t.Name.Package == pkg.Path
+					return t.Name.Package == pkg.Path && (!inClosure || cl.has(t)) && inFile(t)
 				},
-			})
+			},
+			headers: settings.headers,
+		})
 	}
 
-	return packages
+	// The cycles are checked once the imports of every package are known,
+	// before any file is written.
+	for _, pkg := range planned {
+		if err := graph.check(pkg); err != nil {
+			return nil, err
+		}
+	}
+	return packages, nil
+}
+
+// outputFilePath returns where the file of the package generated into path
+// is written.
+func outputFilePath(arguments *args.GeneratorArgs, path, fileName string) string {
+	dir := filepath.Join(arguments.OutputBase, path)
+	if arguments.TrimPathPrefix != "" {
+		prefix := arguments.TrimPathPrefix
+		if !strings.HasSuffix(prefix, string(filepath.Separator)) {
+			prefix += string(filepath.Separator)
+		}
+		dir = strings.TrimPrefix(dir, prefix)
+	}
+	return filepath.Join(dir, fileName)
+}
+
+// filesExist reports whether the files of the package generated into path
+// named by the keys of files are all written.
+func filesExist(arguments *args.GeneratorArgs, path string, files map[string][]byte) bool {
+	for name := range files {
+		if !fileExists(outputFilePath(arguments, path, name)) {
+			return false
+		}
+	}
+	return true
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
 }
 
 // genDeepCopy produces a file with autogenerated deep-copy functions.
 type genDeepCopy struct {
 	generator.DefaultGen
 	targetPackage string
-	imports       namer.ImportTracker
+	imports       *originImportTracker
+	customArgs    *CustomArgs
+	graph         *importGraph
+	renamed       sets.String
+	// declared holds the functions and builder methods hand-written in the
+	// target package, which are not generated.
+	declared sets.String
+	closure  *closure
+	// mixins are the structs of other packages whose builders are mixed in
+	// by +builder-gen:mixin tags.
+	mixins sets.String
+	// setterPrefix is --setter-prefix, or the setter prefix of the input
+	// group of the package.
+	setterPrefix string
+	// initialisms are upper-cased in the names of the builder methods.
+	initialisms sets.String
+	// platform is the GOOS of the file of the builders of the types
+	// specific to it, which leaves the declarations shared by the builders
+	// of the package to the file of the other types.
+	platform string
+	// main is, for the files of the builders of the constrained types, the
+	// generator of the main file of the package, holding the declarations
+	// shared by the builders.
+	main *genDeepCopy
+	// versions are, with --convert-versions, the other API versions of the
+	// package, by import path.
+	versions []string
+	// converted are the functions converting the structs to the other API
+	// versions already written.
+	converted sets.String
+	// lists are the list builders already written.
+	lists sets.String
+	// universe holds the oneof wrappers of the protobuf messages.
+	universe types.Universe
+	warnings []Warning
+}
+
+// NewGenDeepCopy returns the builder generator of a package. After the
+// execution, the members it could not handle are listed by its
+// Warnings() []Warning method.
+func NewGenDeepCopy(sanitizedName, targetPackage string, customArgs *CustomArgs) generator.Generator {
+	return newGenDeepCopy(sanitizedName, targetPackage, customArgs, nil, nil, nil, nil)
 }
 
-func NewGenDeepCopy(sanitizedName, targetPackage string) generator.Generator {
+func newGenDeepCopy(sanitizedName, targetPackage string, customArgs *CustomArgs, graph *importGraph, declared sets.String, closure *closure, mixins sets.String) *genDeepCopy {
 	return &genDeepCopy{
 		DefaultGen: generator.DefaultGen{
 			OptionalName: sanitizedName,
 		},
 		targetPackage: targetPackage,
-		imports:       generator.NewImportTracker(),
+		imports:       newOriginImportTracker(generator.NewImportTracker()),
+		customArgs:    customArgs,
+		graph:         graph,
+		renamed:       sets.NewString(),
+		converted:     sets.NewString(),
+		lists:         sets.NewString(),
+		declared:      declared,
+		closure:       closure,
+		mixins:        mixins,
+		setterPrefix:  customArgs.SetterPrefix,
+		initialisms:   customArgs.initialisms(),
+	}
+}
+
+// warn records a member of t the builder has no method for.
+func (g *genDeepCopy) warn(t *types.Type, m types.Member, reason string) {
+	g.warnings = append(g.warnings, Warning{
+		Package: t.Name.Package,
+		Type:    t.Name.Name,
+		Member:  m.Name,
+		Reason:  reason,
+	})
+}
+
+// Warnings returns the members of the generated types the builders could not
+// handle.
+func (g *genDeepCopy) Warnings() []Warning {
+	result := append([]Warning{}, g.warnings...)
+	sortWarnings(result)
+	return result
+}
+
+// handWritten reports whether the target package already declares the
+// method of the builder of t, or the function when t is nil, in which case
+// it is not generated.
+func (g *genDeepCopy) handWritten(t *types.Type, name string) bool {
+	symbol := name
+	if t != nil {
+		symbol = t.Name.Name + "Builder." + name
+	}
+	if !g.declared.Has(symbol) {
+		return false
 	}
+	klog.V(2).Infof("Skipping %s, it is already declared in %s", symbol, g.targetPackage)
+	return true
 }
 
 func (g *genDeepCopy) Namers(c *generator.Context) namer.NameSystems {
 	// Have the raw namer for this file track what it imports.
 	return namer.NameSystems{
-		"raw": namer.NewRawNamer(g.targetPackage, g.imports),
+		"raw": g.customArgs.rawNamer(g.targetPackage, g.imports),
 	}
 }
 
 func (g *genDeepCopy) Filter(c *generator.Context, t *types.Type) bool {
-	if !copyableType(t) {
+	if !g.customArgs.generates(t) {
 		klog.V(2).Infof("Type %v is not copyable", t)
 		return false
 	}
@@ -197,282 +820,2034 @@ func copyableType(t *types.Type) bool {
 		return t.Underlying.Kind != types.Builtin || copyableType(t.Underlying)
 	}
 
-	if t.Kind != types.Struct {
+	if t.Kind != types.Struct || isProtobufOneofWrapper(t) {
 		return false
 	}
 
 	return true
 }
 
-func underlyingType(t *types.Type) *types.Type {
-	for t.Kind == types.Alias {
-		t = t.Underlying
+// generates reports whether t gets a builder. The enable tag of t takes
+// precedence, otherwise --opt-in requires the package tag.
+func (ca *CustomArgs) generates(t *types.Type) bool {
+	if parent := inlineParent(t); parent != nil {
+		return ca.generates(parent)
 	}
-	return t
-}
-
-func (g *genDeepCopy) isOtherPackage(pkg string) bool {
-	if pkg == g.targetPackage {
+	if !copyableType(t) {
 		return false
 	}
-	if strings.HasSuffix(pkg, "\""+g.targetPackage+"\"") {
+	if enabled, ok := extractEnabledTag(t); ok {
+		return enabled
+	}
+	if ca.includeTypes != nil && !ca.includeTypes.MatchString(t.Name.Name) {
 		return false
 	}
-	return true
+	if ca.excludeTypes != nil && ca.excludeTypes.MatchString(t.Name.Name) {
+		return false
+	}
+	return !ca.OptIn || ca.enabledPackages.Has(t.Name.Package)
 }
 
-func (g *genDeepCopy) Imports(c *generator.Context) (imports []string) {
-	importLines := []string{}
-	for _, singleImport := range g.imports.ImportLines() {
-		if g.isOtherPackage(singleImport) {
-			importLines = append(importLines, singleImport)
+// findEnabledPackages records the packages of the universe tagged
+// +builder-gen=package, and the type tags their doc.go sets for all their
+// types.
+func (ca *CustomArgs) findEnabledPackages(universe types.Universe) error {
+	ca.enabledPackages = sets.NewString()
+	ca.packageDefaults = map[string]map[string]string{}
+	for path, pkg := range universe {
+		// The packages of the input directories given relative to the
+		// working directory are keyed by them, while the other packages
+		// refer to their types by their import path.
+		paths := []string{path}
+		if build.IsLocalImport(path) {
+			if importPath := moduleImportPath(pkg.SourcePath); importPath != "" {
+				paths = append(paths, importPath)
+			}
+		}
+		defaults := extractPackageDefaultTags(pkg)
+		value, err := extractPackageEnabledTag(pkg)
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			if len(defaults) > 0 {
+				ca.packageDefaults[path] = defaults
+			}
+			if value == tagValuePackage {
+				ca.enabledPackages.Insert(path)
+			}
 		}
 	}
-	return importLines
+	return nil
 }
 
-func (g *genDeepCopy) Init(c *generator.Context, w io.Writer) error {
-	return nil
+// moduleImportPath returns the import path of the package of the directory
+// dir in the module declared by the closest go.mod above it, empty if none.
+func moduleImportPath(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	for root := dir; ; root = filepath.Dir(root) {
+		if data, err := os.ReadFile(filepath.Join(root, "go.mod")); err == nil {
+			module := modfile.ModulePath(data)
+			rel, err := filepath.Rel(root, dir)
+			if module == "" || err != nil {
+				return ""
+			}
+			return path.Join(module, filepath.ToSlash(rel))
+		}
+		if parent := filepath.Dir(root); parent == root {
+			return ""
+		}
+	}
 }
 
-func (g *genDeepCopy) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
-	klog.V(5).Infof("Generating deepcopy function for type %v", t)
+// hasGeneratedTypes reports whether some type of pkg gets a builder.
+func (ca *CustomArgs) hasGeneratedTypes(pkg *types.Package) bool {
+	for _, t := range pkg.Types {
+		if ca.generates(t) {
+			return true
+		}
+	}
+	return false
+}
 
-	sw := generator.NewSnippetWriter(w, c, "$", "$")
-	sw.Do("// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.\n", generator.Args{})
+// reservedMethodNames are declared by every builder, members with these names
+// get their setters renamed.
+var reservedMethodNames = sets.NewString("Build", "BuildPtr", "BuildObject", "BuildAndCreate", "BuildUnstructured", "ToApplyConfiguration", "String", "GoString", "Clone", "Err", "BuildSafe", "BuildContext")
 
-	g.newBuilderFunc(sw, t)
-	g.structBuilder(sw, t)
-	g.structMethods(sw, t)
-	g.structMethodBuild(sw, t)
+// reservedPropertyNames are identifiers the generated code uses for the
+// builder fields, methods and local variables, members lowering to one of
+// them get their properties escaped.
+var reservedPropertyNames = sets.NewString("model", "build", "b", "builder", "input", "v", "vv", "i", "val", "remove", "key", "data", "err", "errs")
 
-	return sw.Error()
+// propertyName returns the name of the unexported builder field, and of the
+// local variables, holding the state of the member, escaped like the reserved
+// names when it lowers to a Go keyword like type or func.
+func propertyName(m types.Member) string {
+	name := strings.ToLower(m.Name)
+	if reservedPropertyNames.Has(name) || token.IsKeyword(name) {
+		return name + "_"
+	}
+	return name
 }
 
-func (g *genDeepCopy) newBuilderFunc(sw *generator.SnippetWriter, t *types.Type) {
-	args := generator.Args{
-		"type": t,
-		"name": t.Name.Name,
+// parameterName returns the lower-camel name of the New<T>Builder parameter
+// setting the required member m, escaped when it lowers to a Go keyword or to
+// the builder local of the constructor.
+func parameterName(m types.Member) string {
+	runes := []rune(m.Name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
 	}
-	sw.Do("func New$.name$Builder() *$.type|raw$Builder {\n", args)
-	sw.Do("builder := &$.type|raw$Builder{}\n", args)
-	sw.Do("builder.model = $.type|raw${}\n", args)
-
-	callMethods := extractNewMethodCallTag(t)
-	for _, method := range callMethods {
-		sw.Do("builder.model.$.method$()\n", generator.Args{"method": method})
+	// The last upper-case letter before a lower-case one starts a word.
+	if upper > 1 && upper < len(runes) {
+		upper--
+	}
+	for i := 0; i < upper; i++ {
+		runes[i] = unicode.ToLower(runes[i])
 	}
+	name := string(runes)
+	if name == "builder" || token.IsKeyword(name) {
+		return name + "_"
+	}
+	return name
+}
 
-	for _, m := range t.Members {
-		mt := m.Type
-		umt := underlyingType(mt)
-		// pumt := umt
-		if umt.Kind == types.Pointer {
-			umt = umt.Elem
-		}
+// memberName returns the name the builder methods of the member are derived
+// from: the Go field name, or the camel-cased json name with
+// --json-setter-names, its initialisms upper-cased.
+func (g *genDeepCopy) memberName(m types.Member) string {
+	return initialismName(g.baseName(m), g.initialisms)
+}
 
-		argsMember := generator.Args{
-			"name":       types.ParseFullyQualifiedName(umt.Name.Name).Name,
-			"nameMethod": strings.ToLower(m.Name),
-		}
-		if umt.Kind == types.Slice {
-			if !umt.Elem.IsPrimitive() {
-				sw.Do("builder.$.nameMethod$ = []*$.name$Builder{}\n", argsMember)
-			}
-		} else if umt.Kind == types.Map {
-			if !g.isOtherPackage(umt.Name.Package) || !g.isOtherPackage(types.ParseFullyQualifiedName(umt.Name.Name).Package) {
-				if !umt.Elem.IsPrimitive() {
-					argsMember["mapKey"] = umt.Key.Name.Name
-					sw.Do("builder.$.nameMethod$ = map[$.mapKey$]*$.name$Builder{}\n", argsMember)
-				}
-			}
-		} else if umt.Kind == types.Struct && mt.Kind != types.Pointer {
-			if m.Embedded {
-				sw.Do("builder.$.name$Builder = *New$.name$Builder()\n", argsMember)
-			} else if !g.isOtherPackage(umt.Name.Package) || !g.isOtherPackage(types.ParseFullyQualifiedName(umt.Name.Name).Package) {
-				sw.Do("builder.$.nameMethod$ = New$.name$Builder()\n", argsMember)
-			}
-		}
+// baseName returns the Go field name of m, or its camel-cased json name with
+// --json-setter-names.
+func (g *genDeepCopy) baseName(m types.Member) string {
+	if !g.customArgs.JSONSetterNames {
+		return m.Name
 	}
-	sw.Do("return builder\n", generator.Args{})
-	sw.Do("}\n\n", generator.Args{})
+	jsonName := strings.Split(reflect.StructTag(m.Tags).Get("json"), ",")[0]
+	if jsonName == "" || jsonName == "-" {
+		return m.Name
+	}
+	if name := camelCase(jsonName); name != "" {
+		return name
+	}
+	return m.Name
+}
+
+// camelCase converts names like display_name, display-name or displayName
+// into the exported DisplayName. It returns an empty string if the name can't
+// be turned into an identifier.
+func camelCase(name string) string {
+	var result strings.Builder
+	upper := true
+	for _, r := range name {
+		switch {
+		case r == '_' || r == '-' || r == '.' || r == ' ':
+			upper = true
+		case unicode.IsLetter(r) || (unicode.IsDigit(r) && result.Len() > 0):
+			if upper {
+				r = unicode.ToUpper(r)
+				upper = false
+			}
+			result.WriteRune(r)
+		default:
+			return ""
+		}
+	}
+	return result.String()
+}
+
+// methodName returns the name of the builder method setting the member,
+// prefixing it with the setter prefix, or with Set when it conflicts with a
+// builder method.
+func (g *genDeepCopy) methodName(t *types.Type, m types.Member) string {
+	base := g.memberName(m)
+	if prefix := g.typeSetterPrefix(t); prefix != "" {
+		return prefix + base
+	}
+	if !g.reservedMethodName(t, base) {
+		return base
+	}
+	name := "Set" + base
+	if key := t.Name.String() + "." + m.Name; !g.renamed.Has(key) {
+		g.renamed.Insert(key)
+		klog.Warningf("Member %s of %v conflicts with the builder method %s(), generating %s() instead", m.Name, t, base, name)
+	}
+	return name
+}
+
+// reservedMethodName reports whether the builder of t declares the method
+// name, its Build and BuildPtr methods renamed by a +builder-gen:build-name
+// tag.
+func (g *genDeepCopy) reservedMethodName(t *types.Type, name string) bool {
+	if build := g.buildName(t); name == build || name == build+"Ptr" {
+		return true
+	}
+	return name != "Build" && name != "BuildPtr" && reservedMethodNames.Has(name)
+}
+
+// buildName returns the name of the method of the builder of t returning the
+// model: Build, or the name of the +builder-gen:build-name tag of t or of the
+// doc.go of its package.
+func (g *genDeepCopy) buildName(t *types.Type) string {
+	if name, ok := g.customArgs.typeTag(t, buildNameTagName); ok && name != "" {
+		return name
+	}
+	return "Build"
+}
+
+// checkBuildName returns an error if the +builder-gen:build-name tag of t
+// names no exported identifier, or another method of the builder.
+func (g *genDeepCopy) checkBuildName(t *types.Type) error {
+	name := g.buildName(t)
+	if name == "Build" {
+		return nil
+	}
+	if !token.IsIdentifier(name) || !token.IsExported(name) || reservedMethodNames.Has(name) {
+		return fmt.Errorf("%v: the name %s of the %s tag must be an exported Go identifier, not declared by the builders", t, name, buildNameTagName)
+	}
+	return nil
+}
+
+// isKubernetesObject reports whether t is a Kubernetes API type implementing
+// runtime.Object, either by a visible DeepCopyObject method or by the
+// deepcopy-gen tag requesting it (deepcopy-gen output is excluded from the
+// parse by its build tag).
+func isKubernetesObject(t *types.Type) bool {
+	if !embedsTypeMeta(t) {
+		return false
+	}
+
+	if _, ok := t.Methods["DeepCopyObject"]; ok {
+		return true
+	}
+	for _, intf := range extractDeepCopyInterfacesTag(t) {
+		if intf == runtimeObjectName.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// embedsTypeMeta reports whether t embeds metav1.TypeMeta.
+func embedsTypeMeta(t *types.Type) bool {
+	for _, m := range t.Members {
+		if m.Embedded && m.Type.Name == typeMetaName {
+			return true
+		}
+	}
+	return false
+}
+
+// builderType returns the type behind t's aliases and pointer, which is the
+// model of the builder t is handled with, if any.
+func builderType(t *types.Type) *types.Type {
+	t = underlyingType(t)
+	if t.Kind == types.Pointer {
+		t = underlyingType(t.Elem)
+	}
+	return t
+}
+
+// builderOf returns the builder type of the struct t, named for the raw namer
+// to qualify it when t is declared in another package.
+func builderOf(t *types.Type) *types.Type {
+	return &types.Type{Name: types.Name{Package: t.Name.Package, Name: t.Name.Name + "Builder"}}
+}
+
+// newBuilderOf returns the constructor of the builder of t.
+func (g *genDeepCopy) newBuilderOf(t *types.Type) *types.Type {
+	return &types.Type{Name: types.Name{Package: t.Name.Package, Name: g.customArgs.constructorPrefix() + t.Name.Name + "Builder"}}
+}
+
+// fromModelOf returns the constructor of the builder of t populated from a
+// model, generated with --closure.
+func (g *genDeepCopy) fromModelOf(t *types.Type) *types.Type {
+	return &types.Type{Name: types.Name{Package: t.Name.Package, Name: g.newBuilderOf(t).Name.Name + "FromModel"}}
+}
+
+// underlyingType returns the type behind all the aliases of t, like []*C for
+// A in type A = B; type B []*C. The members are handled by the kind of their
+// underlying type, and named by their own type, like the keys of their maps.
+func underlyingType(t *types.Type) *types.Type {
+	for t.Kind == types.Alias {
+		t = t.Underlying
+	}
+	return t
+}
+
+func (g *genDeepCopy) isOtherPackage(pkg string) bool {
+	if pkg == g.targetPackage {
+		return false
+	}
+	if strings.HasSuffix(pkg, "\""+g.targetPackage+"\"") {
+		return false
+	}
+	return true
+}
+
+// isLocalType reports whether t is declared in the target package, and
+// therefore gets a builder in the same generated file.
+func (g *genDeepCopy) isLocalType(t *types.Type) bool {
+	return !g.isOtherPackage(t.Name.Package) || !g.isOtherPackage(types.ParseFullyQualifiedName(t.Name.Name).Package)
+}
+
+// hasBuilder reports whether t refers to a struct getting a builder in the
+// generated file, or with --closure in the file generated for its package.
+// Members referring to any other type, including structs of other packages
+// behind local aliases, are set as raw values.
+func (g *genDeepCopy) hasBuilder(t *types.Type) bool {
+	t = builderType(t)
+	if t.Kind != types.Struct || !g.customArgs.generates(t) {
+		return false
+	}
+	// The builders of types with required members can only be created with
+	// their arguments, the members holding them are set as values.
+	return !hasRequiredMembers(t) && (g.isLocalType(t) || g.closure.has(t))
+}
+
+// hasNestedBuilder reports whether the member m of t is set through nested
+// builders rather than a value setter.
+func (g *genDeepCopy) hasNestedBuilder(t *types.Type, m types.Member) bool {
+	umt := underlyingType(m.Type)
+	if umt.Kind == types.Pointer {
+		umt = umt.Elem
+	}
+	if umt.Kind == types.Slice || umt.Kind == types.Map {
+		return g.hasBuilder(umt.Elem) || g.builderMapSlice(m) != nil || g.builderLists(m) != nil
+	}
+	return g.namedList(m) != nil || umt.Kind == types.Struct && g.memberBuilder(t, m, umt)
+}
+
+// memberBuilder reports whether the struct umt of the member m of t is set
+// through its builder, the embedded structs tagged +builder-gen:embedded-value
+// being set by value and the mixins using the builders of other packages.
+func (g *genDeepCopy) memberBuilder(t *types.Type, m types.Member, umt *types.Type) bool {
+	return (g.hasBuilder(umt) || g.mixin(t, m)) && !embeddedValue(t, m)
+}
+
+// requiredMembers returns the members of t set from the arguments of its
+// New<T>Builder constructor. Members with nested builders can't be required.
+func (g *genDeepCopy) requiredMembers(t *types.Type) []types.Member {
+	var result []types.Member
+	for _, m := range builderMembers(t) {
+		if extractMemberRequiredTag(m) && !g.hasNestedBuilder(t, m) {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// constructorOf returns the function creating an empty builder of t, the
+// unexported new<T>Builder when New<T>Builder takes the required members.
+func (g *genDeepCopy) constructorOf(t *types.Type) *types.Type {
+	if g.isLocalType(t) && len(g.requiredMembers(t)) > 0 {
+		name := g.newBuilderOf(t).Name.Name
+		return &types.Type{Name: types.Name{Package: t.Name.Package, Name: strings.ToLower(name[:1]) + name[1:]}}
+	}
+	return g.newBuilderOf(t)
+}
+
+func (g *genDeepCopy) Imports(c *generator.Context) (imports []string) {
+	importLines := []string{}
+	for _, singleImport := range g.imports.ImportLines() {
+		if g.isOtherPackage(singleImport) {
+			importLines = append(importLines, singleImport)
+		}
+	}
+	return importLines
+}
+
+func (g *genDeepCopy) Init(c *generator.Context, w io.Writer) error {
+	g.universe = c.Universe
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	if g.main != nil {
+		g.lists = g.lists.Union(g.main.lists)
+		g.converted = g.converted.Union(g.main.converted)
+		return nil
+	}
+	if g.platform != "" {
+		return nil
+	}
+	if g.needsStructValidator(c) && !g.declared.Has(structValidatorName) {
+		args := generator.Args{
+			"name":         structValidatorName,
+			"validatorNew": validatorNewFunc,
+		}
+		sw.Do("// $.name$ validates the models carrying validate struct tags, caching\n", args)
+		sw.Do("// their rules.\n", args)
+		sw.Do("var $.name$ = $.validatorNew|raw$()\n\n", args)
+	}
+	if !g.needsBuilderErrors(c) || g.customArgs.joinErrors() || g.declared.Has(builderErrorsName) {
+		return sw.Error()
+	}
+	args := generator.Args{
+		"name": builderErrorsName,
+		"join": joinFunc,
+	}
+	sw.Do("// $.name$ are the errors recorded by the builders of the package.\n", args)
+	sw.Do("type $.name$ []error\n\n", args)
+	sw.Do("func (errs $.name$) Error() string {\n", args)
+	sw.Do("messages := make([]string, len(errs))\n", args)
+	sw.Do("for i, err := range errs {\n", args)
+	sw.Do("messages[i] = err.Error()\n", args)
+	sw.Do("}\n", args)
+	sw.Do("return $.join|raw$(messages, \"\\n\")\n", args)
+	sw.Do("}\n\n", args)
+	sw.Do("// Unwrap returns the errors, for errors.Is and errors.As.\n", args)
+	sw.Do("func (errs $.name$) Unwrap() []error {\n", args)
+	sw.Do("return errs\n", args)
+	sw.Do("}\n\n", args)
+	sw.Do("// err returns nil without errors, the error when there is only one.\n", args)
+	sw.Do("func (errs $.name$) err() error {\n", args)
+	sw.Do("switch len(errs) {\n", args)
+	sw.Do("case 0:\n", args)
+	sw.Do("return nil\n", args)
+	sw.Do("case 1:\n", args)
+	sw.Do("return errs[0]\n", args)
+	sw.Do("}\n", args)
+	sw.Do("return errs\n", args)
+	sw.Do("}\n\n", args)
+	return sw.Error()
+}
+
+// Finalize reports the members the builders could not handle, failing with
+// --strict, and refuses to write the file if its imports close an import
+// cycle packages() did not plan.
+func (g *genDeepCopy) Finalize(c *generator.Context, w io.Writer) error {
+	if warnings := g.Warnings(); len(warnings) > 0 {
+		g.customArgs.report.add(warnings...)
+		g.customArgs.summary.addWarnings(g.targetPackage, len(warnings))
+		if g.customArgs.Strict {
+			return fmt.Errorf("%s", formatWarnings(g.targetPackage, warnings))
+		}
+		klog.Warning(formatWarnings(g.targetPackage, warnings))
+	}
+
+	if g.graph == nil {
+		return nil
+	}
+	imports := map[string][]string{}
+	for pkg, origins := range g.imports.origins {
+		if g.isOtherPackage(pkg) {
+			imports[pkg] = origins
+		}
+	}
+	return g.graph.add(g.targetPackage, imports)
+}
+
+func (g *genDeepCopy) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	klog.V(5).Infof("Generating deepcopy function for type %v", t)
+	g.imports.current = t
+
+	// The builder methods are counted for the summary of the run.
+	counter := &declarationCounter{w: w, prefix: "func (b *"}
+	sw := generator.NewSnippetWriter(counter, c, "$", "$")
+
+	if t.Kind == types.Alias && g.isBuilderList(t) {
+		g.listBuilder(sw, t)
+		return sw.Error()
+	}
+	g.checkMixins(t)
+	if err := g.checkBuildName(t); err != nil {
+		return err
+	}
+	if err := g.checkSetterPrefix(t); err != nil {
+		return err
+	}
+	if err := checkCapTags(t); err != nil {
+		return err
+	}
+	if err := checkOneofTags(t); err != nil {
+		return err
+	}
+	if err := checkGVKTag(t); err != nil {
+		return err
+	}
+	if err := g.checkTypeHandlers(t); err != nil {
+		return err
+	}
+	if err := g.newBuilderFunc(sw, c, t); err != nil {
+		return err
+	}
+	g.newValueFunc(sw, t)
+	g.newBuilderFromYAMLFunc(sw, t)
+	g.newBuilderFromModelFunc(sw, t)
+	g.structBuilder(sw, t)
+	g.structMethodCopyOnWrite(sw, t)
+	setters := counter.count
+	g.structMethods(sw, t)
+	g.customArgs.summary.addType(g.targetPackage, counter.count-setters)
+	g.structMethodBuild(sw, t)
+	g.structMethodBuildPtr(sw, t)
+	g.structMethodErr(sw, t)
+	if err := g.structMethodBuildSafe(sw, t); err != nil {
+		return err
+	}
+	if err := g.structMethodBuildContext(sw, t); err != nil {
+		return err
+	}
+	g.structMethodBuildObject(sw, t)
+	g.structMethodBuildAndCreate(sw, t)
+	g.structMethodBuildUnstructured(sw, t)
+	g.structMethodToApplyConfiguration(sw, t)
+	g.structMethodsConvertTo(sw, t)
+	g.structMethodString(sw, t)
+	g.structMethodGoString(sw, t)
+	g.structMethodClone(sw, t)
+	g.structMethodUnmarshalJSON(sw, t)
+	g.structMethodFromModel(sw, t)
+	g.listBuilders(sw, t)
+
+	for _, st := range inlineStructsOf(t) {
+		if g.hasBuilder(st) {
+			g.inlineStructAlias(sw, st)
+			if err := g.GenerateType(c, st, w); err != nil {
+				return err
+			}
+		}
+	}
+
+	return sw.Error()
+}
+
+func (g *genDeepCopy) newBuilderFunc(sw *generator.SnippetWriter, c *generator.Context, t *types.Type) error {
+	constructor := g.constructorOf(t)
+	args := generator.Args{
+		"type":        t,
+		"name":        t.Name.Name,
+		"constructor": constructor.Name.Name,
+	}
+	required := g.requiredMembers(t)
+	if len(required) > 0 {
+		g.newRequiredBuilderFunc(sw, t, required)
+	}
+	if g.handWritten(nil, constructor.Name.Name) {
+		return nil
+	}
+	if len(required) > 0 {
+		sw.Do("// $.constructor$ creates a builder for $.name$ without its required members.\n", args)
+	} else {
+		sw.Do("// $.constructor$ creates a builder for $.name$.\n", args)
+		if doc := docLines(t.CommentLines); len(doc) > 0 {
+			sw.Do("//\n", generator.Args{})
+			writeDoc(sw, doc)
+		}
+	}
+	sw.Do("func $.constructor$() *$.type|raw$Builder {\n", args)
+	sw.Do("builder := &$.type|raw$Builder{}\n", args)
+	if err := g.newFuncModel(sw, c, t); err != nil {
+		return err
+	}
+	g.newGroupVersionKind(sw, t)
+
+	callMethods := extractNewMethodCallTag(t)
+	for _, method := range callMethods {
+		g.newMethodCall(sw, t, method)
+	}
+
+	for _, m := range builderMembers(t) {
+		mt := m.Type
+		umt := underlyingType(mt)
+		// pumt := umt
+		if umt.Kind == types.Pointer {
+			umt = umt.Elem
+		}
+
+		argsMember := generator.Args{
+			"embedded":   embeddedField(m),
+			"name":       umt.Name.Name,
+			"nameMethod": propertyName(m),
+			"builder":    builderOf(umt),
+			"newBuilder": g.constructorOf(umt),
+			"cap":        extractMemberCapTag(m),
+		}
+		// The pointers to slices and maps of nested builders, like *[]T,
+		// stay nil until a nested builder is added.
+		pointer := underlyingType(mt).Kind == types.Pointer
+		if mapType := g.builderMapSlice(m); mapType != nil {
+			if extractMemberCapTag(m) > 0 {
+				sw.Do("builder.$.nameMethod$ = make([]map[$.mapKey|raw$]*$.builder|raw$, 0, $.cap$)\n", g.mapSliceArgs(t, m, mapType))
+			}
+		} else if list := g.builderLists(m); list != nil {
+			if args := g.listsArgs(t, m, list); extractMemberCapTag(m) > 0 && umt.Kind == types.Map {
+				sw.Do("builder.$.nameMethod$ = make("+args["lists"].(string)+", $.cap$)\n", args)
+			} else if extractMemberCapTag(m) > 0 {
+				sw.Do("builder.$.nameMethod$ = make("+args["lists"].(string)+", 0, $.cap$)\n", args)
+			}
+		} else if umt.Kind == types.Slice {
+			if g.hasBuilder(umt.Elem) && !pointer && g.namedList(m) == nil {
+				argsMember["builder"] = builderOf(builderType(umt.Elem))
+				if extractMemberCapTag(m) > 0 {
+					sw.Do("builder.$.nameMethod$ = make([]*$.builder|raw$, 0, $.cap$)\n", argsMember)
+				} else {
+					sw.Do("builder.$.nameMethod$ = []*$.builder|raw${}\n", argsMember)
+				}
+			}
+		} else if umt.Kind == types.Map {
+			if g.hasBuilder(umt.Elem) && !pointer {
+				argsMember["builder"] = builderOf(builderType(umt.Elem))
+				argsMember["mapKey"] = umt.Key
+				if extractMemberCapTag(m) > 0 {
+					sw.Do("builder.$.nameMethod$ = make(map[$.mapKey|raw$]*$.builder|raw$, $.cap$)\n", argsMember)
+				} else {
+					sw.Do("builder.$.nameMethod$ = map[$.mapKey|raw$]*$.builder|raw${}\n", argsMember)
+				}
+			}
+		} else if umt.Kind == types.Struct && mt.Kind != types.Pointer {
+			// Only value struct members are allocated eagerly. Go rejects
+			// recursive value types, so this never loops for self or mutually
+			// referencing types; pointer members are allocated on first access.
+			if g.embedsBuilder(t, m, umt) {
+				sw.Do("builder.$.embedded$ = *$.newBuilder|raw$()\n", argsMember)
+			} else if g.memberBuilder(t, m, umt) {
+				sw.Do("builder.$.nameMethod$ = $.newBuilder|raw$()\n", argsMember)
+			}
+		}
+	}
+	sw.Do("return builder\n", generator.Args{})
+	sw.Do("}\n\n", generator.Args{})
+	return nil
+}
+
+// newFuncModel writes the creation of the model of the builder created by
+// the constructor of t, by the function of its +builder-gen:new-func tag if
+// any. The function may return the model or a pointer to it, and an error
+// handled as set by --new-call-errors.
+func (g *genDeepCopy) newFuncModel(sw *generator.SnippetWriter, c *generator.Context, t *types.Type) error {
+	name := extractNewFuncTag(t)
+	args := generator.Args{
+		"type": t,
+		"func": &types.Type{Name: types.Name{Package: t.Name.Package, Name: name}},
+	}
+	if name == "" {
+		sw.Do("builder.model = $.type|raw${}\n", args)
+		return nil
+	}
+
+	var sig *types.Signature
+	if pkg := c.Universe.Package(t.Name.Package); pkg != nil {
+		if fn := pkg.Functions[name]; fn != nil && fn.Underlying != nil {
+			sig = fn.Underlying.Signature
+		}
+	}
+	if sig == nil || len(sig.Parameters) > 0 {
+		return fmt.Errorf("%v: the function %s of the %s tag must be declared in %s without parameters", t, name, newFuncTagName, t.Name.Package)
+	}
+	results := sig.Results
+	pointer := len(results) > 0 && results[0].Kind == types.Pointer && results[0].Elem.Name == t.Name
+	if len(results) == 0 || (results[0].Name != t.Name && !pointer) ||
+		len(results) > 2 || (len(results) == 2 && results[1].Name != types.Name{Name: "error"}) {
+		return fmt.Errorf("%v: the function %s of the %s tag must return %s or *%s, and optionally an error", t, name, newFuncTagName, t.Name.Name, t.Name.Name)
+	}
+
+	if len(results) == 1 {
+		if pointer {
+			sw.Do("builder.model = *$.func|raw$()\n", args)
+		} else {
+			sw.Do("builder.model = $.func|raw$()\n", args)
+		}
+		return nil
+	}
+	if g.customArgs.NewCallErrors == newCallErrorsIgnore {
+		sw.Do("model, _ := $.func|raw$()\n", args)
+	} else {
+		sw.Do("model, err := $.func|raw$()\n", args)
+		sw.Do("if err != nil {\n", args)
+		g.newCallError(sw, name)
+		sw.Do("}\n", args)
+	}
+	if pointer {
+		sw.Do("if model != nil {\n", args)
+		sw.Do("builder.model = *model\n", args)
+		sw.Do("}\n", args)
+	} else {
+		sw.Do("builder.model = model\n", args)
+	}
+	return nil
+}
+
+// newMethodCall writes the call of method on the model of the builder
+// created by the constructor of t, handling the error it returns as set by
+// --new-call-errors.
+func (g *genDeepCopy) newMethodCall(sw *generator.SnippetWriter, t *types.Type, method string) {
+	args := generator.Args{
+		"method": method,
+	}
+	switch {
+	case !returnsError(t, method):
+		sw.Do("builder.model.$.method$()\n", args)
+	case g.customArgs.NewCallErrors == newCallErrorsIgnore:
+		sw.Do("_ = builder.model.$.method$()\n", args)
+	default:
+		sw.Do("if err := builder.model.$.method$(); err != nil {\n", args)
+		g.newCallError(sw, method)
+		sw.Do("}\n", args)
+	}
+}
+
+// newCallError writes the handling of the error err returned by the function
+// or method name called by a constructor, panicking or recording it.
+func (g *genDeepCopy) newCallError(sw *generator.SnippetWriter, name string) {
+	args := generator.Args{
+		"name":   name,
+		"errorf": errorfFunc,
+	}
+	if g.customArgs.NewCallErrors == newCallErrorsRecord {
+		sw.Do("builder.errs = append(builder.errs, $.errorf|raw$(\"$.name$: %w\", err))\n", args)
+	} else {
+		sw.Do("panic(err)\n", args)
+	}
+}
+
+// returnsError reports whether the method of t, or of its pointer, only
+// returns an error.
+func returnsError(t *types.Type, name string) bool {
+	method, ok := t.Methods[name]
+	if !ok || method.Signature == nil {
+		return false
+	}
+	results := method.Signature.Results
+	return len(results) == 1 && results[0].Name == types.Name{Name: "error"}
+}
+
+// allocateSlice writes the allocation of the nil slice of the model the Add
+// and Append setters of m append to, with the capacity of its
+// +builder-gen:cap tag. The setters of --copy-on-write copy the slices they
+// append to, which keep no capacity.
+func (g *genDeepCopy) allocateSlice(sw *generator.SnippetWriter, m types.Member, argsMember generator.Args) {
+	if g.customArgs.CopyOnWrite || extractMemberCapTag(m) == 0 {
+		return
+	}
+	sw.Do("if b.model.$.name$ == nil {\n", argsMember)
+	sw.Do("b.model.$.name$ = make($.typeAlias|raw$, 0, $.cap$)\n", argsMember)
+	sw.Do("}\n", argsMember)
+}
+
+// isPrimitiveSlice reports whether t is a slice, not behind a pointer, of
+// primitive values other than bytes or of maps of primitive values, whose
+// builders can append to it.
+func isPrimitiveSlice(t *types.Type) bool {
+	t = underlyingType(t)
+	if t.Kind != types.Slice {
+		return false
+	}
+	elem := underlyingType(t.Elem)
+	if isPrimitiveMap(elem) {
+		return true
+	}
+	return elem.Kind == types.Builtin && elem.Name.Name != "byte" && elem.Name.Name != "uint8"
+}
+
+// isByteSlice reports whether t is a slice of bytes, not behind a pointer.
+func isByteSlice(t *types.Type) bool {
+	t = underlyingType(t)
+	if t.Kind != types.Slice {
+		return false
+	}
+	elem := underlyingType(t.Elem)
+	return elem.Kind == types.Builtin && (elem.Name.Name == "byte" || elem.Name.Name == "uint8")
+}
+
+// isPrimitiveMap reports whether t is a map, not behind a pointer, of
+// primitive values, whose builders can set its entries.
+func isPrimitiveMap(t *types.Type) bool {
+	t = underlyingType(t)
+	return t.Kind == types.Map && underlyingType(t.Elem).Kind == types.Builtin
+}
+
+// isSimpleStruct reports whether all the members of t have primitive types,
+// possibly behind aliases and named types.
+func isSimpleStruct(t *types.Type) bool {
+	if len(t.Members) == 0 {
+		return false
+	}
+	for _, m := range t.Members {
+		if underlyingType(m.Type).Kind != types.Builtin {
+			return false
+		}
+	}
+	return true
+}
+
+// newValueFunc generates, with --all-args-constructors, a New<T> constructor
+// of the simple structs taking all their members.
+func (g *genDeepCopy) newValueFunc(sw *generator.SnippetWriter, t *types.Type) {
+	if !g.customArgs.AllArgsConstructors || !isSimpleStruct(t) || g.handWritten(nil, "New"+t.Name.Name) {
+		return
+	}
+
+	args := generator.Args{
+		"type": t,
+		"name": t.Name.Name,
+	}
+	var params []string
+	for _, m := range t.Members {
+		params = append(params, propertyName(m)+" $.type"+m.Name+"|raw$")
+		args["type"+m.Name] = m.Type
+	}
+
+	sw.Do("// New$.name$ returns a $.name$ holding the arguments.\n", args)
+	sw.Do("func New$.name$("+strings.Join(params, ", ")+") $.type|raw$ {\n", args)
+	sw.Do("return $.type|raw${\n", args)
+	for _, m := range t.Members {
+		sw.Do("$.name$: $.property$,\n", generator.Args{"name": m.Name, "property": propertyName(m)})
+	}
+	sw.Do("}\n", args)
+	sw.Do("}\n\n", args)
+}
+
+// newRequiredBuilderFunc generates the New<T>Builder constructor of a type
+// with required members, taking them as arguments.
+func (g *genDeepCopy) newRequiredBuilderFunc(sw *generator.SnippetWriter, t *types.Type, required []types.Member) {
+	if g.handWritten(nil, g.newBuilderOf(t).Name.Name) {
+		return
+	}
+	for _, m := range builderMembers(t) {
+		if extractMemberRequiredTag(m) && g.hasNestedBuilder(t, m) {
+			klog.Warningf("Member %s of %v has a nested builder and can't be required, ignoring its %s tag", m.Name, t, requiredTagName)
+		}
+	}
+
+	args := generator.Args{
+		"type":        t,
+		"name":        t.Name.Name,
+		"newBuilder":  g.newBuilderOf(t).Name.Name,
+		"constructor": g.constructorOf(t).Name.Name,
+	}
+	var params []string
+	for _, m := range required {
+		params = append(params, parameterName(m)+" $.type"+m.Name+"|raw$")
+		args["type"+m.Name] = m.Type
+	}
+	args["params"] = strings.Join(params, ", ")
+
+	sw.Do("// $.newBuilder$ creates a builder for $.name$ with its required members.\n", args)
+	if doc := docLines(t.CommentLines); len(doc) > 0 {
+		sw.Do("//\n", generator.Args{})
+		writeDoc(sw, doc)
+	}
+	sw.Do("func $.newBuilder$("+args["params"].(string)+") *$.type|raw$Builder {\n", args)
+	sw.Do("builder := $.constructor$()\n", args)
+	for _, m := range required {
+		sw.Do("builder.model.$.name$ = $.parameter$\n", generator.Args{"name": m.Name, "parameter": parameterName(m)})
+	}
+	sw.Do("return builder\n", args)
+	sw.Do("}\n\n", args)
 }
 
 func (g *genDeepCopy) structBuilder(sw *generator.SnippetWriter, t *types.Type) {
 	args := generator.Args{
-		"type": t,
+		"type": t,
+	}
+	sw.Do("type $.type|raw$Builder struct {\n", args)
+	sw.Do("model $.type|raw$\n", args)
+	if g.customArgs.AccumulateErrors {
+		sw.Do("// errs are the errors of the setters called.\n", args)
+		sw.Do("errs []error\n", args)
+	}
+	for _, m := range builderMembers(t) {
+		mt := m.Type
+		umt := underlyingType(mt)
+		// pumt := umt
+		if umt.Kind == types.Pointer {
+			umt = umt.Elem
+		}
+
+		argsMember := generator.Args{
+			"embedded": embeddedField(m),
+			"property": propertyName(m),
+			"builder":  builderOf(umt),
+		}
+		if mapType := g.builderMapSlice(m); mapType != nil {
+			argsMember["builder"] = builderOf(builderType(mapType.Elem))
+			argsMember["mapKey"] = mapType.Key
+			sw.Do("$.property$ []map[$.mapKey|raw$]*$.builder|raw$ \n", argsMember)
+		} else if list := g.builderLists(m); list != nil {
+			args := g.listsArgs(t, m, list)
+			args["property"] = propertyName(m)
+			sw.Do("$.property$ "+args["lists"].(string)+" \n", args)
+		} else if list := g.namedList(m); list != nil {
+			argsMember["listBuilder"] = g.listBuilderOf(list)
+			sw.Do("$.property$ *$.listBuilder|raw$ \n", argsMember)
+		} else if umt.Kind == types.Slice {
+			if g.hasBuilder(umt.Elem) {
+				argsMember["builder"] = builderOf(builderType(umt.Elem))
+				sw.Do("$.property$ []*$.builder|raw$ \n", argsMember)
+			}
+		} else if umt.Kind == types.Map {
+			if g.hasBuilder(umt.Elem) {
+				argsMember["builder"] = builderOf(builderType(umt.Elem))
+				argsMember["mapKey"] = umt.Key
+				sw.Do("$.property$ map[$.mapKey|raw$]*$.builder|raw$ \n", argsMember)
+				if g.orderedMap(m) {
+					sw.Do("$.property$Keys []$.mapKey|raw$ \n", argsMember)
+				}
+			}
+		} else if umt.Kind == types.Struct {
+			if g.embedsBuilder(t, m, umt) {
+				pointer := ""
+				if mt.Kind == types.Pointer {
+					pointer = "*"
+				}
+				sw.Do(fmt.Sprintf("%s$.builder|raw$\n", pointer), argsMember)
+
+			} else if g.memberBuilder(t, m, umt) {
+				sw.Do("$.property$ *$.builder|raw$\n", argsMember)
+			}
+
+		} else if umt.Kind == types.Interface {
+			g.implementationFields(sw, t, m)
+		}
+	}
+	sw.Do("}\n", generator.Args{})
+}
+
+func (g *genDeepCopy) structMethods(sw *generator.SnippetWriter, t *types.Type) {
+	promoted := g.promotedSetters(t)
+	for _, m := range builderMembers(t) {
+		mt := m.Type
+		umt := underlyingType(mt)
+		// pumt := umt
+		if umt.Kind == types.Pointer {
+			umt = umt.Elem
+		}
+
+		setter, base := g.methodName(t, m), g.memberName(m)
+		argsMember := generator.Args{
+			"embedded":   embeddedField(m),
+			"typeBase":   t,
+			"type":       umt,
+			"typeAlias":  mt,
+			"name":       m.Name,
+			"nameMethod": propertyName(m),
+			"setter":     setter,
+			"base":       base,
+			"builder":    builderOf(umt),
+			"newBuilder": g.constructorOf(umt),
+		}
+		doc := docLines(m.CommentLines)
+
+		if handler := g.typeHandler(t, m); handler != nil {
+			handler.methods(g, sw, t, m, argsMember)
+		} else if umt.Kind == types.Unsupported {
+			g.warn(t, m, fmt.Sprintf("unsupported type %v", mt))
+		} else if umt.IsPrimitive() {
+			g.valueSetter(sw, t, m, argsMember)
+		} else if mapType := g.builderMapSlice(m); mapType != nil {
+			g.mapSliceMethods(sw, t, m, mapType)
+		} else if list := g.builderLists(m); list != nil {
+			g.listsMethods(sw, t, m, list)
+		} else if list := g.namedList(m); list != nil {
+			g.namedListMethods(sw, t, m, list)
+		} else if umt.Kind == types.Slice {
+			if !g.hasBuilder(umt.Elem) {
+				g.valueSetter(sw, t, m, argsMember)
+				if isPrimitiveSlice(mt) {
+					argsMember["elem"] = umt.Elem
+					argsMember["slice"] = g.appendable("b.model." + m.Name)
+					argsMember["cap"] = extractMemberCapTag(m)
+					if !g.handWritten(t, "Add"+base) {
+						sw.Do("func (b *$.typeBase|raw$Builder) Add$.base$(items ...$.elem|raw$) *$.typeBase|raw$Builder {\n", argsMember)
+						g.copyOnWrite(sw)
+						g.clearOneof(sw, t, m)
+						g.allocateSlice(sw, m, argsMember)
+						sw.Do("b.model.$.name$ = append($.slice$, items...)\n", argsMember)
+						sw.Do("return b\n", generator.Args{})
+						sw.Do("}\n\n", generator.Args{})
+					}
+					if !g.handWritten(t, "Append"+base) {
+						sw.Do("func (b *$.typeBase|raw$Builder) Append$.base$(item $.elem|raw$) *$.typeBase|raw$Builder {\n", argsMember)
+						g.copyOnWrite(sw)
+						g.clearOneof(sw, t, m)
+						g.allocateSlice(sw, m, argsMember)
+						sw.Do("b.model.$.name$ = append($.slice$, item)\n", argsMember)
+						sw.Do("return b\n", generator.Args{})
+						sw.Do("}\n\n", generator.Args{})
+					}
+				} else if isByteSlice(mt) {
+					if !g.handWritten(t, "Set"+base+"String") {
+						sw.Do("func (b *$.typeBase|raw$Builder) Set$.base$String(input string) *$.typeBase|raw$Builder {\n", argsMember)
+						g.copyOnWrite(sw)
+						g.clearOneof(sw, t, m)
+						sw.Do("b.model.$.name$ = $.typeAlias|raw$(input)\n", argsMember)
+						sw.Do("return b\n", generator.Args{})
+						sw.Do("}\n\n", generator.Args{})
+					}
+					g.byteSliceEncodingSetter(sw, t, m, argsMember)
+				}
+			} else {
+				argsMember["builder"] = builderOf(builderType(umt.Elem))
+				argsMember["newBuilder"] = g.constructorOf(builderType(umt.Elem))
+				g.setFrom(sw, t, m, argsMember)
+				if g.customArgs.CopyOnWrite {
+					g.copyOnWriteSliceMethods(sw, t, m, argsMember)
+				} else if !g.handWritten(t, "Add"+base) {
+					writeDoc(sw, doc)
+					sw.Do("func (b *$.typeBase|raw$Builder) Add$.base$() *$.builder|raw$ {\n", argsMember)
+					g.clearOneof(sw, t, m)
+					sw.Do("builder := $.newBuilder|raw$()\n", argsMember)
+					sw.Do("b.$.nameMethod$ = append(b.$.nameMethod$, builder)\n", argsMember)
+					sw.Do("return builder\n", argsMember)
+					sw.Do("}\n\n", generator.Args{})
+					g.addWith(sw, t, argsMember)
+				}
+
+				if !g.customArgs.CopyOnWrite && !g.handWritten(t, "Remove"+base) {
+					sw.Do("func (b *$.typeBase|raw$Builder) Remove$.base$(remove *$.builder|raw$) {\n", argsMember)
+					sw.Do("for i, val := range b.$.nameMethod$ {\n", argsMember)
+					sw.Do("if val == remove {\n", generator.Args{})
+					sw.Do("b.$.nameMethod$[i] = b.$.nameMethod$[len(b.$.nameMethod$)-1]\n", argsMember)
+					sw.Do("b.$.nameMethod$ = b.$.nameMethod$[:len(b.$.nameMethod$)-1]\n", argsMember)
+					sw.Do("}\n", generator.Args{})
+					sw.Do("}\n", generator.Args{})
+					sw.Do("}\n", generator.Args{})
+				}
+			}
+		} else if umt.Kind == types.Map {
+			if !g.hasBuilder(umt.Elem) {
+				g.valueSetter(sw, t, m, argsMember)
+				if isPrimitiveMap(mt) && !g.handWritten(t, "Set"+base+"Entry") {
+					argsMember["key"] = umt.Key
+					argsMember["elem"] = umt.Elem
+					sw.Do("func (b *$.typeBase|raw$Builder) Set$.base$Entry(key $.key|raw$, value $.elem|raw$) *$.typeBase|raw$Builder {\n", argsMember)
+					g.copyOnWrite(sw)
+					g.clearOneof(sw, t, m)
+					if g.customArgs.CopyOnWrite {
+						sw.Do("entries := make($.typeAlias|raw$, len(b.model.$.name$)+1)\n", argsMember)
+						sw.Do("for k, v := range b.model.$.name$ {\n", argsMember)
+						sw.Do("entries[k] = v\n", generator.Args{})
+						sw.Do("}\n", generator.Args{})
+						sw.Do("entries[key] = value\n", generator.Args{})
+						sw.Do("b.model.$.name$ = entries\n", argsMember)
+					} else {
+						argsMember["cap"] = extractMemberCapTag(m)
+						sw.Do("if b.model.$.name$ == nil {\n", argsMember)
+						if extractMemberCapTag(m) > 0 {
+							sw.Do("b.model.$.name$ = make($.typeAlias|raw$, $.cap$)\n", argsMember)
+						} else {
+							sw.Do("b.model.$.name$ = $.typeAlias|raw${}\n", argsMember)
+						}
+						sw.Do("}\n", generator.Args{})
+						sw.Do("b.model.$.name$[key] = value\n", argsMember)
+					}
+					sw.Do("return b\n", generator.Args{})
+					sw.Do("}\n\n", generator.Args{})
+				}
+			} else {
+				argsMember["mapKey"] = umt.Key
+				argsMember["builder"] = builderOf(builderType(umt.Elem))
+				argsMember["newBuilder"] = g.constructorOf(builderType(umt.Elem))
+				if !g.handWritten(t, setter) {
+					writeDoc(sw, doc)
+					sw.Do("func (b *$.typeBase|raw$Builder) $.setter$(input $.typeAlias|raw$) *$.typeBase|raw$Builder {\n", argsMember)
+					g.copyOnWrite(sw)
+					g.clearOneof(sw, t, m)
+					argsMember["input"] = "input"
+					if underlyingType(mt).Kind == types.Pointer {
+						sw.Do("if input == nil {\n", generator.Args{})
+						sw.Do("b.$.nameMethod$ = nil\n", argsMember)
+						if g.orderedMap(m) {
+							sw.Do("b.$.nameMethod$Keys = nil\n", argsMember)
+						}
+						sw.Do("return b\n", generator.Args{})
+						sw.Do("}\n", generator.Args{})
+						argsMember["input"] = "*input"
+					}
+					sw.Do("b.$.nameMethod$ = map[$.mapKey|raw$]*$.builder|raw${}\n", argsMember)
+					sw.Do("for k, v := range $.input$ {\n", argsMember)
+					if umt.Elem.Kind == types.Pointer {
+						sw.Do("if v == nil {\n", generator.Args{})
+						sw.Do("continue\n", generator.Args{})
+						sw.Do("}\n", generator.Args{})
+						g.builderFromModel(sw, "builder", true, "*v", builderType(umt.Elem))
+					} else {
+						g.builderFromModel(sw, "builder", true, "v", builderType(umt.Elem))
+					}
+					sw.Do("b.$.nameMethod$[k] = builder\n", argsMember)
+					sw.Do("}\n", generator.Args{})
+					g.orderedMapSort(sw, m)
+					sw.Do("return b\n", generator.Args{})
+					sw.Do("}\n\n", generator.Args{})
+				}
+				g.conditionalSetter(sw, t, argsMember)
+				g.orderedMapKeysMethod(sw, t, m)
+				if g.customArgs.CopyOnWrite {
+					g.copyOnWriteMapMethods(sw, t, m, argsMember)
+				} else if !g.handWritten(t, "Add"+base) {
+					writeDoc(sw, doc)
+					sw.Do("func (b *$.typeBase|raw$Builder) Add$.base$(key $.mapKey|raw$) *$.builder|raw$ {\n", argsMember)
+					g.clearOneof(sw, t, m)
+					if underlyingType(mt).Kind == types.Pointer {
+						sw.Do("if b.$.nameMethod$ == nil {\n", argsMember)
+						sw.Do("b.$.nameMethod$ = map[$.mapKey|raw$]*$.builder|raw${}\n", argsMember)
+						sw.Do("}\n", generator.Args{})
+					}
+					sw.Do("builder := $.newBuilder|raw$()\n", argsMember)
+					g.orderedMapAdd(sw, m, "key")
+					sw.Do("b.$.nameMethod$[key] = builder\n", argsMember)
+					sw.Do("return builder\n", argsMember)
+					sw.Do("}\n\n", generator.Args{})
+					g.addWith(sw, t, argsMember)
+				}
+				g.mapRemove(sw, t, m, argsMember)
+			}
+		} else if umt.Kind == types.Struct {
+			if g.embedsBuilder(t, m, umt) {
+				accessor := g.embeddedAccessor(t, m)
+
+				if accessor && g.customArgs.CopyOnWrite {
+					g.copyOnWriteNestedMethod(sw, t, m, argsMember)
+				} else if accessor && !g.handWritten(t, setter) {
+					writeDoc(sw, doc)
+					sw.Do("func (b *$.typeBase|raw$Builder) $.setter$() *$.builder|raw$ {\n", argsMember)
+					if mt.Kind == types.Pointer {
+						sw.Do("if b.$.embedded$ == nil {\n", argsMember)
+						sw.Do("b.$.embedded$ = $.newBuilder|raw$()\n", argsMember)
+						sw.Do("}\n", generator.Args{})
+						sw.Do("return b.$.embedded$\n", argsMember)
+					} else {
+						sw.Do("return &b.$.embedded$\n", argsMember)
+					}
+					sw.Do("}\n\n", generator.Args{})
+				}
+
+				g.pointerSetter(sw, t, m, argsMember)
+
+				g.embeddedSetters(sw, t, m, promoted)
+			} else if g.memberBuilder(t, m, umt) {
+				if g.customArgs.CopyOnWrite {
+					g.copyOnWriteNestedMethod(sw, t, m, argsMember)
+				} else if !g.handWritten(t, setter) {
+					writeDoc(sw, doc)
+					sw.Do("func (b *$.typeBase|raw$Builder) $.setter$() *$.builder|raw$ {\n", argsMember)
+					g.clearOneof(sw, t, m)
+					if mt.Kind == types.Pointer {
+						sw.Do("if b.$.nameMethod$ == nil {\n", argsMember)
+						sw.Do("b.$.nameMethod$ = $.newBuilder|raw$()\n", argsMember)
+						sw.Do("}\n", generator.Args{})
+					}
+					sw.Do("return b.$.nameMethod$\n", argsMember)
+					sw.Do("}\n\n", generator.Args{})
+				}
+				g.pointerSetter(sw, t, m, argsMember)
+			} else {
+				g.valueSetter(sw, t, m, argsMember)
+			}
+		} else if umt.Kind == types.Interface {
+			g.valueSetter(sw, t, m, argsMember)
+			g.oneofSetters(sw, t, m)
+			g.implementationSetters(sw, t, m)
+			if extractMemberJSONTag(m) && !g.handWritten(t, "Set"+base+"JSON") {
+				argsMember["unmarshal"] = jsonUnmarshalFunc
+				sw.Do("// Set$.base$JSON sets $.name$ to the decoded JSON document data.\n", argsMember)
+				g.failingSetter(sw, "Set$.base$JSON(data []byte)", argsMember)
+				sw.Do("var input $.typeAlias|raw$\n", argsMember)
+				sw.Do("if err := $.unmarshal|raw$(data, &input); err != nil {\n", argsMember)
+				g.failingSetterError(sw, argsMember)
+				sw.Do("}\n", generator.Args{})
+				g.clearOneof(sw, t, m)
+				g.clearImplementations(sw, t, m)
+				sw.Do("b.model.$.name$ = input\n", argsMember)
+				g.failingSetterEnd(sw)
+			}
+		} else {
+			g.warn(t, m, fmt.Sprintf("%s members are not supported", strings.ToLower(string(umt.Kind))))
+		}
+		g.presenceMethod(sw, t, m, argsMember)
+		g.applyConfigurationSetter(sw, t, m, argsMember)
+	}
+	g.metaSetters(sw, t, promoted)
+}
+
+// valueSetter writes the setter of the member m of t taking its value, and
+// its conditional variant. The setters of the primitive slices keep a copy of
+// input, for their Add and Append setters not to append to the array of the
+// caller.
+func (g *genDeepCopy) valueSetter(sw *generator.SnippetWriter, t *types.Type, m types.Member, argsMember generator.Args) {
+	if !g.handWritten(t, argsMember["setter"].(string)) {
+		writeDoc(sw, docLines(m.CommentLines))
+		sw.Do("func (b *$.typeBase|raw$Builder) $.setter$(input $.typeAlias|raw$) *$.typeBase|raw$Builder {\n", argsMember)
+		g.copyOnWrite(sw)
+		g.clearOneof(sw, t, m)
+		g.clearImplementations(sw, t, m)
+		if isPrimitiveSlice(m.Type) {
+			sw.Do("if input != nil {\n", argsMember)
+			sw.Do("input = append(make($.typeAlias|raw$, 0, len(input)), input...)\n", argsMember)
+			sw.Do("}\n", argsMember)
+		}
+		sw.Do("b.model.$.name$ = input\n", argsMember)
+		sw.Do("return b\n", generator.Args{})
+		sw.Do("}\n\n", generator.Args{})
+	}
+	g.conditionalSetter(sw, t, argsMember)
+}
+
+// pointerSetter writes, for the member m of t holding a pointer to a struct
+// with a builder, the Set<Member> setter replacing its nested builder by one
+// holding the value input points to, or by nil.
+func (g *genDeepCopy) pointerSetter(sw *generator.SnippetWriter, t *types.Type, m types.Member, argsMember generator.Args) {
+	if m.Type.Kind != types.Pointer {
+		return
+	}
+	name := "Set" + argsMember["base"].(string)
+	if name == argsMember["setter"] {
+		name += "Value"
+		klog.V(2).Infof("Member %s of %v has the nested builder method %s(), generating %s() instead", m.Name, t, argsMember["setter"], name)
+	}
+	if g.handWritten(t, name) {
+		return
+	}
+
+	field := propertyName(m)
+	if g.embedsBuilder(t, m, builderType(m.Type)) {
+		field = embeddedField(m)
+	}
+	umt := m.Type.Elem
+	argsMember["pointerSetter"] = name
+	argsMember["field"] = field
+	sw.Do("// $.pointerSetter$ sets $.name$ to a copy of the value input points to, nil\n", argsMember)
+	sw.Do("// if input is nil.\n", argsMember)
+	sw.Do("func (b *$.typeBase|raw$Builder) $.pointerSetter$(input $.typeAlias|raw$) *$.typeBase|raw$Builder {\n", argsMember)
+	g.copyOnWrite(sw)
+	g.clearOneof(sw, t, m)
+	sw.Do("b.$.field$ = nil\n", argsMember)
+	sw.Do("b.model.$.name$ = nil\n", argsMember)
+	sw.Do("if input != nil {\n", argsMember)
+	g.builderFromModel(sw, "b."+field, false, "*input", umt)
+	sw.Do("}\n", argsMember)
+	sw.Do("return b\n", argsMember)
+	sw.Do("}\n\n", argsMember)
+}
+
+// conditionalSetter writes, with --conditional-setters, the <setter>If
+// variant of the setter of a member, only setting it when cond is true.
+func (g *genDeepCopy) conditionalSetter(sw *generator.SnippetWriter, t *types.Type, argsMember generator.Args) {
+	if !g.customArgs.ConditionalSetters || g.handWritten(t, argsMember["setter"].(string)+"If") {
+		return
+	}
+	sw.Do("// $.setter$If calls $.setter$ when cond is true.\n", argsMember)
+	sw.Do("func (b *$.typeBase|raw$Builder) $.setter$If(cond bool, input $.typeAlias|raw$) *$.typeBase|raw$Builder {\n", argsMember)
+	sw.Do("if cond {\n", argsMember)
+	sw.Do("return b.$.setter$(input)\n", argsMember)
+	sw.Do("}\n", argsMember)
+	sw.Do("return b\n", argsMember)
+	sw.Do("}\n\n", argsMember)
+}
+
+// setFrom writes the Set<Member>From method of a member holding a slice of
+// nested builders, replacing them by builders of the elements of items, which
+// are edited like those of Add<Member>.
+func (g *genDeepCopy) setFrom(sw *generator.SnippetWriter, t *types.Type, m types.Member, argsMember generator.Args) {
+	if g.handWritten(t, "Set"+argsMember["base"].(string)+"From") {
+		return
+	}
+	elem := underlyingType(argsMember["type"].(*types.Type)).Elem
+	sw.Do("// Set$.base$From replaces the builders of $.name$ by builders of the\n", argsMember)
+	sw.Do("// elements of items.\n", argsMember)
+	sw.Do("func (b *$.typeBase|raw$Builder) Set$.base$From(items $.type|raw$) *$.typeBase|raw$Builder {\n", argsMember)
+	g.copyOnWrite(sw)
+	g.clearOneof(sw, t, m)
+	sw.Do("b.$.nameMethod$ = make([]*$.builder|raw$, 0, len(items))\n", argsMember)
+	sw.Do("for _, item := range items {\n", argsMember)
+	if elem.Kind == types.Pointer {
+		sw.Do("if item == nil {\n", argsMember)
+		sw.Do("continue\n", argsMember)
+		sw.Do("}\n", argsMember)
+		g.builderFromModel(sw, "builder", true, "*item", builderType(elem))
+	} else {
+		g.builderFromModel(sw, "builder", true, "item", builderType(elem))
+	}
+	sw.Do("b.$.nameMethod$ = append(b.$.nameMethod$, builder)\n", argsMember)
+	sw.Do("}\n", argsMember)
+	sw.Do("return b\n", argsMember)
+	sw.Do("}\n\n", argsMember)
+}
+
+// mapRemove writes the Remove<Member> method of a member holding a map of
+// nested builders, dropping the builder of key, which Build then leaves out.
+// With --copy-on-write, it returns the changed copy.
+func (g *genDeepCopy) mapRemove(sw *generator.SnippetWriter, t *types.Type, m types.Member, argsMember generator.Args) {
+	if g.handWritten(t, "Remove"+argsMember["base"].(string)) {
+		return
+	}
+	sw.Do("// Remove$.base$ drops the builder of key from $.name$.\n", argsMember)
+	if !g.customArgs.CopyOnWrite {
+		sw.Do("func (b *$.typeBase|raw$Builder) Remove$.base$(key $.mapKey|raw$) {\n", argsMember)
+		g.orderedMapRemove(sw, m, "key")
+		sw.Do("delete(b.$.nameMethod$, key)\n", argsMember)
+		sw.Do("}\n\n", argsMember)
+		return
+	}
+	sw.Do("func (b *$.typeBase|raw$Builder) Remove$.base$(key $.mapKey|raw$) *$.typeBase|raw$Builder {\n", argsMember)
+	sw.Do("if _, ok := b.$.nameMethod$[key]; !ok {\n", argsMember)
+	sw.Do("return b\n", argsMember)
+	sw.Do("}\n", argsMember)
+	sw.Do("b = b.copyOnWrite()\n", argsMember)
+	g.orderedMapRemove(sw, m, "key")
+	sw.Do("builders := make(map[$.mapKey|raw$]*$.builder|raw$, len(b.$.nameMethod$))\n", argsMember)
+	sw.Do("for k, v := range b.$.nameMethod$ {\n", argsMember)
+	sw.Do("if k != key {\n", argsMember)
+	sw.Do("builders[k] = v\n", argsMember)
+	sw.Do("}\n", argsMember)
+	sw.Do("}\n", argsMember)
+	sw.Do("b.$.nameMethod$ = builders\n", argsMember)
+	sw.Do("return b\n", argsMember)
+	sw.Do("}\n\n", argsMember)
+}
+
+// addWith writes the Add<Member>With variant of the Add method of a member
+// holding a slice or a map of nested builders, setting the new builder with
+// build and returning the builder of t, so that the chain goes on.
+func (g *genDeepCopy) addWith(sw *generator.SnippetWriter, t *types.Type, argsMember generator.Args) {
+	if g.handWritten(t, "Add"+argsMember["base"].(string)+"With") {
+		return
+	}
+	if argsMember["mapKey"] != nil {
+		sw.Do("// Add$.base$With calls build with the builder Add$.base$ sets for key.\n", argsMember)
+		sw.Do("func (b *$.typeBase|raw$Builder) Add$.base$With(key $.mapKey|raw$, build func(*$.builder|raw$)) *$.typeBase|raw$Builder {\n", argsMember)
+		sw.Do("build(b.Add$.base$(key))\n", argsMember)
+	} else {
+		sw.Do("// Add$.base$With calls build with the builder Add$.base$ appends.\n", argsMember)
+		sw.Do("func (b *$.typeBase|raw$Builder) Add$.base$With(build func(*$.builder|raw$)) *$.typeBase|raw$Builder {\n", argsMember)
+		sw.Do("build(b.Add$.base$())\n", argsMember)
+	}
+	sw.Do("return b\n", argsMember)
+	sw.Do("}\n\n", argsMember)
+}
+
+// structMethodErr writes, with --accumulate-errors, the Err method joining
+// the errors of the builder and its nested builders.
+func (g *genDeepCopy) structMethodErr(sw *generator.SnippetWriter, t *types.Type) {
+	if !g.customArgs.AccumulateErrors || g.handWritten(t, "Err") {
+		return
+	}
+
+	args := generator.Args{
+		"type":   t,
+		"errors": g.errorsType(),
 	}
-	sw.Do("type $.type|raw$Builder struct {\n", args)
-	sw.Do("model $.type|raw$\n", args)
-	for _, m := range t.Members {
+	sw.Do("// Err returns the errors of the setters called on the builder and its\n", args)
+	sw.Do("// nested builders, nil if none failed.\n", args)
+	sw.Do("func (b *$.type|raw$Builder) Err() error {\n", args)
+	sw.Do("if b == nil {\n", args)
+	sw.Do("return nil\n", args)
+	sw.Do("}\n", args)
+	sw.Do("errs := append($.errors${}, b.errs...)\n", args)
+	for _, m := range builderMembers(t) {
+		mt := m.Type
+		umt := underlyingType(mt)
+		if umt.Kind == types.Pointer {
+			umt = umt.Elem
+		}
+		argsMember := generator.Args{
+			"embedded":   embeddedField(m),
+			"name":       m.Name,
+			"nameMethod": propertyName(m),
+		}
+		switch {
+		case g.builderMapSlice(m) != nil:
+			sw.Do("for _, builders := range b.$.nameMethod$ {\n", argsMember)
+			sw.Do("for _, v := range builders {\n", argsMember)
+			sw.Do("if err := v.Err(); err != nil {\n", argsMember)
+			sw.Do("errs = append(errs, err)\n", argsMember)
+			sw.Do("}\n", argsMember)
+			sw.Do("}\n", argsMember)
+			sw.Do("}\n", argsMember)
+		case g.builderLists(m) != nil:
+			sw.Do("for _, v := range b.$.nameMethod$ {\n", argsMember)
+			sw.Do("if err := v.Err(); err != nil {\n", argsMember)
+			sw.Do("errs = append(errs, err)\n", argsMember)
+			sw.Do("}\n", argsMember)
+			sw.Do("}\n", argsMember)
+		case g.namedList(m) != nil:
+			sw.Do("if b.$.nameMethod$ != nil {\n", argsMember)
+			sw.Do("if err := b.$.nameMethod$.Err(); err != nil {\n", argsMember)
+			sw.Do("errs = append(errs, err)\n", argsMember)
+			sw.Do("}\n", argsMember)
+			sw.Do("}\n", argsMember)
+		case (umt.Kind == types.Slice || umt.Kind == types.Map) && g.hasBuilder(umt.Elem):
+			if g.orderedMap(m) {
+				g.orderedMapRange(sw, m, "b")
+			} else {
+				sw.Do("for _, v := range b.$.nameMethod$ {\n", argsMember)
+			}
+			sw.Do("if err := v.Err(); err != nil {\n", argsMember)
+			sw.Do("errs = append(errs, err)\n", argsMember)
+			sw.Do("}\n", argsMember)
+			sw.Do("}\n", argsMember)
+		case umt.Kind == types.Struct && g.embedsBuilder(t, m, umt):
+			sw.Do("if err := b.$.embedded$.Err(); err != nil {\n", argsMember)
+			sw.Do("errs = append(errs, err)\n", argsMember)
+			sw.Do("}\n", argsMember)
+		case umt.Kind == types.Struct && g.memberBuilder(t, m, umt):
+			sw.Do("if err := b.$.nameMethod$.Err(); err != nil {\n", argsMember)
+			sw.Do("errs = append(errs, err)\n", argsMember)
+			sw.Do("}\n", argsMember)
+		case umt.Kind == types.Interface:
+			impls, _ := g.implementations(t, m)
+			for _, impl := range impls {
+				sw.Do("if err := b.$.field$.Err(); err != nil {\n", g.implementationArgs(t, m, impl))
+				sw.Do("errs = append(errs, err)\n", argsMember)
+				sw.Do("}\n", argsMember)
+			}
+		}
+	}
+	g.returnErrors(sw, "")
+	sw.Do("}\n\n", args)
+}
+
+// byteSliceEncodingSetter writes the setter decoding the strings of the
+// encoding tag of the byte slice m.
+func (g *genDeepCopy) byteSliceEncodingSetter(sw *generator.SnippetWriter, t *types.Type, m types.Member, argsMember generator.Args) {
+	encoding := extractMemberEncodingTag(m)
+	switch {
+	case encoding == "":
+		return
+	case encoding != encodingBase64:
+		klog.Warningf("Member %s of %v has the unsupported encoding %q, expected %q", m.Name, t, encoding, encodingBase64)
+		return
+	case g.handWritten(t, "Set"+argsMember["base"].(string)+"Base64"):
+		return
+	}
+	argsMember["decode"] = base64DecodeFunc
+	sw.Do("// Set$.base$Base64 sets $.name$ to the decoded base64 string input.\n", argsMember)
+	g.failingSetter(sw, "Set$.base$Base64(input string)", argsMember)
+	sw.Do("decoded, err := $.decode|raw$(input)\n", argsMember)
+	sw.Do("if err != nil {\n", generator.Args{})
+	g.failingSetterError(sw, argsMember)
+	sw.Do("}\n", generator.Args{})
+	g.clearOneof(sw, t, m)
+	if m.Type.Name.Package == "" {
+		sw.Do("b.model.$.name$ = decoded\n", argsMember)
+	} else {
+		sw.Do("b.model.$.name$ = $.typeAlias|raw$(decoded)\n", argsMember)
+	}
+	g.failingSetterEnd(sw)
+}
+
+// failingSetter opens the setter with signature, whose input may be invalid.
+// It returns the error, or records it in the builder and returns the builder
+// with --accumulate-errors. With --copy-on-write, it returns the changed copy
+// of the builder and the error.
+func (g *genDeepCopy) failingSetter(sw *generator.SnippetWriter, signature string, argsMember generator.Args) {
+	switch {
+	case g.customArgs.AccumulateErrors:
+		sw.Do("func (b *$.typeBase|raw$Builder) "+signature+" *$.typeBase|raw$Builder {\n", argsMember)
+	case g.customArgs.CopyOnWrite:
+		sw.Do("func (b *$.typeBase|raw$Builder) "+signature+" (*$.typeBase|raw$Builder, error) {\n", argsMember)
+	default:
+		sw.Do("func (b *$.typeBase|raw$Builder) "+signature+" error {\n", argsMember)
+	}
+	g.copyOnWrite(sw)
+}
+
+// failingSetterError handles the error err of a failing setter.
+func (g *genDeepCopy) failingSetterError(sw *generator.SnippetWriter, argsMember generator.Args) {
+	if g.customArgs.AccumulateErrors {
+		argsMember["errorf"] = errorfFunc
+		sw.Do("b.errs = append(b.errs, $.errorf|raw$(\"$.name$: %w\", err))\n", argsMember)
+		sw.Do("return b\n", argsMember)
+	} else if g.customArgs.CopyOnWrite {
+		sw.Do("return nil, err\n", argsMember)
+	} else {
+		sw.Do("return err\n", argsMember)
+	}
+}
+
+// failingSetterEnd closes a failing setter which succeeded.
+func (g *genDeepCopy) failingSetterEnd(sw *generator.SnippetWriter) {
+	switch {
+	case g.customArgs.AccumulateErrors:
+		sw.Do("return b\n", nil)
+	case g.customArgs.CopyOnWrite:
+		sw.Do("return b, nil\n", nil)
+	default:
+		sw.Do("return nil\n", nil)
+	}
+	sw.Do("}\n\n", nil)
+}
+
+// structMethodBuildPtr writes the <Build>Ptr method of the builder of t,
+// returning a pointer to a newly built model.
+func (g *genDeepCopy) structMethodBuildPtr(sw *generator.SnippetWriter, t *types.Type) {
+	args := generator.Args{
+		"type":  t,
+		"build": g.buildName(t),
+	}
+	if g.handWritten(t, g.buildName(t)+"Ptr") {
+		return
+	}
+	sw.Do("// $.build$Ptr returns a pointer to the model built by $.build$.\n", args)
+	sw.Do("func (b *$.type|raw$Builder) $.build$Ptr() *$.type|raw$ {\n", args)
+	sw.Do("model := b.$.build$()\n", args)
+	sw.Do("return &model\n", args)
+	sw.Do("}\n\n", args)
+}
+
+func (g *genDeepCopy) structMethodBuild(sw *generator.SnippetWriter, t *types.Type) {
+	args := generator.Args{
+		"type":  t,
+		"build": g.buildName(t),
+	}
+
+	if g.handWritten(t, g.buildName(t)) {
+		return
+	}
+	if g.customArgs.ImmutableBuild && generatesDeepCopy(g.customArgs, g.targetPackage, g.declared, t) {
+		sw.Do("// $.build$ returns a deep copy of the built model, which the later changes\n", args)
+		sw.Do("// of the builder don't affect.\n", args)
+		sw.Do("func (b *$.type|raw$Builder) $.build$() $.type|raw$ {\n", args)
+		sw.Do("model := b.build()\n", args)
+		sw.Do("var out $.type|raw$\n", args)
+		sw.Do("model.DeepCopyInto(&out)\n", args)
+		sw.Do("return out\n", args)
+		sw.Do("}\n\n", args)
+		sw.Do("func (b *$.type|raw$Builder) build() $.type|raw$ {\n", args)
+	} else if g.customArgs.ImmutableBuild {
+		sw.Do("// $.build$ returns the model built from a copy of the builder, which its\n", args)
+		sw.Do("// later changes don't affect.\n", args)
+		sw.Do("func (b *$.type|raw$Builder) $.build$() $.type|raw$ {\n", args)
+		sw.Do("return b.Clone().build()\n", args)
+		sw.Do("}\n\n", args)
+		sw.Do("func (b *$.type|raw$Builder) build() $.type|raw$ {\n", args)
+	} else if g.customArgs.CopyOnWrite {
+		sw.Do("// $.build$ returns the model built from a copy of the builder, leaving the\n", args)
+		sw.Do("// builder unchanged for the goroutines sharing it.\n", args)
+		sw.Do("func (b *$.type|raw$Builder) $.build$() $.type|raw$ {\n", args)
+		sw.Do("builder := *b\n", args)
+		sw.Do("return builder.build()\n", args)
+		sw.Do("}\n\n", args)
+		sw.Do("func (b *$.type|raw$Builder) build() $.type|raw$ {\n", args)
+	} else {
+		sw.Do("func (b *$.type|raw$Builder) $.build$() $.type|raw$ {\n", args)
+	}
+	for _, m := range builderMembers(t) {
 		mt := m.Type
 		umt := underlyingType(mt)
 		// pumt := umt
 		if umt.Kind == types.Pointer {
-			umt = mt.Elem
+			umt = umt.Elem
 		}
 
 		argsMember := generator.Args{
-			"name":     types.ParseFullyQualifiedName(umt.Name.Name).Name,
-			"property": strings.ToLower(m.Name),
+			"embedded":   embeddedField(m),
+			"name":       m.Name,
+			"nameMethod": propertyName(m),
+			"build":      g.buildName(umt),
 		}
-		if umt.Kind == types.Slice {
-			if !umt.Elem.IsPrimitive() {
-				sw.Do("$.property$ []*$.name$Builder \n", argsMember)
+		if umt.Kind == types.Unsupported {
+			klog.V(5).Infof("type unsupported %v %v", t, m.Name)
+		} else if mapType := g.builderMapSlice(m); mapType != nil {
+			g.mapSliceBuild(sw, m, mapType, g.mapSliceArgs(t, m, mapType))
+		} else if list := g.builderLists(m); list != nil {
+			g.listsBuild(sw, m, g.listsArgs(t, m, list))
+		} else if list := g.namedList(m); list != nil {
+			g.namedListBuild(sw, g.namedListArgs(t, m, list))
+		} else if (umt.Kind == types.Slice || umt.Kind == types.Map) && g.hasBuilder(umt.Elem) {
+			argsCollection := generator.Args{
+				"target":     "b.model." + m.Name,
+				"assign":     "=",
+				"name":       m.Name,
+				"nameMethod": propertyName(m),
+				"type":       umt,
+				"build":      g.buildName(builderType(umt.Elem)),
+				"cap":        extractMemberCapTag(m),
 			}
-		} else if umt.Kind == types.Map {
-			if !g.isOtherPackage(umt.Name.Package) || !g.isOtherPackage(types.ParseFullyQualifiedName(umt.Name.Name).Package) {
-				if !umt.Elem.IsPrimitive() {
-					argsMember["mapKey"] = umt.Key.Name.Name
-					sw.Do("$.property$ map[$.mapKey$]*$.name$Builder \n", argsMember)
+			// The pointers to slices and maps are built into a variable
+			// when nested builders were added.
+			pointer := underlyingType(mt).Kind == types.Pointer
+			if pointer {
+				argsCollection["target"], argsCollection["assign"] = propertyName(m), ":="
+				sw.Do("if b.$.nameMethod$ != nil {\n", argsCollection)
+			}
+			if umt.Kind == types.Slice {
+				argsCollection["type"] = umt.Elem
+				if extractMemberCapTag(m) > 0 {
+					sw.Do("$.target$ $.assign$ make([]$.type|raw$, 0, $.cap$)\n", argsCollection)
+				} else {
+					sw.Do("$.target$ $.assign$ []$.type|raw${}\n", argsCollection)
+				}
+				sw.Do("for _, v := range b.$.nameMethod$ {\n", argsCollection)
+				if umt.Elem.Kind == types.Pointer {
+					sw.Do("vv := v.$.build$()\n", argsCollection)
+					sw.Do("$.target$ = append($.target$, &vv)\n", argsCollection)
+				} else {
+					sw.Do("$.target$ = append($.target$, v.$.build$())\n", argsCollection)
 				}
+			} else {
+				if extractMemberCapTag(m) > 0 {
+					sw.Do("$.target$ $.assign$ make($.type|raw$, $.cap$)\n", argsCollection)
+				} else {
+					sw.Do("$.target$ $.assign$ $.type|raw${}\n", argsCollection)
+				}
+				g.orderedMapRange(sw, m, "b")
+				if umt.Elem.Kind == types.Pointer {
+					sw.Do("vv := v.$.build$()\n", argsCollection)
+					sw.Do("$.target$[k] = &vv\n", argsCollection)
+				} else {
+					sw.Do("$.target$[k] = v.$.build$()\n", argsCollection)
+				}
+			}
+			sw.Do("}\n", generator.Args{})
+			if pointer {
+				sw.Do("b.model.$.name$ = &$.target$\n", argsCollection)
+				sw.Do("}\n", generator.Args{})
 			}
 		} else if umt.Kind == types.Struct {
-			if m.Embedded {
-				pointer := ""
+			if g.embedsBuilder(t, m, umt) {
 				if mt.Kind == types.Pointer {
-					pointer = "*"
+					sw.Do("if b.$.embedded$ != nil {\n", argsMember)
+					sw.Do("$.nameMethod$ := b.$.embedded$.$.build$() \n", argsMember)
+					sw.Do("b.model.$.name$ = &$.nameMethod$ \n", argsMember)
+					sw.Do("}\n", generator.Args{})
+				} else {
+					sw.Do("b.model.$.name$ = b.$.embedded$.$.build$() \n", argsMember)
+				}
+			} else if g.memberBuilder(t, m, umt) {
+				if mt.Kind == types.Pointer && g.omitsEmpty(m, umt) {
+					sw.Do("if b.$.nameMethod$ != nil {\n", argsMember)
+					sw.Do("$.nameMethod$ := b.$.nameMethod$.$.build$() \n", argsMember)
+					sw.Do("b.model.$.name$ = nil\n", argsMember)
+					sw.Do("if !$.nameMethod$.IsEmpty() {\n", argsMember)
+					sw.Do("b.model.$.name$ = &$.nameMethod$\n", argsMember)
+					sw.Do("}\n", generator.Args{})
+					sw.Do("}\n", generator.Args{})
+				} else if mt.Kind == types.Pointer {
+					sw.Do("if b.$.nameMethod$ != nil {\n", argsMember)
+					sw.Do("$.nameMethod$ := b.$.nameMethod$.$.build$() \n", argsMember)
+					sw.Do("b.model.$.name$ = &$.nameMethod$\n", argsMember)
+					sw.Do("}\n", generator.Args{})
+				} else {
+					sw.Do("b.model.$.name$ = b.$.nameMethod$.$.build$()\n", argsMember)
 				}
-				sw.Do(fmt.Sprintf("%s$.name$Builder\n", pointer), argsMember)
-
-			} else if !g.isOtherPackage(umt.Name.Package) || !g.isOtherPackage(types.ParseFullyQualifiedName(umt.Name.Name).Package) {
-				sw.Do("$.property$ *$.name$Builder\n", argsMember)
 			}
-
+		} else if umt.Kind == types.Interface {
+			g.implementationsBuild(sw, t, m)
 		}
 	}
-	sw.Do("}\n", generator.Args{})
+	sw.Do("return b.model\n", generator.Args{})
+	sw.Do("}\n\n", generator.Args{})
 }
 
-func (g *genDeepCopy) structMethods(sw *generator.SnippetWriter, t *types.Type) {
-	for _, m := range t.Members {
+func (g *genDeepCopy) structMethodBuildObject(sw *generator.SnippetWriter, t *types.Type) {
+	if !isKubernetesObject(t) || g.handWritten(t, "BuildObject") {
+		return
+	}
+
+	args := generator.Args{
+		"type":   t,
+		"object": &types.Type{Name: runtimeObjectName},
+		"build":  g.buildName(t),
+	}
+	sw.Do("func (b *$.type|raw$Builder) BuildObject() $.object|raw$ {\n", args)
+	sw.Do("model := b.$.build$()\n", args)
+	sw.Do("return model.DeepCopyObject()\n", generator.Args{})
+	sw.Do("}\n\n", generator.Args{})
+}
+
+// Functions of the standard library called by the generated String and
+// GoString methods.
+var (
+	sprintfFunc = &types.Type{Name: types.Name{Package: "fmt", Name: "Sprintf"}}
+	joinFunc    = &types.Type{Name: types.Name{Package: "strings", Name: "Join"}}
+	valueOfFunc = &types.Type{Name: types.Name{Package: "reflect", Name: "ValueOf"}}
+)
+
+// errorfFunc wraps the errors recorded with --accumulate-errors.
+var errorfFunc = &types.Type{Name: types.Name{Package: "fmt", Name: "Errorf"}}
+
+// builderErrorsName is the type joining the errors recorded with
+// --accumulate-errors and those of the validations, declared once per
+// package.
+const builderErrorsName = "builderErrors"
+
+// Functions of the standard library decoding the inputs of UnmarshalJSON and
+// of the setters of the members tagged +builder-gen:json and
+// +builder-gen:encoding=base64.
+var (
+	jsonUnmarshalFunc = &types.Type{Name: types.Name{Package: "encoding/json", Name: "Unmarshal"}}
+	base64DecodeFunc  = &types.Type{Name: types.Name{Package: "encoding/base64", Name: "StdEncoding.DecodeString"}}
+)
+
+// structMethodString generates a String method listing the members set on
+// the builder: the non-zero values of the model, the nested builders and the
+// number of builders of the slices and maps.
+func (g *genDeepCopy) structMethodString(sw *generator.SnippetWriter, t *types.Type) {
+	if g.handWritten(t, "String") {
+		return
+	}
+
+	args := generator.Args{
+		"type":    t,
+		"sprintf": sprintfFunc,
+		"join":    joinFunc,
+		"valueOf": valueOfFunc,
+	}
+	sw.Do("// String summarizes the members set on the builder, for debugging.\n", args)
+	sw.Do("func (b *$.type|raw$Builder) String() string {\n", args)
+	sw.Do("if b == nil {\n", args)
+	sw.Do("return \"<nil>\"\n", args)
+	sw.Do("}\n", args)
+	sw.Do("var fields []string\n", args)
+	for _, m := range builderMembers(t) {
 		mt := m.Type
 		umt := underlyingType(mt)
-		// pumt := umt
 		if umt.Kind == types.Pointer {
 			umt = umt.Elem
 		}
 
 		argsMember := generator.Args{
-			"typeBase":   t,
-			"type":       umt,
-			"typeAlias":  mt,
+			"embedded":   embeddedField(m),
 			"name":       m.Name,
-			"nameMethod": strings.ToLower(m.Name),
+			"nameMethod": propertyName(m),
+			"sprintf":    sprintfFunc,
+			"valueOf":    valueOfFunc,
+			"verb":       "%+v",
 		}
-
-		if umt.Kind == types.Unsupported {
-			klog.V(5).Infof("type unsupported %v %v", t, m.Name)
-		} else if umt.IsPrimitive() {
-			sw.Do("func (b *$.typeBase|raw$Builder) $.name$(input $.typeAlias|raw$) *$.typeBase|raw$Builder {\n", argsMember)
-			sw.Do("b.model.$.name$ = input\n", argsMember)
-			sw.Do("return b\n", generator.Args{})
-			sw.Do("}\n\n", generator.Args{})
-		} else if umt.Kind == types.Slice {
-			if umt.Elem.IsPrimitive() {
-				sw.Do("func (b *$.typeBase|raw$Builder) $.name$(input $.typeAlias|raw$) *$.typeBase|raw$Builder {\n", argsMember)
-				sw.Do("b.model.$.name$ = input\n", argsMember)
-				sw.Do("return b\n", generator.Args{})
-				sw.Do("}\n\n", generator.Args{})
+		if underlyingType(mt).Kind == types.Builtin {
+			argsMember["verb"] = "%#v"
+		}
+		if g.builderMapSlice(m) != nil {
+			sw.Do("if len(b.$.nameMethod$) > 0 {\n", argsMember)
+			sw.Do("fields = append(fields, $.sprintf|raw$(\"$.name$: %d maps of builders\", len(b.$.nameMethod$)))\n", argsMember)
+			sw.Do("}\n", argsMember)
+		} else if g.builderLists(m) != nil {
+			sw.Do("if len(b.$.nameMethod$) > 0 {\n", argsMember)
+			sw.Do("fields = append(fields, $.sprintf|raw$(\"$.name$: %d lists of builders\", len(b.$.nameMethod$)))\n", argsMember)
+			sw.Do("}\n", argsMember)
+		} else if g.namedList(m) != nil {
+			sw.Do("if b.$.nameMethod$ != nil {\n", argsMember)
+			sw.Do("fields = append(fields, $.sprintf|raw$(\"$.name$: %d builders\", len(b.$.nameMethod$.items)))\n", argsMember)
+			sw.Do("}\n", argsMember)
+		} else if (umt.Kind == types.Slice || umt.Kind == types.Map) && g.hasBuilder(umt.Elem) {
+			sw.Do("if len(b.$.nameMethod$) > 0 {\n", argsMember)
+			sw.Do("fields = append(fields, $.sprintf|raw$(\"$.name$: %d builders\", len(b.$.nameMethod$)))\n", argsMember)
+			sw.Do("}\n", argsMember)
+		} else if umt.Kind == types.Struct && g.embedsBuilder(t, m, umt) {
+			if mt.Kind == types.Pointer {
+				sw.Do("if b.$.embedded$ != nil {\n", argsMember)
+				sw.Do("fields = append(fields, \"$.name$: \"+b.$.embedded$.String())\n", argsMember)
+				sw.Do("}\n", argsMember)
 			} else {
-				argsMember["nameNew"] = types.ParseFullyQualifiedName(umt.Elem.Name.Name).Name
-				sw.Do("func (b *$.typeBase|raw$Builder) Add$.name$() *$.nameNew$Builder {\n", argsMember)
-				sw.Do("builder := New$.nameNew$Builder()\n", argsMember)
-				sw.Do("b.$.nameMethod$ = append(b.$.nameMethod$, builder)\n", argsMember)
-				sw.Do("return builder\n", argsMember)
-				sw.Do("}\n\n", generator.Args{})
-
-				sw.Do("func (b *$.typeBase|raw$Builder) Remove$.name$(remove *$.nameNew$Builder) {\n", argsMember)
-				sw.Do("for i, val := range b.$.nameMethod$ {\n", argsMember)
-				sw.Do("if val == remove {\n", generator.Args{})
-				sw.Do("b.$.nameMethod$[i] = b.$.nameMethod$[len(b.$.nameMethod$)-1]\n", argsMember)
-				sw.Do("b.$.nameMethod$ = b.$.nameMethod$[:len(b.$.nameMethod$)-1]\n", argsMember)
-				sw.Do("}\n", generator.Args{})
-				sw.Do("}\n", generator.Args{})
-				sw.Do("}\n", generator.Args{})
+				sw.Do("fields = append(fields, \"$.name$: \"+b.$.embedded$.String())\n", argsMember)
 			}
-		} else if umt.Kind == types.Map {
-			if umt.Elem.IsPrimitive() || g.isOtherPackage(umt.Name.Package) || g.isOtherPackage(types.ParseFullyQualifiedName(umt.Name.Name).Package) {
-				sw.Do("func (b *$.typeBase|raw$Builder) $.name$(input $.typeAlias|raw$) *$.typeBase|raw$Builder {\n", argsMember)
-				sw.Do("b.model.$.name$ = input\n", argsMember)
-				sw.Do("return b\n", generator.Args{})
-				sw.Do("}\n\n", generator.Args{})
+		} else if umt.Kind == types.Struct && g.memberBuilder(t, m, umt) {
+			sw.Do("if b.$.nameMethod$ != nil {\n", argsMember)
+			sw.Do("fields = append(fields, \"$.name$: \"+b.$.nameMethod$.String())\n", argsMember)
+			sw.Do("}\n", argsMember)
+		} else if underlyingType(mt).Kind == types.Func {
+			sw.Do("if b.model.$.name$ != nil {\n", argsMember)
+			sw.Do("fields = append(fields, \"$.name$: <func>\")\n", argsMember)
+			sw.Do("}\n", argsMember)
+		} else if impls, _ := g.implementations(t, m); len(impls) > 0 {
+			// The nested builder of the implementation set, if any, holds
+			// the member.
+			for _, impl := range impls {
+				argsImpl := g.implementationArgs(t, m, impl)
+				sw.Do("if b.$.field$ != nil {\n", argsImpl)
+				sw.Do("fields = append(fields, \"$.name$: \"+b.$.field$.String())\n", argsImpl)
+				sw.Do("} else ", argsImpl)
+			}
+			sw.Do("if !$.valueOf|raw$(&b.model.$.name$).Elem().IsZero() {\n", argsMember)
+			sw.Do("fields = append(fields, $.sprintf|raw$(\"$.name$: $.verb$\", b.model.$.name$))\n", argsMember)
+			sw.Do("}\n", argsMember)
+		} else {
+			sw.Do("if !$.valueOf|raw$(&b.model.$.name$).Elem().IsZero() {\n", argsMember)
+			sw.Do("fields = append(fields, $.sprintf|raw$(\"$.name$: $.verb$\", b.model.$.name$))\n", argsMember)
+			sw.Do("}\n", argsMember)
+		}
+	}
+	sw.Do("return \"$.type|raw$Builder{\" + $.join|raw$(fields, \", \") + \"}\"\n", args)
+	sw.Do("}\n\n", args)
+}
+
+// structMethodGoString generates a GoString method formatting the model and
+// the nested builders, which %#v would otherwise print as pointers.
+func (g *genDeepCopy) structMethodGoString(sw *generator.SnippetWriter, t *types.Type) {
+	if g.handWritten(t, "GoString") {
+		return
+	}
+
+	fields := []string{"model: %#v"}
+	values := []string{"b.model"}
+	for _, m := range builderMembers(t) {
+		mt := m.Type
+		umt := underlyingType(mt)
+		if umt.Kind == types.Pointer {
+			umt = umt.Elem
+		}
+
+		property := propertyName(m)
+		if g.namedList(m) != nil || (umt.Kind == types.Slice || umt.Kind == types.Map) && (g.hasBuilder(umt.Elem) || g.builderMapSlice(m) != nil || g.builderLists(m) != nil) {
+			fields = append(fields, property+": %#v")
+			values = append(values, "b."+property)
+		} else if umt.Kind == types.Struct && g.embedsBuilder(t, m, umt) {
+			fields = append(fields, embeddedField(m)+": %#v")
+			if mt.Kind == types.Pointer {
+				values = append(values, "b."+embeddedField(m))
 			} else {
-				argsMember["mapKey"] = umt.Key.Name.Name
-				argsMember["nameNew"] = types.ParseFullyQualifiedName(umt.Elem.Name.Name).Name
-				sw.Do("func (b *$.typeBase|raw$Builder) Add$.name$(key $.mapKey$) *$.nameNew$Builder {\n", argsMember)
-				sw.Do("builder := New$.nameNew$Builder()\n", argsMember)
-				sw.Do("b.$.nameMethod$[key] = builder\n", argsMember)
-				sw.Do("return builder\n", argsMember)
-				sw.Do("}\n\n", generator.Args{})
+				values = append(values, "&b."+embeddedField(m))
 			}
-		} else if umt.Kind == types.Struct {
-			if m.Embedded {
-				ignoreMethods := extractEmbbedIgnoreMethodTag(t)
-				ignore := false
-				for _, method := range ignoreMethods {
-					if method == argsMember["name"] {
-						ignore = true
-					}
-				}
+		} else if umt.Kind == types.Struct && g.memberBuilder(t, m, umt) {
+			fields = append(fields, property+": %#v")
+			values = append(values, "b."+property)
+		} else if umt.Kind == types.Interface {
+			impls, _ := g.implementations(t, m)
+			for _, impl := range impls {
+				fields = append(fields, impl.field+": %#v")
+				values = append(values, "b."+impl.field)
+			}
+		}
+	}
 
-				if !ignore {
-					sw.Do("func (b *$.typeBase|raw$Builder) $.name$() *$.type|raw$Builder {\n", argsMember)
-					if mt.Kind == types.Pointer {
-						sw.Do("if b.$.name$Builder == nil {\n", argsMember)
-						sw.Do("b.$.name$Builder = New$.type|raw$Builder()\n", argsMember)
-						sw.Do("}\n", generator.Args{})
-						sw.Do("return b.$.name$Builder\n", argsMember)
-					} else {
-						sw.Do("return &b.$.name$Builder\n", argsMember)
-					}
-					sw.Do("}\n\n", generator.Args{})
-				}
+	args := generator.Args{
+		"type":    t,
+		"sprintf": sprintfFunc,
+		"format":  strings.Join(fields, ", "),
+		"values":  strings.Join(values, ", "),
+	}
+	sw.Do("// GoString formats the builder with its nested builders, for %#v.\n", args)
+	sw.Do("func (b *$.type|raw$Builder) GoString() string {\n", args)
+	sw.Do("if b == nil {\n", args)
+	sw.Do("return \"(*$.type|raw$Builder)(nil)\"\n", args)
+	sw.Do("}\n", args)
+	sw.Do("return $.sprintf|raw$(\"&$.type|raw$Builder{$.format$}\", $.values$)\n", args)
+	sw.Do("}\n\n", args)
+}
 
-				for _, em := range umt.Members {
-					if em.Type.IsPrimitive() {
-						argsMemberEmbedded := generator.Args{
-							"typeBase":   argsMember["typeBase"],
-							"type":       argsMember["type"],
-							"typeEmbbed": em.Type,
-							"typeAlias":  argsMember["typeAlias"],
-							"name":       argsMember["name"],
-							"nameEmbbed": em.Name,
-							"nameMethod": argsMember["nameMethod"],
-						}
-						sw.Do("func (b *$.typeBase|raw$Builder) $.nameEmbbed$(input $.typeEmbbed|raw$) *$.typeBase|raw$Builder {\n", argsMemberEmbedded)
-						sw.Do("b.$.name$Builder.$.nameEmbbed$(input)\n", argsMemberEmbedded)
-						sw.Do("return b\n", generator.Args{})
-						sw.Do("}\n\n", generator.Args{})
-					}
-				}
+// structMethodClone copies the builder, cloning its nested builders and
+// copying the slices and maps of the model the setters replace or append to.
+func (g *genDeepCopy) structMethodClone(sw *generator.SnippetWriter, t *types.Type) {
+	if g.handWritten(t, "Clone") {
+		return
+	}
 
-			} else if !g.isOtherPackage(umt.Name.Package) || !g.isOtherPackage(types.ParseFullyQualifiedName(umt.Name.Name).Package) {
-				sw.Do("func (b *$.typeBase|raw$Builder) $.name$() *$.type|raw$Builder {\n", argsMember)
-				if mt.Kind == types.Pointer {
-					sw.Do("if b.$.nameMethod$ == nil {\n", argsMember)
-					sw.Do("b.$.nameMethod$ = New$.type|raw$Builder()\n", argsMember)
-					sw.Do("}\n", generator.Args{})
+	args := generator.Args{
+		"type": t,
+	}
+	sw.Do("// Clone returns a copy of the builder, its nested builders cloned, to\n", args)
+	sw.Do("// set the copies independently.\n", args)
+	sw.Do("func (b *$.type|raw$Builder) Clone() *$.type|raw$Builder {\n", args)
+	sw.Do("if b == nil {\n", args)
+	sw.Do("return nil\n", args)
+	sw.Do("}\n", args)
+	sw.Do("clone := *b\n", args)
+	if g.customArgs.AccumulateErrors {
+		sw.Do("clone.errs = append([]error(nil), b.errs...)\n", args)
+	}
+	for _, m := range builderMembers(t) {
+		mt := m.Type
+		umt := underlyingType(mt)
+		if umt.Kind == types.Pointer {
+			umt = umt.Elem
+		}
+		argsMember := generator.Args{
+			"embedded":   embeddedField(m),
+			"name":       m.Name,
+			"nameMethod": propertyName(m),
+			"type":       mt,
+		}
+		if mapType := g.builderMapSlice(m); mapType != nil {
+			g.mapSliceClone(sw, g.mapSliceArgs(t, m, mapType))
+			continue
+		}
+		if list := g.builderLists(m); list != nil {
+			g.listsClone(sw, g.listsArgs(t, m, list))
+			continue
+		}
+		if g.namedList(m) != nil {
+			sw.Do("if b.$.nameMethod$ != nil {\n", argsMember)
+			sw.Do("clone.$.nameMethod$ = b.$.nameMethod$.Clone()\n", argsMember)
+			sw.Do("}\n", argsMember)
+			continue
+		}
+		if umt.Kind == types.Interface {
+			impls, _ := g.implementations(t, m)
+			for _, impl := range impls {
+				sw.Do("clone.$.field$ = b.$.field$.Clone()\n", g.implementationArgs(t, m, impl))
+			}
+			continue
+		}
+		if umt.Kind == types.Slice || umt.Kind == types.Map {
+			if g.hasBuilder(umt.Elem) {
+				argsMember["builder"] = builderOf(builderType(umt.Elem))
+				sw.Do("if b.$.nameMethod$ != nil {\n", argsMember)
+				if umt.Kind == types.Slice {
+					sw.Do("clone.$.nameMethod$ = make([]*$.builder|raw$, len(b.$.nameMethod$))\n", argsMember)
+				} else {
+					argsMember["mapKey"] = umt.Key
+					sw.Do("clone.$.nameMethod$ = make(map[$.mapKey|raw$]*$.builder|raw$, len(b.$.nameMethod$))\n", argsMember)
+				}
+				sw.Do("for k, v := range b.$.nameMethod$ {\n", argsMember)
+				sw.Do("clone.$.nameMethod$[k] = v.Clone()\n", argsMember)
+				sw.Do("}\n", argsMember)
+				sw.Do("}\n", argsMember)
+				if g.orderedMap(m) {
+					sw.Do("clone.$.nameMethod$Keys = append([]$.mapKey|raw$(nil), b.$.nameMethod$Keys...)\n", argsMember)
+				}
+			} else if underlyingType(mt).Kind != types.Pointer {
+				sw.Do("if b.model.$.name$ != nil {\n", argsMember)
+				sw.Do("clone.model.$.name$ = make($.type|raw$, len(b.model.$.name$))\n", argsMember)
+				if umt.Kind == types.Slice {
+					sw.Do("copy(clone.model.$.name$, b.model.$.name$)\n", argsMember)
+				} else {
+					sw.Do("for k, v := range b.model.$.name$ {\n", argsMember)
+					sw.Do("clone.model.$.name$[k] = v\n", argsMember)
+					sw.Do("}\n", argsMember)
 				}
-				sw.Do("return b.$.nameMethod$\n", argsMember)
-				sw.Do("}\n\n", generator.Args{})
+				sw.Do("}\n", argsMember)
+			}
+			continue
+		}
+		if umt.Kind != types.Struct || !g.memberBuilder(t, m, umt) {
+			continue
+		}
+		if g.embedsBuilder(t, m, umt) {
+			if mt.Kind == types.Pointer {
+				sw.Do("clone.$.embedded$ = b.$.embedded$.Clone()\n", argsMember)
 			} else {
-				sw.Do("func (b *$.typeBase|raw$Builder) $.name$(input $.typeAlias|raw$) *$.typeBase|raw$Builder {\n", argsMember)
-				sw.Do("b.model.$.name$ = input\n", argsMember)
-				sw.Do("return b\n", generator.Args{})
-				sw.Do("}\n\n", generator.Args{})
+				sw.Do("clone.$.embedded$ = *b.$.embedded$.Clone()\n", argsMember)
 			}
+		} else {
+			sw.Do("clone.$.nameMethod$ = b.$.nameMethod$.Clone()\n", argsMember)
 		}
 	}
+	sw.Do("return &clone\n", args)
+	sw.Do("}\n\n", args)
 }
 
-func (g *genDeepCopy) structMethodBuild(sw *generator.SnippetWriter, t *types.Type) {
+// newBuilderFromYAMLFunc generates, with --yaml-package, a New<T>BuilderFromYAML
+// constructor decoding a YAML document over the defaults of the builder.
+func (g *genDeepCopy) newBuilderFromYAMLFunc(sw *generator.SnippetWriter, t *types.Type) {
+	if g.customArgs.YAMLPackage == "" || g.handWritten(nil, g.newBuilderOf(t).Name.Name+"FromYAML") {
+		return
+	}
+
+	args := generator.Args{
+		"type": t,
+		"name": t.Name.Name,
+		"unmarshal": &types.Type{
+			Name: types.Name{Package: g.customArgs.YAMLPackage, Name: "Unmarshal"},
+		},
+		"constructor": g.constructorOf(t),
+		"newBuilder":  g.newBuilderOf(t).Name.Name,
+		"build":       g.buildName(t),
+	}
+	sw.Do("// $.newBuilder$FromYAML creates a builder for $.name$ from the YAML document data.\n", args)
+	sw.Do("func $.newBuilder$FromYAML(data []byte) (*$.type|raw$Builder, error) {\n", args)
+	sw.Do("builder := $.constructor|raw$()\n", args)
+	sw.Do("model := builder.$.build$()\n", args)
+	sw.Do("if err := $.unmarshal|raw$(data, &model); err != nil {\n", args)
+	sw.Do("return nil, err\n", generator.Args{})
+	sw.Do("}\n", generator.Args{})
+	sw.Do("builder.fromModel(model)\n", generator.Args{})
+	sw.Do("return builder, nil\n", generator.Args{})
+	sw.Do("}\n\n", generator.Args{})
+}
+
+// structMethodUnmarshalJSON makes the builder a json.Unmarshaler, with
+// --unmarshal-json, decoding the documents over the model it holds.
+func (g *genDeepCopy) structMethodUnmarshalJSON(sw *generator.SnippetWriter, t *types.Type) {
+	if !g.customArgs.UnmarshalJSON || g.handWritten(t, "UnmarshalJSON") {
+		return
+	}
+
+	args := generator.Args{
+		"type":      t,
+		"unmarshal": jsonUnmarshalFunc,
+		"build":     g.buildName(t),
+	}
+	sw.Do("// UnmarshalJSON sets the members present in data, keeping the others.\n", args)
+	sw.Do("func (b *$.type|raw$Builder) UnmarshalJSON(data []byte) error {\n", args)
+	sw.Do("model := b.$.build$()\n", args)
+	sw.Do("if err := $.unmarshal|raw$(data, &model); err != nil {\n", args)
+	sw.Do("return err\n", args)
+	sw.Do("}\n", args)
+	sw.Do("b.fromModel(model)\n", args)
+	sw.Do("return nil\n", args)
+	sw.Do("}\n\n", args)
+}
+
+// newBuilderFromModelFunc exports fromModel for the builders of the other
+// packages generated with --closure or mixed in.
+func (g *genDeepCopy) newBuilderFromModelFunc(sw *generator.SnippetWriter, t *types.Type) {
+	if !(g.closure.has(t) || g.mixins.Has(t.Name.String()) || g.convertedVersion(t)) || g.handWritten(nil, g.fromModelOf(t).Name.Name) {
+		return
+	}
+
+	args := generator.Args{
+		"type":        t,
+		"name":        t.Name.Name,
+		"constructor": g.constructorOf(t),
+		"fromModel":   g.fromModelOf(t).Name.Name,
+	}
+	sw.Do("// $.fromModel$ creates a builder for $.name$ holding model.\n", args)
+	sw.Do("func $.fromModel$(model $.type|raw$) *$.type|raw$Builder {\n", args)
+	sw.Do("builder := $.constructor|raw$()\n", args)
+	sw.Do("builder.fromModel(model)\n", generator.Args{})
+	sw.Do("return builder\n", generator.Args{})
+	sw.Do("}\n\n", generator.Args{})
+}
+
+// structMethodFromModel is the reverse of Build, it replaces the model and
+// rebuilds the nested builders from the values it holds.
+func (g *genDeepCopy) structMethodFromModel(sw *generator.SnippetWriter, t *types.Type) {
+	if g.handWritten(t, "fromModel") {
+		return
+	}
+
 	args := generator.Args{
 		"type": t,
 	}
 
-	sw.Do("func (b *$.type|raw$Builder) Build() $.type|raw$ {\n", args)
-	for _, m := range t.Members {
+	sw.Do("func (b *$.type|raw$Builder) fromModel(model $.type|raw$) {\n", args)
+	sw.Do("b.model = model\n", generator.Args{})
+	for _, m := range builderMembers(t) {
 		mt := m.Type
 		umt := underlyingType(mt)
 		// pumt := umt
@@ -481,47 +2856,123 @@ func (g *genDeepCopy) structMethodBuild(sw *generator.SnippetWriter, t *types.Ty
 		}
 
 		argsMember := generator.Args{
+			"embedded":   embeddedField(m),
 			"name":       m.Name,
-			"nameMethod": strings.ToLower(m.Name),
+			"nameMethod": propertyName(m),
 		}
-		if umt.Kind == types.Unsupported {
-			klog.V(5).Infof("type unsupported %v %v", t, m.Name)
-		} else if umt.Kind == types.Slice {
-			if !umt.Elem.IsPrimitive() {
-				argsSlice := generator.Args{"name": m.Name, "type": umt.Elem}
-				sw.Do("b.model.$.name$ = []$.type|raw${}\n", argsSlice)
-				sw.Do("for _, v := range b.$.nameMethod$ {\n", argsMember)
+		if mapType := g.builderMapSlice(m); mapType != nil {
+			g.mapSliceFromModel(sw, mapType, g.mapSliceArgs(t, m, mapType), "model."+m.Name)
+			continue
+		}
+		if list := g.builderLists(m); list != nil {
+			g.listsFromModel(sw, g.listsArgs(t, m, list), "model."+m.Name)
+			continue
+		}
+		if list := g.namedList(m); list != nil {
+			g.namedListFromModel(sw, g.namedListArgs(t, m, list))
+			continue
+		}
+		if umt.Kind == types.Interface {
+			g.implementationsFromModel(sw, t, m)
+			continue
+		}
+		// The pointers to slices and maps range over their collection, if
+		// any.
+		argsMember["model"] = "model." + m.Name
+		pointer := underlyingType(mt).Kind == types.Pointer
+		if pointer && (umt.Kind == types.Slice || umt.Kind == types.Map) && g.hasBuilder(umt.Elem) {
+			argsMember["model"] = "*model." + m.Name
+			sw.Do("b.$.nameMethod$ = nil\n", argsMember)
+			sw.Do("if model.$.name$ != nil {\n", argsMember)
+		}
+		if umt.Kind == types.Slice {
+			if g.hasBuilder(umt.Elem) {
+				argsMember["builder"] = builderOf(builderType(umt.Elem))
+				sw.Do("b.$.nameMethod$ = []*$.builder|raw${}\n", argsMember)
+				sw.Do("for _, v := range $.model$ {\n", argsMember)
 				if umt.Elem.Kind == types.Pointer {
-					sw.Do("vv := v.Build()\n", generator.Args{})
-					sw.Do("b.model.$.name$ = append(b.model.$.name$, &vv)\n", argsSlice)
+					sw.Do("if v == nil {\n", generator.Args{})
+					sw.Do("continue\n", generator.Args{})
+					sw.Do("}\n", generator.Args{})
+					g.builderFromModel(sw, "builder", true, "*v", builderType(umt.Elem))
 				} else {
-					sw.Do("b.model.$.name$ = append(b.model.$.name$, v.Build())\n", argsSlice)
+					g.builderFromModel(sw, "builder", true, "v", builderType(umt.Elem))
 				}
+				sw.Do("b.$.nameMethod$ = append(b.$.nameMethod$, builder)\n", argsMember)
 				sw.Do("}\n", generator.Args{})
+				if pointer {
+					sw.Do("}\n", generator.Args{})
+				}
 			}
 		} else if umt.Kind == types.Map {
-		} else if umt.Kind == types.Struct {
-			if m.Embedded {
-				if mt.Kind == types.Pointer {
-					sw.Do("if b.$.name$Builder != nil {\n", argsMember)
-					sw.Do("$.nameMethod$ := b.$.name$Builder.Build() \n", argsMember)
-					sw.Do("b.model.$.name$ = &$.nameMethod$ \n", argsMember)
+			if g.hasBuilder(umt.Elem) {
+				argsMember["builder"] = builderOf(builderType(umt.Elem))
+				argsMember["mapKey"] = umt.Key
+				sw.Do("b.$.nameMethod$ = map[$.mapKey|raw$]*$.builder|raw${}\n", argsMember)
+				sw.Do("for k, v := range $.model$ {\n", argsMember)
+				if umt.Elem.Kind == types.Pointer {
+					sw.Do("if v == nil {\n", generator.Args{})
+					sw.Do("continue\n", generator.Args{})
 					sw.Do("}\n", generator.Args{})
+					g.builderFromModel(sw, "builder", true, "*v", builderType(umt.Elem))
 				} else {
-					sw.Do("b.model.$.name$ = b.$.name$Builder.Build() \n", argsMember)
+					g.builderFromModel(sw, "builder", true, "v", builderType(umt.Elem))
 				}
-			} else if !g.isOtherPackage(umt.Name.Package) || !g.isOtherPackage(types.ParseFullyQualifiedName(umt.Name.Name).Package) {
-				if mt.Kind == types.Pointer {
-					sw.Do("if b.$.nameMethod$ != nil {\n", argsMember)
-					sw.Do("$.nameMethod$ := b.$.nameMethod$.Build() \n", argsMember)
-					sw.Do("b.model.$.name$ = &$.nameMethod$\n", argsMember)
+				sw.Do("b.$.nameMethod$[k] = builder\n", argsMember)
+				sw.Do("}\n", generator.Args{})
+				if pointer {
 					sw.Do("}\n", generator.Args{})
-				} else {
-					sw.Do("b.model.$.name$ = b.$.nameMethod$.Build()\n", argsMember)
 				}
+				g.orderedMapSort(sw, m)
+			}
+		} else if umt.Kind == types.Struct {
+			field := ""
+			if g.embedsBuilder(t, m, umt) {
+				field = embeddedField(m)
+			} else if g.memberBuilder(t, m, umt) {
+				field = propertyName(m)
+			} else {
+				continue
+			}
+			argsMember["field"] = field
+			if mt.Kind == types.Pointer {
+				sw.Do("b.$.field$ = nil\n", argsMember)
+				sw.Do("if model.$.name$ != nil {\n", argsMember)
+				g.builderFromModel(sw, "b."+field, false, "*model."+m.Name, umt)
+				sw.Do("}\n", generator.Args{})
+			} else if !g.isLocalType(umt) && g.embedsBuilder(t, m, umt) {
+				// Embedded value builders are not pointers.
+				argsMember["fromModel"] = g.fromModelOf(umt)
+				sw.Do("b.$.field$ = *$.fromModel|raw$(model.$.name$)\n", argsMember)
+			} else if !g.isLocalType(umt) {
+				g.builderFromModel(sw, "b."+field, false, "model."+m.Name, umt)
+			} else {
+				sw.Do("b.$.field$.fromModel(model.$.name$)\n", argsMember)
 			}
 		}
 	}
-	sw.Do("return b.model\n", generator.Args{})
 	sw.Do("}\n\n", generator.Args{})
 }
+
+// builderFromModel writes the assignment to target of a builder of t
+// populated from value, declaring target if requested. The builders of other
+// packages can't call their fromModel, they are created with their
+// New<T>BuilderFromModel instead.
+func (g *genDeepCopy) builderFromModel(sw *generator.SnippetWriter, target string, declare bool, value string, t *types.Type) {
+	args := generator.Args{
+		"target":     target,
+		"assign":     "=",
+		"value":      value,
+		"newBuilder": g.constructorOf(t),
+		"fromModel":  g.fromModelOf(t),
+	}
+	if declare {
+		args["assign"] = ":="
+	}
+	if g.isLocalType(t) {
+		sw.Do("$.target$ $.assign$ $.newBuilder|raw$()\n", args)
+		sw.Do("$.target$.fromModel($.value$)\n", args)
+		return
+	}
+	sw.Do("$.target$ $.assign$ $.fromModel|raw$($.value$)\n", args)
+}
@@ -0,0 +1,272 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// cacheVersion changes whenever the layout of the cache file, or the inputs
+// hashed for a package, change.
+const cacheVersion = 2
+
+type cacheFile struct {
+	Version  int                   `json:"version"`
+	Packages map[string]cacheEntry `json:"packages"`
+}
+
+// cacheEntry is what is remembered of the last generation of a package.
+type cacheEntry struct {
+	Hash string `json:"hash"`
+	// Imports are the imports the generated file adds, needed to check the
+	// import cycles of the other packages.
+	Imports  map[string][]string `json:"imports,omitempty"`
+	Warnings []Warning           `json:"warnings,omitempty"`
+}
+
+// generationCache skips the packages whose inputs did not change since their
+// file was last generated.
+type generationCache struct {
+	lock    sync.Mutex
+	path    string
+	entries map[string]cacheEntry
+	// pending maps the output package paths to the source package and the
+	// hash of the files being generated.
+	pending map[string]pendingEntry
+}
+
+type pendingEntry struct {
+	pkg  string
+	hash string
+}
+
+func loadGenerationCache(path string) (*generationCache, error) {
+	c := &generationCache{
+		path:    path,
+		entries: map[string]cacheEntry{},
+		pending: map[string]pendingEntry{},
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var file cacheFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Version != cacheVersion {
+		// Unreadable or outdated caches are regenerated from scratch.
+		return c, nil
+	}
+	for pkg, entry := range file.Packages {
+		c.entries[pkg] = entry
+	}
+	return c, nil
+}
+
+// lookup returns the entry of pkg if it was generated from the same inputs.
+func (c *generationCache) lookup(pkg, hash string) (cacheEntry, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.entries[pkg]
+	return entry, ok && entry.Hash == hash
+}
+
+// expect registers the package about to be generated into outputPath.
+func (c *generationCache) expect(outputPath, pkg, hash string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.pending[outputPath] = pendingEntry{pkg: pkg, hash: hash}
+}
+
+// commit records the package generated into outputPath and saves the cache.
+func (c *generationCache) commit(outputPath string, entry func(pkg string) cacheEntry) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	pending, ok := c.pending[outputPath]
+	if !ok {
+		return nil
+	}
+	delete(c.pending, outputPath)
+	e := entry(pending.pkg)
+	e.Hash = pending.hash
+	c.entries[pending.pkg] = e
+	return c.save()
+}
+
+func (c *generationCache) save() error {
+	data, err := json.MarshalIndent(cacheFile{Version: cacheVersion, Packages: c.entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// cachingFile is a file type committing the packages to the cache once their
+// file is written.
+type cachingFile struct {
+	generator.FileType
+	cache  *generationCache
+	graph  *importGraph
+	report *warningReport
+}
+
+func (ft *cachingFile) AssembleFile(f *generator.File, pathname string) error {
+	if err := ft.FileType.AssembleFile(f, pathname); err != nil {
+		return err
+	}
+	return ft.cache.commit(f.PackagePath, func(pkg string) cacheEntry {
+		return cacheEntry{
+			Imports:  ft.graph.generatedImports(pkg),
+			Warnings: ft.report.forPackage(pkg),
+		}
+	})
+}
+
+// fingerprintArgs are the arguments of a package the fingerprint encodes: the
+// CustomArgs, and the settings resolved for the package in place of the
+// fields they tag json:"-".
+type fingerprintArgs struct {
+	CustomArgs         *CustomArgs
+	Config             Config
+	Initialisms        []string
+	OutputFileBaseName string
+	SetterPrefix       string
+}
+
+// generatorFingerprint hashes what, besides the package itself, changes the
+// generated files: the generator binary, its arguments and the file headers.
+func generatorFingerprint(customArgs *CustomArgs, settings *packageSettings) ([]byte, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(executable)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\n", cacheVersion)
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	err = json.NewEncoder(h).Encode(fingerprintArgs{
+		CustomArgs:         customArgs,
+		Config:             customArgs.config,
+		Initialisms:        customArgs.initialisms().List(),
+		OutputFileBaseName: settings.outputFileBaseName,
+		SetterPrefix:       settings.setterPrefix,
+	})
+	if err != nil {
+		return nil, err
+	}
+	h.Write(settings.header)
+	for _, name := range sets.StringKeySet(settings.headers).List() {
+		fmt.Fprintf(h, "%s\n", name)
+		h.Write(settings.headers[name])
+	}
+	return h.Sum(nil), nil
+}
+
+// packageHash hashes the declarations of pkg the generated file depends on,
+// including which structs of other packages have builders with --closure and
+// which are mixed in, and the declarations of the other API versions of the
+// package it converts its structs to.
+func packageHash(fingerprint []byte, pkg *types.Package, declared sets.String, cl *closure, mixins sets.String, versions []*types.Package) string {
+	h := sha256.New()
+	h.Write(fingerprint)
+	fmt.Fprintf(h, "%s %q\n", filepath.ToSlash(pkg.Path), pkg.Comments)
+	hashPackageTypes(h, pkg)
+	fmt.Fprintf(h, "declared %q\n", declared.List())
+	if cl != nil {
+		fmt.Fprintf(h, "closure %q\n", cl.types.List())
+	}
+	fmt.Fprintf(h, "mixins %q\n", mixins.List())
+	for _, version := range versions {
+		fmt.Fprintf(h, "version %s\n", filepath.ToSlash(version.Path))
+		hashPackageTypes(h, version)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// hashPackageTypes writes the types of pkg, with their members and methods.
+func hashPackageTypes(h hash.Hash, pkg *types.Package) {
+	names := make([]string, 0, len(pkg.Types))
+	for name := range pkg.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t := pkg.Types[name]
+		fmt.Fprintf(h, "type %s %s %q %q\n", name, t.Kind, t.SecondClosestCommentLines, t.CommentLines)
+		hashType(h, t)
+		for _, m := range t.Members {
+			fmt.Fprintf(h, "member %s %v %q %q ", m.Name, m.Embedded, m.Tags, m.CommentLines)
+			hashType(h, m.Type)
+		}
+		methods := make([]string, 0, len(t.Methods))
+		for method := range t.Methods {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		fmt.Fprintf(h, "methods %q\n", methods)
+	}
+}
+
+// hashType writes the structure of t up to the named structs and interfaces,
+// whose members are hashed with their own package.
+func hashType(h hash.Hash, t *types.Type) {
+	if t == nil {
+		fmt.Fprintf(h, "nil\n")
+		return
+	}
+	fmt.Fprintf(h, "%s/%s ", t.Name, t.Kind)
+	switch t.Kind {
+	case types.Alias:
+		hashType(h, t.Underlying)
+	case types.Pointer, types.Slice, types.Array, types.Chan:
+		hashType(h, t.Elem)
+	case types.Map:
+		hashType(h, t.Key)
+		hashType(h, t.Elem)
+	case types.Struct:
+		if t.Name.Name == "" {
+			// Anonymous structs have no declaration of their own.
+			for _, m := range t.Members {
+				fmt.Fprintf(h, "%s %q ", m.Name, m.Tags)
+				hashType(h, m.Type)
+			}
+		}
+	}
+	fmt.Fprintf(h, "\n")
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+)
+
+// unfingerprinted are the fields of CustomArgs tagged json:"-", which don't
+// change the generated files or are hashed once resolved for each package.
+var unfingerprinted = sets.NewString("SetterPrefix", "Initialisms", "InputGroups", "DryRun", "Stdout", "CacheFile", "Parallelism", "ConfigFile", "Config")
+
+func TestGeneratorFingerprint(t *testing.T) {
+	settings := packageSettings{outputFileBaseName: "zz_generated", header: []byte("// header\n")}
+	base, err := generatorFingerprint(&CustomArgs{}, &settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields := reflect.TypeOf(CustomArgs{})
+	for i := 0; i < fields.NumField(); i++ {
+		field := fields.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Tag.Get("json") == "-" {
+			if !unfingerprinted.Has(field.Name) {
+				t.Errorf("%s is left out of the fingerprint", field.Name)
+			}
+			continue
+		}
+		customArgs := &CustomArgs{}
+		value := reflect.ValueOf(customArgs).Elem().Field(i)
+		switch value.Kind() {
+		case reflect.Bool:
+			value.SetBool(true)
+		case reflect.String:
+			value.SetString("changed")
+		case reflect.Int:
+			value.SetInt(1)
+		case reflect.Slice:
+			value.Set(reflect.MakeSlice(value.Type(), 1, 1))
+		default:
+			t.Errorf("%s has the unexpected kind %s", field.Name, value.Kind())
+			continue
+		}
+		fingerprint, err := generatorFingerprint(customArgs, &settings)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(fingerprint, base) {
+			t.Errorf("changing %s kept the fingerprint", field.Name)
+		}
+	}
+
+	resolved := map[string]func(*CustomArgs, *packageSettings){
+		"config": func(ca *CustomArgs, _ *packageSettings) {
+			ca.config.ApplyConfigurations = map[string]string{"a": "b"}
+		},
+		"initialisms":    func(ca *CustomArgs, _ *packageSettings) { ca.Initialisms = []string{"ID"} },
+		"file base name": func(_ *CustomArgs, s *packageSettings) { s.outputFileBaseName = "zz_other" },
+		"setter prefix":  func(_ *CustomArgs, s *packageSettings) { s.setterPrefix = "With" },
+		"header":         func(_ *CustomArgs, s *packageSettings) { s.header = []byte("// other\n") },
+		"constrained header": func(_ *CustomArgs, s *packageSettings) {
+			s.headers = map[string][]byte{"zz_generated_linux.go": []byte("//go:build linux\n")}
+		},
+	}
+	for name, change := range resolved {
+		customArgs, changed := &CustomArgs{}, settings
+		change(customArgs, &changed)
+		fingerprint, err := generatorFingerprint(customArgs, &changed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(fingerprint, base) {
+			t.Errorf("changing the %s kept the fingerprint", name)
+		}
+	}
+}
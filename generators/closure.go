@@ -0,0 +1,139 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"go/build"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/types"
+)
+
+// closure holds the structs of other packages reachable from the members of
+// the input packages, which get builders with --closure.
+type closure struct {
+	// packages are the paths of the other packages with reachable types.
+	packages sets.String
+	// types are the reachable types, as types.Name.String().
+	types sets.String
+}
+
+// computeClosure walks the builder members of the structs of the roots getting
+// a builder, collecting those of the packages accepted by eligible.
+func computeClosure(universe types.Universe, roots sets.String, eligible func(pkg string) bool, generates func(*types.Type) bool) *closure {
+	c := &closure{packages: sets.NewString(), types: sets.NewString()}
+	var queue []*types.Type
+	for _, root := range roots.List() {
+		pkg := universe[root]
+		if pkg == nil {
+			continue
+		}
+		for _, t := range pkg.Types {
+			if t.Kind == types.Struct && generates(t) {
+				queue = append(queue, t)
+			}
+		}
+	}
+
+	seen := map[*types.Type]bool{}
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		for _, m := range builderMembers(t) {
+			st := closureTarget(m.Type)
+			if st == nil || !generates(st) || roots.Has(st.Name.Package) {
+				continue
+			}
+			if isStandardPackage(st.Name.Package) || !eligible(st.Name.Package) {
+				continue
+			}
+			c.packages.Insert(st.Name.Package)
+			c.types.Insert(st.Name.String())
+			queue = append(queue, st)
+		}
+	}
+	return c
+}
+
+// closureTarget returns the named struct a member of type t would be built
+// with, following the same aliases, pointers, slices and maps as the
+// builders do.
+func closureTarget(t *types.Type) *types.Type {
+	t = builderType(t)
+	if t.Kind == types.Slice || t.Kind == types.Map {
+		t = builderType(t.Elem)
+	}
+	if t.Kind != types.Struct || t.Name.Name == "" {
+		return nil
+	}
+	return t
+}
+
+func (c *closure) has(t *types.Type) bool {
+	return c != nil && c.types.Has(t.Name.String())
+}
+
+// isStandardPackage reports whether pkg belongs to the standard library, whose
+// import paths have no dot in their first element.
+func isStandardPackage(pkg string) bool {
+	return !strings.Contains(strings.Split(pkg, "/")[0], ".")
+}
+
+// packageDirUnder returns a function reporting whether the sources of a
+// package are in base, and can therefore receive a generated file.
+func packageDirUnder(base string) func(pkg string) bool {
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		return func(string) bool { return false }
+	}
+	cache := map[string]bool{}
+	return func(pkg string) bool {
+		if result, ok := cache[pkg]; ok {
+			return result
+		}
+		result := false
+		if p, err := build.Import(pkg, ".", build.FindOnly); err == nil {
+			result = isSubdir(absBase, p.Dir)
+		}
+		cache[pkg] = result
+		return result
+	}
+}
+
+// isSubdir reports whether dir is base or one of its subdirectories.
+func isSubdir(base, dir string) bool {
+	rel, err := filepath.Rel(base, dir)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// closureOutputPath returns the path, relative to base, of the package whose
+// sources are in dir.
+func closureOutputPath(base, dir string) (string, error) {
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(absBase, dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the configuration of the builders read from the file of
+// --config, in YAML or JSON.
+type Config struct {
+	// Types configure how the builders set the members of the types they
+	// name, as <import path>.<name>, in every builder holding them.
+	Types map[string]TypeConfig `json:"types,omitempty"`
+	// External configure how the builders set the members of the types of
+	// other packages without builders, named like Types.
+	External map[string]ExternalType `json:"external,omitempty"`
+	// ApplyConfigurations are, with --kubernetes, the import paths of the
+	// packages of the apply configurations of the types of the packages, by
+	// import path. Those of the Kubernetes API types are known.
+	ApplyConfigurations map[string]string `json:"applyConfigurations,omitempty"`
+}
+
+// TypeConfig configures how the builders set the members of a type.
+type TypeConfig struct {
+	// Handler names the handler generating the methods of the builders for
+	// the members of the type, one of union.
+	Handler string `json:"handler"`
+	// String is, for the union handler, the member of the type holding its
+	// string form.
+	String string `json:"string,omitempty"`
+}
+
+// typeHandler generates the methods of the builders setting the members of a
+// configured type, instead of the default ones.
+type typeHandler interface {
+	// handles reports whether the handler generates the methods setting the
+	// member m of t, those it does not handle getting the default ones.
+	handles(g *genDeepCopy, t *types.Type, m types.Member) bool
+	// check returns an error if the members of type t can't be set as
+	// configured.
+	check(g *genDeepCopy, t *types.Type) error
+	// methods writes the methods of the builder of t setting its member m.
+	methods(g *genDeepCopy, sw *generator.SnippetWriter, t *types.Type, m types.Member, argsMember generator.Args)
+	// smokeCall returns the name of a method setting the member m in the
+	// smoke tests, and the format of its call on the builder b.
+	smokeCall(g *genDeepCopy, t *types.Type, m types.Member) (name, format string)
+	// packages returns the packages the methods import besides that of the
+	// type, planned before generating for the import cycles.
+	packages() []string
+}
+
+// typeHandlers make the handlers of the configured types, by handler name.
+var typeHandlers = map[string]func(config TypeConfig) (typeHandler, error){
+	unionHandlerName: newUnionHandler,
+}
+
+// readConfig returns Config, read from ConfigFile when nil, and the handlers
+// of the configured types.
+func (ca *CustomArgs) readConfig() (Config, map[types.Name]typeHandler, error) {
+	if ca.Config != nil {
+		return ca.parseConfig(*ca.Config)
+	}
+	if ca.ConfigFile == "" {
+		return Config{}, nil, nil
+	}
+	data, err := os.ReadFile(ca.ConfigFile)
+	if err != nil {
+		return Config{}, nil, fmt.Errorf("Failed reading --config: %v", err)
+	}
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return Config{}, nil, fmt.Errorf("invalid --config %s: %v", ca.ConfigFile, err)
+	}
+	return ca.parseConfig(config)
+}
+
+// parseConfig returns config and the handlers of the types it configures.
+func (ca *CustomArgs) parseConfig(config Config) (Config, map[types.Name]typeHandler, error) {
+	handlers := map[types.Name]typeHandler{}
+	names := make([]string, 0, len(config.Types))
+	for name := range config.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		typeName, err := parseQualifiedName(name)
+		if err != nil {
+			return Config{}, nil, fmt.Errorf("configured type: %v", err)
+		}
+		typeConfig := config.Types[name]
+		newHandler, ok := typeHandlers[typeConfig.Handler]
+		if !ok {
+			return Config{}, nil, fmt.Errorf("configured type %s: unknown handler %q, must be one of %v", name, typeConfig.Handler, handlerNames())
+		}
+		handler, err := newHandler(typeConfig)
+		if err != nil {
+			return Config{}, nil, fmt.Errorf("configured type %s: %v", name, err)
+		}
+		handlers[typeName] = handler
+	}
+
+	names = names[:0]
+	for name := range config.External {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		typeName, err := parseQualifiedName(name)
+		if err != nil {
+			return Config{}, nil, fmt.Errorf("external type: %v", err)
+		}
+		if _, ok := config.Types[name]; ok {
+			return Config{}, nil, fmt.Errorf("the type %s is configured both as a type and as an external type", name)
+		}
+		handler, err := newExternalHandler(typeName, config.External[name])
+		if err != nil {
+			return Config{}, nil, fmt.Errorf("external type %s: %v", name, err)
+		}
+		if handler != nil {
+			handlers[typeName] = handler
+		}
+	}
+	return config, handlers, nil
+}
+
+// handlerNames returns the names of the handlers of the configured types.
+func handlerNames() []string {
+	var names []string
+	for name := range typeHandlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// typeHandler returns the handler of the configured type of the member m of
+// t, directly or by pointer, nil if it is left to the default methods.
+func (g *genDeepCopy) typeHandler(t *types.Type, m types.Member) typeHandler {
+	handler, ok := g.customArgs.handlers[builderType(m.Type).Name]
+	if !ok || !handler.handles(g, t, m) {
+		return nil
+	}
+	return handler
+}
+
+// checkTypeHandlers returns an error if a member of t of a configured type
+// can't be set as configured.
+func (g *genDeepCopy) checkTypeHandlers(t *types.Type) error {
+	for _, m := range builderMembers(t) {
+		if handler := g.typeHandler(t, m); handler != nil {
+			if err := handler.check(g, builderType(m.Type)); err != nil {
+				return fmt.Errorf("%v.%s: %v", t, m.Name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"go/ast"
+	"go/build"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// sourceContext returns the build context selecting the source files of the
+// input packages: the default one with --build-tags, and the build tag
+// excluding the generated files.
+func (ca *CustomArgs) sourceContext(generatedBuildTag string) build.Context {
+	ctx := build.Default
+	ctx.BuildTags = append(append(append([]string{}, ctx.BuildTags...), ca.BuildTags...), generatedBuildTag)
+	return ctx
+}
+
+// sourceConstraint is the //go:build constraint of the source file declaring
+// a type.
+type sourceConstraint struct {
+	file string
+	expr constraint.Expr
+}
+
+// typeConstraints returns the //go:build constraints of the source files of
+// pkg selected by ctx, by the names of the types they declare, for the
+// builders of these types to be built with the same constraints. The types
+// of the files without constraint are not listed.
+func typeConstraints(pkg *types.Package, ctx build.Context, outputFileName string) (map[string]sourceConstraint, error) {
+	result := map[string]sourceConstraint{}
+	if pkg.SourcePath == "" {
+		return result, nil
+	}
+
+	entries, err := os.ReadDir(pkg.SourcePath)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == outputFileName {
+			continue
+		}
+		if match, err := ctx.MatchFile(pkg.SourcePath, name); err != nil || !match {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(pkg.SourcePath, name), nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		expr := fileConstraint(file)
+		if expr == nil {
+			continue
+		}
+		for _, typeName := range declaredTypes(file) {
+			result[typeName] = sourceConstraint{file: name, expr: expr}
+		}
+	}
+	return result, nil
+}
+
+// fileConstraint returns the //go:build constraint of file, nil if it has
+// none. Like the go command, only the comments before the package clause are
+// considered.
+func fileConstraint(file *ast.File) constraint.Expr {
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if constraint.IsGoBuild(comment.Text) {
+				if expr, err := constraint.Parse(comment.Text); err == nil {
+					return expr
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// constrainedFile is the generated file of the builders of the types whose
+// source files share a //go:build constraint.
+type constrainedFile struct {
+	// name suffixes the name of the generated file: the name of the first of
+	// the source files, without its extension and GOOS suffix.
+	name  string
+	expr  constraint.Expr
+	types sets.String
+}
+
+// constrainedFiles returns the generated files of the types of pkg selected
+// by generates whose source files are constrained, one per distinct
+// constraint, in the order of their names.
+func constrainedFiles(pkg *types.Package, constraints map[string]sourceConstraint, generates func(*types.Type) bool) []*constrainedFile {
+	byExpr := map[string]*constrainedFile{}
+	for name, source := range constraints {
+		if t := pkg.Types[name]; t == nil || !generates(t) {
+			continue
+		}
+		stem := strings.TrimSuffix(source.file, ".go")
+		if goos := fileOS(source.file); goos != "" {
+			stem = strings.TrimSuffix(stem, "_"+goos)
+		}
+		f := byExpr[source.expr.String()]
+		if f == nil {
+			f = &constrainedFile{name: stem, expr: source.expr, types: sets.NewString()}
+			byExpr[source.expr.String()] = f
+		} else if stem < f.name {
+			f.name = stem
+		}
+		f.types.Insert(name)
+	}
+	result := make([]*constrainedFile, 0, len(byExpr))
+	for _, f := range byExpr {
+		result = append(result, f)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].name < result[j].name
+	})
+	return result
+}
+
+// constrainedPackage is a package whose files of the builders of the
+// constrained types get the headers of their constraints.
+type constrainedPackage struct {
+	generator.DefaultPackage
+	// headers are the headers of the files of the constrained types, by file
+	// name.
+	headers map[string][]byte
+}
+
+func (p *constrainedPackage) Header(filename string) []byte {
+	if header, ok := p.headers[filename]; ok {
+		return header
+	}
+	return p.DefaultPackage.Header(filename)
+}
+
+// fileGenerator restricts a generator to the types of its file, those named
+// by types or, with exclude, the others.
+type fileGenerator struct {
+	generator.Generator
+	types   sets.String
+	exclude bool
+}
+
+func (g *fileGenerator) Filter(c *generator.Context, t *types.Type) bool {
+	return g.types.Has(t.Name.Name) != g.exclude && g.Generator.Filter(c, t)
+}
+
+// declaredTypes returns the names of the types declared by file.
+func declaredTypes(file *ast.File) []string {
+	var result []string
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			result = append(result, spec.(*ast.TypeSpec).Name.Name)
+		}
+	}
+	return result
+}
+
+// knownOS are the values of GOOS, the suffixes of the names of the files
+// specific to an operating system like types_linux.go.
+var knownOS = sets.NewString("aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos", "ios", "js", "linux",
+	"nacl", "netbsd", "openbsd", "plan9", "solaris", "wasip1", "windows", "zos")
+
+// fileOS returns the GOOS named by the suffix of the file name, like linux
+// for types_linux.go, or "" for the files of all the operating systems. Like
+// the go command, the suffix is looked for before the first dot.
+func fileOS(name string) string {
+	base, _, _ := strings.Cut(name, ".")
+	if i := strings.LastIndex(base, "_"); i > 0 && knownOS.Has(base[i+1:]) {
+		return base[i+1:]
+	}
+	return ""
+}
+
+// platformTypes returns the names of the types declared by the files of the
+// directory dir specific to an operating system, by GOOS, the files being
+// selected by ctx with GOOS set to theirs.
+func platformTypes(dir string, ctx build.Context) (map[string]sets.String, error) {
+	result := map[string]sets.String{}
+	if dir == "" {
+		return result, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		goos := fileOS(name)
+		if entry.IsDir() || goos == "" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		ctx.GOOS = goos
+		if match, err := ctx.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		if result[goos] == nil {
+			result[goos] = sets.NewString()
+		}
+		result[goos].Insert(declaredTypes(file)...)
+	}
+	return result, nil
+}
+
+// inputPlatforms returns the input packages of the universe with files
+// specific to an operating system, by GOOS, their builders generated by a
+// parse per GOOS.
+func inputPlatforms(universe types.Universe, inputs []string, ctx build.Context) (map[string][]string, error) {
+	result := map[string][]string{}
+	for _, path := range inputs {
+		pkg := universe[path]
+		if pkg == nil {
+			continue
+		}
+		platforms, err := platformTypes(pkg.SourcePath, ctx)
+		if err != nil {
+			return nil, err
+		}
+		for goos := range platforms {
+			result[goos] = append(result[goos], path)
+		}
+	}
+	return result, nil
+}
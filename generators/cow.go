@@ -0,0 +1,147 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// With --copy-on-write, the setters leave their builder unchanged and return
+// a changed copy of it, sharing the members they don't change. The slices and
+// maps are never changed in place, and the nested builders are replaced by
+// the builders returned by the update functions of the methods of their
+// members, so the builders can be shared by goroutines without locks.
+
+// copyOnWrite writes, with --copy-on-write, the first statement of the
+// setters, replacing their receiver by the copy they change and return.
+func (g *genDeepCopy) copyOnWrite(sw *generator.SnippetWriter) {
+	if g.customArgs.CopyOnWrite {
+		sw.Do("b = b.copyOnWrite()\n", nil)
+	}
+}
+
+// appendable returns the slice expression the setters append to, its
+// capacity capped with --copy-on-write for append to copy the slices shared
+// with other builders.
+func (g *genDeepCopy) appendable(slice string) string {
+	if !g.customArgs.CopyOnWrite {
+		return slice
+	}
+	return slice + "[:len(" + slice + "):len(" + slice + ")]"
+}
+
+// structMethodCopyOnWrite writes, with --copy-on-write, the copyOnWrite
+// method of the builder of t, returning the copy the setters change.
+func (g *genDeepCopy) structMethodCopyOnWrite(sw *generator.SnippetWriter, t *types.Type) {
+	if !g.customArgs.CopyOnWrite || g.handWritten(t, "copyOnWrite") {
+		return
+	}
+
+	args := generator.Args{
+		"type": t,
+	}
+	sw.Do("// copyOnWrite returns the copy of the builder a setter changes.\n", args)
+	sw.Do("func (b *$.type|raw$Builder) copyOnWrite() *$.type|raw$Builder {\n", args)
+	sw.Do("builder := *b\n", args)
+	if g.customArgs.AccumulateErrors {
+		sw.Do("builder.errs = builder.errs[:len(builder.errs):len(builder.errs)]\n", args)
+	}
+	sw.Do("return &builder\n", args)
+	sw.Do("}\n\n", args)
+}
+
+// copyOnWriteNestedMethod writes the method of the member m of t holding a
+// nested builder, replacing it by the builder returned by update.
+func (g *genDeepCopy) copyOnWriteNestedMethod(sw *generator.SnippetWriter, t *types.Type, m types.Member, argsMember generator.Args) {
+	if g.handWritten(t, argsMember["setter"].(string)) {
+		return
+	}
+
+	embedded := g.embedsBuilder(t, m, builderType(m.Type))
+	field := propertyName(m)
+	if embedded {
+		field = embeddedField(m)
+	}
+	argsMember["field"] = field
+	writeDoc(sw, docLines(m.CommentLines))
+	sw.Do("func (b *$.typeBase|raw$Builder) $.setter$(update func(*$.builder|raw$) *$.builder|raw$) *$.typeBase|raw$Builder {\n", argsMember)
+	sw.Do("b = b.copyOnWrite()\n", argsMember)
+	g.clearOneof(sw, t, m)
+	switch {
+	case m.Type.Kind == types.Pointer:
+		sw.Do("nested := b.$.field$\n", argsMember)
+		sw.Do("if nested == nil {\n", argsMember)
+		sw.Do("nested = $.newBuilder|raw$()\n", argsMember)
+		sw.Do("}\n", argsMember)
+		sw.Do("b.$.field$ = update(nested)\n", argsMember)
+	case embedded:
+		sw.Do("b.$.field$ = *update(&b.$.field$)\n", argsMember)
+	default:
+		sw.Do("b.$.field$ = update(b.$.field$)\n", argsMember)
+	}
+	sw.Do("return b\n", argsMember)
+	sw.Do("}\n\n", argsMember)
+}
+
+// copyOnWriteSliceMethods writes the Add and Remove methods of the member m
+// of t holding a slice of nested builders.
+func (g *genDeepCopy) copyOnWriteSliceMethods(sw *generator.SnippetWriter, t *types.Type, m types.Member, argsMember generator.Args) {
+	base := argsMember["base"].(string)
+	argsMember["slice"] = g.appendable("b." + propertyName(m))
+	if !g.handWritten(t, "Add"+base) {
+		writeDoc(sw, docLines(m.CommentLines))
+		sw.Do("func (b *$.typeBase|raw$Builder) Add$.base$(update func(*$.builder|raw$) *$.builder|raw$) *$.typeBase|raw$Builder {\n", argsMember)
+		sw.Do("b = b.copyOnWrite()\n", argsMember)
+		g.clearOneof(sw, t, m)
+		sw.Do("b.$.nameMethod$ = append($.slice$, update($.newBuilder|raw$()))\n", argsMember)
+		sw.Do("return b\n", argsMember)
+		sw.Do("}\n\n", argsMember)
+	}
+	if !g.handWritten(t, "Remove"+base) {
+		sw.Do("func (b *$.typeBase|raw$Builder) Remove$.base$(remove *$.builder|raw$) *$.typeBase|raw$Builder {\n", argsMember)
+		sw.Do("b = b.copyOnWrite()\n", argsMember)
+		sw.Do("builders := make([]*$.builder|raw$, 0, len(b.$.nameMethod$))\n", argsMember)
+		sw.Do("for _, val := range b.$.nameMethod$ {\n", argsMember)
+		sw.Do("if val != remove {\n", argsMember)
+		sw.Do("builders = append(builders, val)\n", argsMember)
+		sw.Do("}\n", argsMember)
+		sw.Do("}\n", argsMember)
+		sw.Do("b.$.nameMethod$ = builders\n", argsMember)
+		sw.Do("return b\n", argsMember)
+		sw.Do("}\n\n", argsMember)
+	}
+}
+
+// copyOnWriteMapMethods writes the Add method of the member m of t holding a
+// map of nested builders, copying the map.
+func (g *genDeepCopy) copyOnWriteMapMethods(sw *generator.SnippetWriter, t *types.Type, m types.Member, argsMember generator.Args) {
+	if g.handWritten(t, "Add"+argsMember["base"].(string)) {
+		return
+	}
+	writeDoc(sw, docLines(m.CommentLines))
+	sw.Do("func (b *$.typeBase|raw$Builder) Add$.base$(key $.mapKey|raw$, update func(*$.builder|raw$) *$.builder|raw$) *$.typeBase|raw$Builder {\n", argsMember)
+	sw.Do("b = b.copyOnWrite()\n", argsMember)
+	g.clearOneof(sw, t, m)
+	sw.Do("builders := make(map[$.mapKey|raw$]*$.builder|raw$, len(b.$.nameMethod$)+1)\n", argsMember)
+	sw.Do("for k, v := range b.$.nameMethod$ {\n", argsMember)
+	sw.Do("builders[k] = v\n", argsMember)
+	sw.Do("}\n", argsMember)
+	g.orderedMapAdd(sw, m, "key")
+	sw.Do("builders[key] = update($.newBuilder|raw$())\n", argsMember)
+	sw.Do("b.$.nameMethod$ = builders\n", argsMember)
+	sw.Do("return b\n", argsMember)
+	sw.Do("}\n\n", argsMember)
+}
@@ -0,0 +1,279 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// importGraph collects the imports of every generated file, so that imports
+// which are not already present in the source package (for example a builder
+// referencing the builders of another generated package) can be checked for
+// cycles before any file is written. packages() plans the imports of the
+// files it generates and checks them before executing any package, the
+// imports of the generated files being checked again once known.
+type importGraph struct {
+	lock      sync.Mutex
+	universe  types.Universe
+	generated map[string]map[string][]string
+}
+
+func newImportGraph(universe types.Universe) *importGraph {
+	return &importGraph{
+		universe:  universe,
+		generated: map[string]map[string][]string{},
+	}
+}
+
+// imports returns the packages imported by pkg, including the ones the
+// generated file adds.
+func (ig *importGraph) imports(pkg string) []string {
+	seen := map[string]bool{}
+	if p, ok := ig.universe[pkg]; ok {
+		for i := range p.Imports {
+			seen[i] = true
+		}
+	}
+	for i := range ig.generated[pkg] {
+		seen[i] = true
+	}
+
+	result := make([]string, 0, len(seen))
+	for i := range seen {
+		result = append(result, i)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// add registers the imports of the file generated for pkg, mapped to the
+// types whose builders need them, and returns an error describing the chain
+// if one of the imports closes a cycle back to pkg.
+func (ig *importGraph) add(pkg string, imports map[string][]string) error {
+	ig.lock.Lock()
+	defer ig.lock.Unlock()
+
+	ig.generated[pkg] = imports
+	return ig.checkLocked(pkg)
+}
+
+// check returns an error describing the chain if one of the imports
+// registered for the file of pkg closes a cycle back to pkg.
+func (ig *importGraph) check(pkg string) error {
+	ig.lock.Lock()
+	defer ig.lock.Unlock()
+	return ig.checkLocked(pkg)
+}
+
+func (ig *importGraph) checkLocked(pkg string) error {
+	imports := ig.generated[pkg]
+	targets := make([]string, 0, len(imports))
+	for target := range imports {
+		if p, ok := ig.universe[pkg]; ok && p.HasImport(target) {
+			// Already imported by the source, it can't introduce a cycle.
+			continue
+		}
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	for _, target := range targets {
+		path := ig.path(target, pkg)
+		if path == nil {
+			continue
+		}
+		chain := []string{fmt.Sprintf("%s (builders of %s)", pkg, strings.Join(imports[target], ", "))}
+		for i, p := range path {
+			if i == len(path)-1 {
+				chain = append(chain, p)
+				continue
+			}
+			if types := ig.generated[p][path[i+1]]; len(types) > 0 {
+				chain = append(chain, fmt.Sprintf("%s (builders of %s)", p, strings.Join(types, ", ")))
+			} else {
+				chain = append(chain, p)
+			}
+		}
+		return fmt.Errorf("generated code for %q introduces an import cycle: %s", pkg, strings.Join(chain, " -> "))
+	}
+	return nil
+}
+
+// preload registers the imports of a file generated by a previous run, or
+// planned for the file about to be generated.
+func (ig *importGraph) preload(pkg string, imports map[string][]string) {
+	ig.lock.Lock()
+	defer ig.lock.Unlock()
+	ig.generated[pkg] = imports
+}
+
+// generatedImports returns the imports the file generated for pkg adds.
+func (ig *importGraph) generatedImports(pkg string) map[string][]string {
+	ig.lock.Lock()
+	defer ig.lock.Unlock()
+	return ig.generated[pkg]
+}
+
+// path returns the shortest import path from one package to another, or nil.
+func (ig *importGraph) path(from, to string) []string {
+	parents := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == to {
+			path := []string{}
+			for p := current; p != ""; p = parents[p] {
+				path = append([]string{p}, path...)
+			}
+			return path
+		}
+		for _, next := range ig.imports(current) {
+			if _, ok := parents[next]; ok {
+				continue
+			}
+			parents[next] = current
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
+
+// plannedImports returns the packages the builders of the types of pkg
+// passing generates import besides those of the source, mapped to the types
+// whose builders need them: the builders of the structs of other packages,
+// those of the implementations of the interfaces, the packages of the
+// configured types, the apply configurations and the hub API version. The
+// libraries the flags use, which don't import the generated packages, are
+// left out.
+func (g *genDeepCopy) plannedImports(pkg *types.Package, generates func(*types.Type) bool) map[string][]string {
+	imports := map[string][]string{}
+	add := func(target string, t *types.Type) {
+		if target == "" || !g.isOtherPackage(target) {
+			return
+		}
+		for _, name := range imports[target] {
+			if name == t.Name.Name {
+				return
+			}
+		}
+		imports[target] = append(imports[target], t.Name.Name)
+	}
+
+	hub := g.hubVersion()
+	names := make([]string, 0, len(pkg.Types))
+	for name := range pkg.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t := pkg.Types[name]
+		if t.Kind != types.Struct || !generates(t) {
+			continue
+		}
+		if hub != "" && hub != g.targetPackage && g.versionOf(t, hub) != nil {
+			add(hub, t)
+		}
+		// The anonymous structs of the members get builders of their own.
+		for queue := []*types.Type{t}; len(queue) > 0; queue = queue[1:] {
+			st := queue[0]
+			queue = append(queue, inlineStructsOf(st)...)
+			if config := g.applyConfigurationOf(st); config != nil {
+				add(config.Name.Package, t)
+			}
+			for _, m := range builderMembers(st) {
+				for _, mt := range memberStructs(m.Type) {
+					if g.hasBuilder(mt) || g.mixins.Has(mt.Name.String()) {
+						add(mt.Name.Package, t)
+					}
+					if config := g.applyConfigurationOf(mt); config != nil {
+						add(config.Name.Package, t)
+					}
+				}
+				impls, _ := g.implementations(st, m)
+				for _, impl := range impls {
+					add(impl.t.Name.Package, t)
+				}
+				if handler := g.typeHandler(st, m); handler != nil {
+					for _, target := range handler.packages() {
+						add(target, t)
+					}
+				}
+			}
+		}
+	}
+	return imports
+}
+
+// memberStructs returns the named structs a member of type t holds, behind
+// pointers, slices and maps.
+func memberStructs(t *types.Type) []*types.Type {
+	t = underlyingType(t)
+	switch t.Kind {
+	case types.Pointer, types.Slice, types.Array:
+		return memberStructs(t.Elem)
+	case types.Map:
+		return append(memberStructs(t.Key), memberStructs(t.Elem)...)
+	case types.Struct:
+		if t.Name.Name != "" {
+			return []*types.Type{t}
+		}
+	}
+	return nil
+}
+
+// originImportTracker is an ImportTracker remembering which generated types
+// caused each package to be imported.
+type originImportTracker struct {
+	namer.ImportTracker
+	current *types.Type
+	origins map[string][]string
+}
+
+func newOriginImportTracker(tracker namer.ImportTracker) *originImportTracker {
+	return &originImportTracker{
+		ImportTracker: tracker,
+		origins:       map[string][]string{},
+	}
+}
+
+func (t *originImportTracker) AddType(in *types.Type) {
+	t.ImportTracker.AddType(in)
+	t.addOrigin(in.Name.Package)
+}
+
+func (t *originImportTracker) AddSymbol(in types.Name) {
+	t.ImportTracker.AddSymbol(in)
+	t.addOrigin(in.Package)
+}
+
+func (t *originImportTracker) addOrigin(pkg string) {
+	if pkg == "" || t.current == nil {
+		return
+	}
+	name := t.current.Name.Name
+	for _, existing := range t.origins[pkg] {
+		if existing == name {
+			return
+		}
+	}
+	t.origins[pkg] = append(t.origins[pkg], name)
+}
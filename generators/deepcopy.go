@@ -0,0 +1,274 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+	"k8s.io/klog/v2"
+)
+
+// deepCopyGenTagName is the tag of deepcopy-gen, +k8s:deepcopy-gen=false
+// leaving the type without deep-copy functions.
+const deepCopyGenTagName = "k8s:deepcopy-gen"
+
+// generatesDeepCopy reports whether t, a model of the package targetPackage,
+// gets the DeepCopyInto and DeepCopy methods of --deep-copy: the structs
+// getting builders, unless tagged +k8s:deepcopy-gen=false or declaring them,
+// but for the anonymous structs.
+func generatesDeepCopy(customArgs *CustomArgs, targetPackage string, declared sets.String, t *types.Type) bool {
+	if !customArgs.DeepCopy || t.Name.Package != targetPackage || t.Kind != types.Struct || !customArgs.generates(t) || inlineParent(t) != nil {
+		return false
+	}
+	if values := extractTag(t, deepCopyGenTagName); len(values) > 0 && values[0] == "false" {
+		return false
+	}
+	for _, name := range []string{"DeepCopyInto", "DeepCopy"} {
+		if _, ok := t.Methods[name]; ok || declared.Has(t.Name.Name+"."+name) {
+			return false
+		}
+	}
+	return true
+}
+
+// genModelDeepCopy produces the DeepCopyInto and DeepCopy methods of the
+// models of the builders, like deepcopy-gen, with --deep-copy. It writes to
+// the file of the builders.
+type genModelDeepCopy struct {
+	generator.DefaultGen
+	targetPackage string
+	imports       namer.ImportTracker
+	customArgs    *CustomArgs
+	// declared holds the methods hand-written in the target package, which
+	// are not generated.
+	declared sets.String
+}
+
+func newGenModelDeepCopy(sanitizedName, targetPackage string, customArgs *CustomArgs, declared sets.String) *genModelDeepCopy {
+	return &genModelDeepCopy{
+		DefaultGen: generator.DefaultGen{
+			OptionalName: sanitizedName,
+		},
+		targetPackage: targetPackage,
+		imports:       generator.NewImportTracker(),
+		customArgs:    customArgs,
+		declared:      declared,
+	}
+}
+
+func (g *genModelDeepCopy) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.targetPackage, g.imports),
+	}
+}
+
+func (g *genModelDeepCopy) Filter(c *generator.Context, t *types.Type) bool {
+	generated := generatesDeepCopy(g.customArgs, g.targetPackage, g.declared, t)
+	if !generated && t.Name.Package == g.targetPackage && t.Kind == types.Struct && g.customArgs.generates(t) {
+		klog.V(2).Infof("Skipping the deep-copy functions of %s, tagged or already declared in %s", t.Name.Name, g.targetPackage)
+	}
+	return generated
+}
+
+func (g *genModelDeepCopy) Imports(c *generator.Context) []string {
+	return g.imports.ImportLines()
+}
+
+// hasDeepCopyInto reports whether the values of t are copied with their
+// DeepCopyInto method, either generated or declared, those of the aliases
+// being the methods of the aliased types.
+func (g *genModelDeepCopy) hasDeepCopyInto(t *types.Type) bool {
+	for t.Kind == types.Alias {
+		t = t.Underlying
+	}
+	if generatesDeepCopy(g.customArgs, g.targetPackage, g.declared, t) {
+		return true
+	}
+	_, ok := t.Methods["DeepCopyInto"]
+	return ok && t.Kind == types.Struct
+}
+
+// interfaceDeepCopy returns the DeepCopy<Interface> method of the interface
+// t, copying the values it holds, empty if it declares none.
+func interfaceDeepCopy(t *types.Type) string {
+	name := "DeepCopy" + t.Name.Name
+	if _, ok := t.Methods[name]; t.Kind == types.Interface && ok {
+		return name
+	}
+	return ""
+}
+
+// needsDeepCopy reports whether copying the values of t by assignment would
+// share memory between the copies. visiting holds the structs being
+// checked, which the recursive structs refer to.
+func (g *genModelDeepCopy) needsDeepCopy(t *types.Type, visiting map[*types.Type]bool) bool {
+	switch t.Kind {
+	case types.Alias:
+		return g.needsDeepCopy(t.Underlying, visiting)
+	case types.Pointer, types.Slice, types.Map:
+		return true
+	case types.Interface:
+		return interfaceDeepCopy(t) != ""
+	case types.Array:
+		return g.needsDeepCopy(t.Elem, visiting)
+	case types.Struct:
+		if visiting[t] {
+			return true
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+		for _, m := range t.Members {
+			if g.needsDeepCopy(m.Type, visiting) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (g *genModelDeepCopy) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	args := generator.Args{
+		"type": t,
+	}
+	sw.Do("// DeepCopyInto copies the receiver into out, which must be non-nil, with\n", args)
+	sw.Do("// the values its pointers, slices and maps refer to.\n", args)
+	sw.Do("func (in *$.type|raw$) DeepCopyInto(out *$.type|raw$) {\n", args)
+	sw.Do("*out = *in\n", args)
+	for _, m := range t.Members {
+		g.copyValue(sw, "in."+m.Name, "out."+m.Name, inlineMemberType(t, m), 1)
+	}
+	sw.Do("}\n\n", args)
+
+	sw.Do("// DeepCopy returns a deep copy of the receiver, nil for a nil receiver.\n", args)
+	sw.Do("func (in *$.type|raw$) DeepCopy() *$.type|raw$ {\n", args)
+	sw.Do("if in == nil {\n", args)
+	sw.Do("return nil\n", args)
+	sw.Do("}\n", args)
+	sw.Do("out := new($.type|raw$)\n", args)
+	sw.Do("in.DeepCopyInto(out)\n", args)
+	sw.Do("return out\n", args)
+	sw.Do("}\n\n", args)
+
+	for _, intf := range extractDeepCopyInterfacesTag(t) {
+		name := types.ParseFullyQualifiedName(intf)
+		method := "DeepCopy" + name.Name
+		if _, ok := t.Methods[method]; ok || g.declared.Has(t.Name.Name+"."+method) {
+			continue
+		}
+		args["interface"] = &types.Type{Name: name}
+		args["method"] = method
+		sw.Do("// $.method$ returns a deep copy of the receiver as a $.interface|raw$,\n", args)
+		sw.Do("// nil for a nil receiver.\n", args)
+		sw.Do("func (in *$.type|raw$) $.method$() $.interface|raw$ {\n", args)
+		sw.Do("if c := in.DeepCopy(); c != nil {\n", args)
+		sw.Do("return c\n", args)
+		sw.Do("}\n", args)
+		sw.Do("return nil\n", args)
+		sw.Do("}\n\n", args)
+	}
+	return sw.Error()
+}
+
+// copyValue writes the statements replacing the memory out shares with in,
+// of type t, once assigned, by copies. depth numbers the loop variables of
+// the nested slices and maps.
+func (g *genModelDeepCopy) copyValue(sw *generator.SnippetWriter, in, out string, t *types.Type, depth int) {
+	if !g.needsDeepCopy(t, map[*types.Type]bool{}) {
+		return
+	}
+	args := generator.Args{
+		"in":     in,
+		"out":    out,
+		"type":   t,
+		"i":      fmt.Sprintf("i%d", depth),
+		"k":      fmt.Sprintf("key%d", depth),
+		"v":      fmt.Sprintf("value%d", depth),
+		"copied": fmt.Sprintf("copied%d", depth),
+	}
+	if g.hasDeepCopyInto(t) {
+		sw.Do("$.in$.DeepCopyInto(&$.out$)\n", args)
+		return
+	}
+
+	u := underlyingType(t)
+	switch u.Kind {
+	case types.Pointer:
+		args["elem"] = u.Elem
+		sw.Do("if $.in$ != nil {\n", args)
+		sw.Do("$.out$ = new($.elem|raw$)\n", args)
+		if g.hasDeepCopyInto(u.Elem) {
+			sw.Do("$.in$.DeepCopyInto($.out$)\n", args)
+		} else {
+			sw.Do("*$.out$ = *$.in$\n", args)
+			g.copyValue(sw, "(*"+in+")", "(*"+out+")", u.Elem, depth)
+		}
+		sw.Do("}\n", args)
+	case types.Slice:
+		sw.Do("if $.in$ != nil {\n", args)
+		sw.Do("$.out$ = make($.type|raw$, len($.in$))\n", args)
+		sw.Do("copy($.out$, $.in$)\n", args)
+		if g.needsDeepCopy(u.Elem, map[*types.Type]bool{}) {
+			sw.Do("for $.i$ := range $.in$ {\n", args)
+			g.copyValue(sw, in+"["+args["i"].(string)+"]", out+"["+args["i"].(string)+"]", u.Elem, depth+1)
+			sw.Do("}\n", args)
+		}
+		sw.Do("}\n", args)
+	case types.Array:
+		sw.Do("for $.i$ := range $.in$ {\n", args)
+		g.copyValue(sw, in+"["+args["i"].(string)+"]", out+"["+args["i"].(string)+"]", u.Elem, depth+1)
+		sw.Do("}\n", args)
+	case types.Map:
+		sw.Do("if $.in$ != nil {\n", args)
+		sw.Do("$.out$ = make($.type|raw$, len($.in$))\n", args)
+		sw.Do("for $.k$, $.v$ := range $.in$ {\n", args)
+		if g.needsDeepCopy(u.Elem, map[*types.Type]bool{}) {
+			sw.Do("$.copied$ := $.v$\n", args)
+			g.copyValue(sw, args["v"].(string), args["copied"].(string), u.Elem, depth+1)
+			sw.Do("$.out$[$.k$] = $.copied$\n", args)
+		} else {
+			sw.Do("$.out$[$.k$] = $.v$\n", args)
+		}
+		sw.Do("}\n", args)
+		sw.Do("}\n", args)
+	case types.Interface:
+		args["method"] = interfaceDeepCopy(u)
+		sw.Do("if $.in$ != nil {\n", args)
+		sw.Do("$.out$ = $.in$.$.method$()\n", args)
+		sw.Do("}\n", args)
+	case types.Struct:
+		// The structs without DeepCopyInto, anonymous or of other
+		// packages, are copied member by member, those of other packages
+		// but for their unexported members. The anonymous structs of the
+		// package are named by the aliases declared for their builders.
+		for _, m := range u.Members {
+			mt := m.Type
+			switch {
+			case u.Name.Package == g.targetPackage:
+				mt = inlineMemberType(u, m)
+			case u.Name.Package != "" && !token.IsExported(m.Name):
+				klog.V(2).Infof("The unexported member %s of %v is copied by assignment", m.Name, u)
+				continue
+			}
+			g.copyValue(sw, in+"."+m.Name, out+"."+m.Name, mt, depth)
+		}
+	}
+}
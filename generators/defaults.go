@@ -0,0 +1,78 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"go/token"
+
+	"k8s.io/gengo/types"
+)
+
+// The doc.go of a package may carry the type tags below, which then apply to
+// all the types of the package not carrying them, like
+//
+//	// +builder-gen:setter-prefix=With
+//	// +builder-gen:build-name=Create
+//	package api
+
+// packageDefaultTagNames are the type tags the doc.go of the packages can
+// set for all their types.
+var packageDefaultTagNames = []string{setterPrefixTagName, buildNameTagName}
+
+// extractPackageDefaultTags returns the values of the type tags of the doc.go
+// of pkg, by tag name.
+func extractPackageDefaultTags(pkg *types.Package) map[string]string {
+	tags := types.ExtractCommentTags("+", pkg.Comments)
+	result := map[string]string{}
+	for _, name := range packageDefaultTagNames {
+		if values := tags[name]; len(values) > 0 {
+			result[name] = values[0]
+		}
+	}
+	return result
+}
+
+// typeTag returns the value of the tag name of t, or the one of the doc.go of
+// its package when t does not carry it. ok is false when neither does.
+func (ca *CustomArgs) typeTag(t *types.Type, name string) (value string, ok bool) {
+	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
+	if values := types.ExtractCommentTags("+", comments)[name]; len(values) > 0 {
+		return values[0], true
+	}
+	value, ok = ca.packageDefaults[t.Name.Package][name]
+	return value, ok
+}
+
+// typeSetterPrefix returns the prefix of the setters of the builder of t:
+// the one of its +builder-gen:setter-prefix tag, or of the doc.go of its
+// package, the empty value dropping the prefix, and the one of the package
+// settings otherwise.
+func (g *genDeepCopy) typeSetterPrefix(t *types.Type) string {
+	if prefix, ok := g.customArgs.typeTag(t, setterPrefixTagName); ok {
+		return prefix
+	}
+	return g.setterPrefix
+}
+
+// checkSetterPrefix returns an error if the setter prefix of the tags of t is
+// not a Go identifier.
+func (g *genDeepCopy) checkSetterPrefix(t *types.Type) error {
+	prefix, ok := g.customArgs.typeTag(t, setterPrefixTagName)
+	if ok && prefix != "" && !token.IsIdentifier(prefix) {
+		return fmt.Errorf("%v: the prefix %q of the %s tag must be a Go identifier", t, prefix, setterPrefixTagName)
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines around the changes of a hunk.
+const diffContext = 3
+
+// edit is a line of a diff: ' ' kept, '-' removed or '+' added.
+type edit struct {
+	op   byte
+	line string
+}
+
+// unifiedDiff returns the changes from oldData to newData in the unified
+// format, or an empty string if they are equal.
+func unifiedDiff(oldName, newName string, oldData, newData []byte) string {
+	edits := diffLines(splitLines(string(oldData)), splitLines(string(newData)))
+
+	var out strings.Builder
+	oldLine, newLine := 1, 1
+	for i := 0; i < len(edits); {
+		if edits[i].op == ' ' {
+			oldLine, newLine = oldLine+1, newLine+1
+			i++
+			continue
+		}
+
+		// Start the hunk diffContext lines before the change, and extend it
+		// while the next change is close enough to share the context.
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for end < len(edits) {
+			if edits[end].op != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(edits) && edits[next].op == ' ' {
+				next++
+			}
+			if next == len(edits) || next-end > 2*diffContext {
+				end += minInt(diffContext, next-end)
+				break
+			}
+			end = next
+		}
+
+		oldStart, newStart := oldLine-(i-start), newLine-(i-start)
+		oldCount, newCount := 0, 0
+		var body strings.Builder
+		for _, e := range edits[start:end] {
+			switch e.op {
+			case ' ':
+				oldCount, newCount = oldCount+1, newCount+1
+			case '-':
+				oldCount++
+			case '+':
+				newCount++
+			}
+			body.WriteByte(e.op)
+			body.WriteString(e.line)
+			body.WriteByte('\n')
+		}
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+		out.WriteString(body.String())
+
+		for _, e := range edits[i:end] {
+			if e.op != '+' {
+				oldLine++
+			}
+			if e.op != '-' {
+				newLine++
+			}
+		}
+		i = end
+	}
+	return out.String()
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		// Empty ranges refer to the line before the change.
+		start--
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// diffLines returns a shortest edit script from a to b, with the linear space
+// variant of Myers' algorithm: the longest common prefix and suffix are kept,
+// an empty side, like that of a new file, is added or removed at once, and the
+// rest is split at the middle snake of its edit graph.
+func diffLines(a, b []string) []edit {
+	d := differ{a: a, b: b, edits: make([]edit, 0, maxInt(len(a), len(b)))}
+	d.compare(0, len(a), 0, len(b))
+	return d.edits
+}
+
+type differ struct {
+	a, b  []string
+	edits []edit
+}
+
+// compare appends the edits from a[aLo:aHi] to b[bLo:bHi].
+func (d *differ) compare(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.edits = append(d.edits, edit{' ', d.a[aLo]})
+		aLo, bLo = aLo+1, bLo+1
+	}
+	suffix := 0
+	for aLo < aHi-suffix && bLo < bHi-suffix && d.a[aHi-suffix-1] == d.b[bHi-suffix-1] {
+		suffix++
+	}
+	aHi, bHi = aHi-suffix, bHi-suffix
+
+	switch {
+	case aLo == aHi:
+		for _, line := range d.b[bLo:bHi] {
+			d.edits = append(d.edits, edit{'+', line})
+		}
+	case bLo == bHi:
+		for _, line := range d.a[aLo:aHi] {
+			d.edits = append(d.edits, edit{'-', line})
+		}
+	default:
+		x, y, ok := d.middleSnake(aLo, aHi, bLo, bHi)
+		if ok {
+			d.compare(aLo, x, bLo, y)
+			d.compare(x, aHi, y, bHi)
+		} else {
+			d.compare(aLo, aHi, bHi, bHi)
+			d.compare(aHi, aHi, bLo, bHi)
+		}
+	}
+
+	for _, line := range d.a[aHi : aHi+suffix] {
+		d.edits = append(d.edits, edit{' ', line})
+	}
+}
+
+// middleSnake returns a point of a shortest path from (aLo, bLo) to
+// (aHi, bHi) in the edit graph, where the paths searched forward from the
+// start and backward from the end overlap. Only the furthest reaching paths
+// of the current number of edits are kept, in space linear in the lengths.
+func (d *differ) middleSnake(aLo, aHi, bLo, bHi int) (int, int, bool) {
+	n, m := aHi-aLo, bHi-bLo
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	forward, backward := make([]int, 2*maxD+2), make([]int, 2*maxD+2)
+	for i := range forward {
+		forward[i], backward[i] = -1, -1
+	}
+	forward[offset+1], backward[offset+1] = 0, 0
+	delta := n - m
+	// With an odd delta the paths overlap while searching forward.
+	odd := delta%2 != 0
+	// The diagonals which went past the edges of the graph are skipped.
+	fStart, fEnd, bStart, bEnd := 0, 0, 0, 0
+
+	for e := 0; e < maxD; e++ {
+		for k := -e + fStart; k <= e-fEnd; k += 2 {
+			var x int
+			if k == -e || (k != e && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.a[aLo+x] == d.b[bLo+y] {
+				x, y = x+1, y+1
+			}
+			forward[offset+k] = x
+			if x > n {
+				fEnd += 2
+			} else if y > m {
+				fStart += 2
+			} else if odd {
+				if i := offset + delta - k; i >= 0 && i < len(backward) && backward[i] != -1 && x >= n-backward[i] {
+					return aLo + x, bLo + y, true
+				}
+			}
+		}
+		for k := -e + bStart; k <= e-bEnd; k += 2 {
+			var x int
+			if k == -e || (k != e && backward[offset+k-1] < backward[offset+k+1]) {
+				x = backward[offset+k+1]
+			} else {
+				x = backward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.a[aHi-x-1] == d.b[bHi-y-1] {
+				x, y = x+1, y+1
+			}
+			backward[offset+k] = x
+			if x > n {
+				bEnd += 2
+			} else if y > m {
+				bStart += 2
+			} else if !odd {
+				if i := offset + delta - k; i >= 0 && i < len(forward) && forward[i] != -1 && forward[i] >= n-x {
+					fx := forward[i]
+					return aLo + fx, bLo + fx - (i - offset), true
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
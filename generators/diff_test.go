@@ -0,0 +1,167 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// numbered returns the lines from..to, one number per line, with the lines of
+// replaced replaced by their values.
+func numbered(from, to int, replaced map[int]string) string {
+	var b strings.Builder
+	for i := from; i <= to; i++ {
+		if line, ok := replaced[i]; ok {
+			b.WriteString(line)
+		} else {
+			b.WriteString(strconv.Itoa(i))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{name: "equal", old: "a\nb\n", new: "a\nb\n", want: ""},
+		{name: "both empty", old: "", new: "", want: ""},
+		{
+			name: "new file",
+			old:  "",
+			new:  "a\nb\nc\n",
+			want: "--- a/f.go\n+++ b/f.go\n@@ -0,0 +1,3 @@\n+a\n+b\n+c\n",
+		},
+		{
+			name: "removed file",
+			old:  "a\nb\n",
+			new:  "",
+			want: "--- a/f.go\n+++ b/f.go\n@@ -1,2 +0,0 @@\n-a\n-b\n",
+		},
+		{
+			name: "changed line",
+			old:  "a\nb\nc\n",
+			new:  "a\nB\nc\n",
+			want: "--- a/f.go\n+++ b/f.go\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name: "added lines",
+			old:  numbered(1, 10, nil),
+			new:  numbered(1, 10, map[int]string{5: "5\nx\ny"}),
+			want: "--- a/f.go\n+++ b/f.go\n@@ -3,6 +3,8 @@\n 3\n 4\n 5\n+x\n+y\n 6\n 7\n 8\n",
+		},
+		{
+			name: "distant changes",
+			old:  numbered(1, 20, nil),
+			new:  numbered(1, 20, map[int]string{2: "x", 19: "y"}),
+			want: "--- a/f.go\n+++ b/f.go\n" +
+				"@@ -1,5 +1,5 @@\n 1\n-2\n+x\n 3\n 4\n 5\n" +
+				"@@ -16,5 +16,5 @@\n 16\n 17\n 18\n-19\n+y\n 20\n",
+		},
+		{
+			name: "close changes",
+			old:  numbered(1, 12, nil),
+			new:  numbered(1, 12, map[int]string{3: "x", 9: "y"}),
+			want: "--- a/f.go\n+++ b/f.go\n" +
+				"@@ -1,12 +1,12 @@\n 1\n 2\n-3\n+x\n 4\n 5\n 6\n 7\n 8\n-9\n+y\n 10\n 11\n 12\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unifiedDiff("a/f.go", "b/f.go", []byte(tt.old), []byte(tt.new)); got != tt.want {
+				t.Errorf("unifiedDiff() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// lcsLength returns the length of the longest common subsequence of a and b.
+func lcsLength(a, b []string) int {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = maxInt(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+	return lengths[0][0]
+}
+
+func TestDiffLines(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	lines := func() []string {
+		result := make([]string, random.Intn(20))
+		for i := range result {
+			result[i] = string(rune('a' + random.Intn(4)))
+		}
+		return result
+	}
+	for i := 0; i < 2000; i++ {
+		a, b := lines(), lines()
+		edits := diffLines(a, b)
+		var gotA, gotB []string
+		changes := 0
+		for _, e := range edits {
+			if e.op != '+' {
+				gotA = append(gotA, e.line)
+			}
+			if e.op != '-' {
+				gotB = append(gotB, e.line)
+			}
+			if e.op != ' ' {
+				changes++
+			}
+		}
+		if strings.Join(gotA, "") != strings.Join(a, "") || strings.Join(gotB, "") != strings.Join(b, "") {
+			t.Fatalf("diffLines(%q, %q) = %q, which does not edit the first into the second", a, b, edits)
+		}
+		if want := len(a) + len(b) - 2*lcsLength(a, b); changes != want {
+			t.Fatalf("diffLines(%q, %q) has %d changes, want %d", a, b, changes, want)
+		}
+	}
+}
+
+func TestDiffLinesLarge(t *testing.T) {
+	oldData := numbered(1, 10000, nil)
+	newData := numbered(1, 10000, map[int]string{1: "x", 5000: "y", 10000: "z"})
+	if got := strings.Count(unifiedDiff("a/f.go", "b/f.go", []byte(oldData), []byte(newData)), "@@ -"); got != 3 {
+		t.Errorf("unifiedDiff() has %d hunks, want 3", got)
+	}
+	if got := strings.Count(unifiedDiff("/dev/null", "b/f.go", nil, []byte(newData)), "@@ -"); got != 1 {
+		t.Errorf("unifiedDiff() of a new file has %d hunks, want 1", got)
+	}
+
+	// Replacing every line is the worst case of Myers' algorithm.
+	var other strings.Builder
+	for i := 1; i <= 10000; i++ {
+		other.WriteString("line " + strconv.Itoa(i) + "\n")
+	}
+	edits := diffLines(splitLines(oldData), splitLines(other.String()))
+	if len(edits) != 20000 {
+		t.Errorf("diffLines() has %d edits, want 20000", len(edits))
+	}
+}
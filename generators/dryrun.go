@@ -0,0 +1,92 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	"k8s.io/gengo/generator"
+)
+
+// dryRunFile is a Go file type collecting the changes the generation would
+// make to the existing files, to print them once all the packages are
+// generated instead of writing the files.
+type dryRunFile struct {
+	golang *generator.DefaultFileType
+	lock   sync.Mutex
+	diffs  map[string]string
+}
+
+func newDryRunFile() *dryRunFile {
+	return &dryRunFile{
+		golang: generator.NewGolangFile(),
+		diffs:  map[string]string{},
+	}
+}
+
+func (ft *dryRunFile) AssembleFile(f *generator.File, pathname string) error {
+	formatted := &bytes.Buffer{}
+	if err := renderGolangFile(ft.golang, f, pathname, formatted, nil); err != nil {
+		return err
+	}
+	return ft.writeContent(pathname, formatted.Bytes())
+}
+
+// writeContent collects the diff of the file pathname with content.
+func (ft *dryRunFile) writeContent(pathname string, content []byte) error {
+	existing, err := os.ReadFile(pathname)
+	oldName := "a/" + pathname
+	if os.IsNotExist(err) {
+		oldName = "/dev/null"
+	} else if err != nil {
+		return err
+	}
+
+	diff := unifiedDiff(oldName, "b/"+pathname, existing, content)
+	if diff == "" {
+		return nil
+	}
+	ft.lock.Lock()
+	defer ft.lock.Unlock()
+	ft.diffs[pathname] = diff
+	return nil
+}
+
+func (ft *dryRunFile) VerifyFile(f *generator.File, pathname string) error {
+	return ft.golang.VerifyFile(f, pathname)
+}
+
+// flush writes the collected diffs sorted by path, the packages being
+// generated concurrently.
+func (ft *dryRunFile) flush(out io.Writer) error {
+	ft.lock.Lock()
+	defer ft.lock.Unlock()
+	paths := make([]string, 0, len(ft.diffs))
+	for path := range ft.diffs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if _, err := io.WriteString(out, ft.diffs[path]); err != nil {
+			return err
+		}
+	}
+	ft.diffs = map[string]string{}
+	return nil
+}
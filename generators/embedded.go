@@ -0,0 +1,224 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// embeddedIgnored reports whether the struct embedded in t through path, the
+// embedded members from t, is ignored by a +builder-gen:embedded-ignore-method
+// tag: on t, naming the path dotted like TestE.TestD, or on the member
+// embedding it directly in t.
+func embeddedIgnored(t *types.Type, path []types.Member) bool {
+	if len(path) == 1 {
+		values := types.ExtractCommentTags("+", path[0].CommentLines)[embeddedIgnoreMethodTagName]
+		if len(values) > 0 && values[0] != "false" {
+			return true
+		}
+	}
+	dotted := dottedPath(path)
+	for _, method := range extractEmbbedIgnoreMethodTag(t) {
+		if method == dotted {
+			return true
+		}
+	}
+	return false
+}
+
+// dottedPath returns the names of the members of path joined by dots, like
+// TestE.TestD.
+func dottedPath(path []types.Member) string {
+	names := make([]string, len(path))
+	for i, m := range path {
+		names[i] = m.Name
+	}
+	return strings.Join(names, ".")
+}
+
+// embeddedValue reports whether the embedded member m of t is set by value,
+// like the structs without builders, tagged +builder-gen:embedded-value on
+// the member or listed by the tag on t.
+func embeddedValue(t *types.Type, m types.Member) bool {
+	if !m.Embedded {
+		return false
+	}
+	if values := types.ExtractCommentTags("+", m.CommentLines)[embeddedValueTagName]; len(values) > 0 {
+		return values[0] != "false"
+	}
+	for _, name := range extractTag(t, embeddedValueTagName) {
+		if name == m.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// embeddedAccessor reports whether the builder of t gets the method
+// returning the builder of the struct embedded by m, left out with
+// --flatten-embedded.
+func (g *genDeepCopy) embeddedAccessor(t *types.Type, m types.Member) bool {
+	return !g.customArgs.FlattenEmbedded && !embeddedIgnored(t, []types.Member{m})
+}
+
+// forwarded reports whether the setter of the member em of the embedded
+// struct et is forwarded by the outer builders: those of the primitive members, and
+// with --flatten-embedded those taking the value of the member.
+func (g *genDeepCopy) forwarded(et *types.Type, em types.Member) bool {
+	if em.Type.IsPrimitive() {
+		return true
+	}
+	// The members of the structs mixed in from other packages may have
+	// builders there, only their primitive members are forwarded.
+	if !g.customArgs.FlattenEmbedded || !g.isLocalType(et) {
+		return false
+	}
+	u := underlyingType(em.Type)
+	if u.Kind == types.Pointer {
+		u = u.Elem
+	}
+	switch u.Kind {
+	case types.Slice:
+		return !g.hasBuilder(u.Elem)
+	case types.Struct:
+		return !g.memberBuilder(et, em, u)
+	case types.Map, types.Interface:
+		return true
+	}
+	return u.IsPrimitive()
+}
+
+// promotedSetter is a setter of a member of a struct embedded in t, forwarded
+// by the builder of t.
+type promotedSetter struct {
+	// path are the embedded members from t to the struct of member.
+	path   []types.Member
+	member types.Member
+	name   string
+}
+
+// promotedSetters returns the setters the builder of t forwards to the
+// structs it embeds, but the ignored ones, promoted like Go promotes the
+// fields: the names of the methods of the builder of t and of the shallower
+// structs hide the deeper ones, and the names found more than once at the
+// shallowest depth are ambiguous, skipped with a warning.
+func (g *genDeepCopy) promotedSetters(t *types.Type) []promotedSetter {
+	taken := sets.NewString(g.buildName(t), g.buildName(t)+"Ptr")
+	var level [][]types.Member
+	for _, m := range builderMembers(t) {
+		taken.Insert(g.methodName(t, m))
+		if umt := builderType(m.Type); umt.Kind == types.Struct && g.embedsBuilder(t, m, umt) {
+			level = append(level, []types.Member{m})
+		}
+	}
+
+	var result []promotedSetter
+	for len(level) > 0 {
+		var names []string
+		found := map[string][]promotedSetter{}
+		var next [][]types.Member
+		for _, path := range level {
+			et := builderType(path[len(path)-1].Type)
+			for _, em := range builderMembers(et) {
+				if name := g.methodName(et, em); g.forwarded(et, em) && !taken.Has(name) {
+					if _, ok := found[name]; !ok {
+						names = append(names, name)
+					}
+					found[name] = append(found[name], promotedSetter{path: path, member: em, name: name})
+				}
+				// The copies of --copy-on-write would have to be made at
+				// every level, and the structs mixed in from other
+				// packages may have builders only there, only the setters
+				// of the directly embedded structs are forwarded then.
+				deeper := append(path[:len(path):len(path)], em)
+				if emt := builderType(em.Type); !g.customArgs.CopyOnWrite && g.isLocalType(et) && emt.Kind == types.Struct && g.embedsBuilder(et, em, emt) && !embeddedIgnored(t, deeper) {
+					next = append(next, deeper)
+				}
+			}
+		}
+		for _, name := range names {
+			taken.Insert(name)
+			setters := found[name]
+			if len(setters) == 1 {
+				result = append(result, setters[0])
+				continue
+			}
+			paths := make([]string, len(setters))
+			for i, setter := range setters {
+				paths[i] = dottedPath(append(setter.path[:len(setter.path):len(setter.path)], setter.member))
+			}
+			g.warnings = append(g.warnings, Warning{
+				Package: t.Name.Package,
+				Type:    t.Name.Name,
+				Member:  name,
+				Reason:  fmt.Sprintf("ambiguous setter promoted from %s, not forwarded", strings.Join(paths, " and ")),
+			})
+		}
+		level = next
+	}
+	return result
+}
+
+// embeddedSetters writes the setters of promoted forwarded to the struct
+// embedded by m, returning the builder of t so the chains keep its type.
+func (g *genDeepCopy) embeddedSetters(sw *generator.SnippetWriter, t *types.Type, m types.Member, promoted []promotedSetter) {
+	for _, setter := range promoted {
+		if setter.path[0].Name == m.Name && !g.handWritten(t, setter.name) {
+			g.embeddedSetter(sw, t, setter.path, setter.member, setter.name)
+		}
+	}
+}
+
+// embeddedSetter writes the setter name of the member em of the struct
+// embedded through path, allocating the embedded builders held by pointers.
+func (g *genDeepCopy) embeddedSetter(sw *generator.SnippetWriter, t *types.Type, path []types.Member, em types.Member, name string) {
+	args := generator.Args{
+		"typeBase":   t,
+		"typeEmbbed": em.Type,
+		"nameEmbbed": name,
+	}
+	writeDoc(sw, docLines(em.CommentLines))
+	sw.Do("func (b *$.typeBase|raw$Builder) $.nameEmbbed$(input $.typeEmbbed|raw$) *$.typeBase|raw$Builder {\n", args)
+	g.copyOnWrite(sw)
+	field := "b"
+	for _, m := range path {
+		field += "." + embeddedField(m)
+		if m.Type.Kind == types.Pointer {
+			argsField := generator.Args{
+				"field":      field,
+				"newBuilder": g.constructorOf(builderType(m.Type)),
+			}
+			sw.Do("if $.field$ == nil {\n", argsField)
+			sw.Do("$.field$ = $.newBuilder|raw$()\n", argsField)
+			sw.Do("}\n", argsField)
+		}
+	}
+	args["field"] = field
+	switch {
+	case !g.customArgs.CopyOnWrite:
+		sw.Do("$.field$.$.nameEmbbed$(input)\n", args)
+	case path[0].Type.Kind == types.Pointer:
+		sw.Do("$.field$ = $.field$.$.nameEmbbed$(input)\n", args)
+	default:
+		sw.Do("$.field$ = *$.field$.$.nameEmbbed$(input)\n", args)
+	}
+	sw.Do("return b\n", args)
+	sw.Do("}\n\n", args)
+}
@@ -0,0 +1,264 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+	"k8s.io/klog/v2"
+)
+
+// genEqual produces Equal methods on the models of the builders, with --equal.
+// It writes to the file of the builders.
+type genEqual struct {
+	generator.DefaultGen
+	targetPackage string
+	imports       namer.ImportTracker
+	customArgs    *CustomArgs
+	// declared holds the methods hand-written in the target package, which
+	// are not generated.
+	declared sets.String
+	// uncompared holds, by name, why the models of the target package with
+	// members Equal can't compare get no Equal method.
+	uncompared map[string]string
+}
+
+func newGenEqual(sanitizedName, targetPackage string, customArgs *CustomArgs, declared sets.String) *genEqual {
+	return &genEqual{
+		DefaultGen: generator.DefaultGen{
+			OptionalName: sanitizedName,
+		},
+		targetPackage: targetPackage,
+		imports:       generator.NewImportTracker(),
+		customArgs:    customArgs,
+		declared:      declared,
+	}
+}
+
+func (g *genEqual) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.targetPackage, g.imports),
+	}
+}
+
+func (g *genEqual) Filter(c *generator.Context, t *types.Type) bool {
+	if g.uncompared == nil {
+		g.findUncompared(c.Universe.Package(g.targetPackage))
+	}
+	if reason, ok := g.uncompared[t.Name.Name]; ok && g.candidate(t) {
+		klog.Warningf("Skipping %v.Equal, %s", t, reason)
+	} else if t.Name.Package == g.targetPackage && t.Kind == types.Struct && g.customArgs.generates(t) && !g.generated(t) {
+		klog.V(2).Infof("Skipping %s.Equal, it is already declared in %s", t.Name.Name, g.targetPackage)
+	}
+	return g.generated(t)
+}
+
+func (g *genEqual) Imports(c *generator.Context) []string {
+	return g.imports.ImportLines()
+}
+
+// generated reports whether t is a model of this package getting an Equal
+// method.
+func (g *genEqual) generated(t *types.Type) bool {
+	if !g.candidate(t) {
+		return false
+	}
+	_, ok := g.uncompared[t.Name.Name]
+	return !ok
+}
+
+// candidate reports whether t is a model of this package not declaring an
+// Equal method, which gets one if its members can be compared.
+func (g *genEqual) candidate(t *types.Type) bool {
+	if t.Name.Package != g.targetPackage || t.Kind != types.Struct || !g.customArgs.generates(t) {
+		return false
+	}
+	_, ok := t.Methods["Equal"]
+	return !ok && !g.declared.Has(t.Name.Name+".Equal")
+}
+
+// findUncompared records the candidates of pkg with members Equal can't
+// compare, and those with members of the models recorded, until none is
+// added.
+func (g *genEqual) findUncompared(pkg *types.Package) {
+	g.uncompared = map[string]string{}
+	names := make([]string, 0, len(pkg.Types))
+	for name := range pkg.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for added := true; added; {
+		added = false
+		for _, name := range names {
+			t := pkg.Types[name]
+			if !g.generated(t) {
+				continue
+			}
+			for _, m := range t.Members {
+				if reason := g.uncomparable(m.Type, sets.NewString()); reason != "" {
+					g.uncompared[name] = fmt.Sprintf("its member %s holds %s, which can't be compared", m.Name, reason)
+					added = true
+					break
+				}
+			}
+		}
+	}
+}
+
+// hasEqual reports whether values of t are compared with their Equal method,
+// either generated or declared as func (T) Equal(T) bool.
+func (g *genEqual) hasEqual(t *types.Type) bool {
+	if g.generated(t) {
+		return true
+	}
+	method, ok := t.Methods["Equal"]
+	if !ok || method.Signature == nil {
+		return false
+	}
+	sig := method.Signature
+	if sig.Receiver != nil && sig.Receiver.Kind == types.Pointer {
+		return false
+	}
+	return len(sig.Parameters) == 1 && sig.Parameters[0].Name == t.Name &&
+		len(sig.Results) == 1 && sig.Results[0].Name == types.Bool.Name
+}
+
+func (g *genEqual) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	args := generator.Args{
+		"type": t,
+	}
+	sw.Do("// Equal reports whether in and other hold the same values, comparing\n", args)
+	sw.Do("// pointers, slices and maps by their contents.\n", args)
+	sw.Do("func (in $.type|raw$) Equal(other $.type|raw$) bool {\n", args)
+	for _, m := range t.Members {
+		g.compare(sw, "in."+m.Name, "other."+m.Name, m.Type, 1)
+	}
+	sw.Do("return true\n", args)
+	sw.Do("}\n\n", args)
+	return sw.Error()
+}
+
+// compare writes the statements returning false when a and b, of type t,
+// differ. depth numbers the loop variables of the nested slices and maps.
+func (g *genEqual) compare(sw *generator.SnippetWriter, a, b string, t *types.Type, depth int) {
+	args := generator.Args{
+		"a":  a,
+		"b":  b,
+		"i":  fmt.Sprintf("i%d", depth),
+		"k":  fmt.Sprintf("k%d", depth),
+		"v":  fmt.Sprintf("v%d", depth),
+		"w":  fmt.Sprintf("w%d", depth),
+		"ok": fmt.Sprintf("ok%d", depth),
+	}
+	if g.hasEqual(t) {
+		sw.Do("if !$.a$.Equal($.b$) {\nreturn false\n}\n", args)
+		return
+	}
+
+	u := underlyingType(t)
+	switch {
+	case u.Kind == types.Pointer:
+		sw.Do("if ($.a$ == nil) != ($.b$ == nil) {\nreturn false\n}\n", args)
+		sw.Do("if $.a$ != nil {\n", args)
+		g.compare(sw, "(*"+a+")", "(*"+b+")", u.Elem, depth)
+		sw.Do("}\n", args)
+	case u.Kind == types.Slice || u.Kind == types.Array:
+		if u.Kind == types.Slice {
+			sw.Do("if len($.a$) != len($.b$) {\nreturn false\n}\n", args)
+		}
+		sw.Do("for $.i$ := range $.a$ {\n", args)
+		g.compare(sw, a+"["+args["i"].(string)+"]", b+"["+args["i"].(string)+"]", u.Elem, depth+1)
+		sw.Do("}\n", args)
+	case u.Kind == types.Map:
+		sw.Do("if len($.a$) != len($.b$) {\nreturn false\n}\n", args)
+		sw.Do("for $.k$, $.v$ := range $.a$ {\n", args)
+		sw.Do("$.w$, $.ok$ := $.b$[$.k$]\n", args)
+		sw.Do("if !$.ok$ {\nreturn false\n}\n", args)
+		g.compare(sw, args["v"].(string), args["w"].(string), u.Elem, depth+1)
+		sw.Do("}\n", args)
+	case comparableType(u):
+		sw.Do("if $.a$ != $.b$ {\nreturn false\n}\n", args)
+	default:
+		// The structs without an Equal method, of other packages or not
+		// generated, are compared member by member.
+		for _, m := range u.Members {
+			g.compare(sw, a+"."+m.Name, b+"."+m.Name, m.Type, depth)
+		}
+	}
+}
+
+// uncomparable describes the values of t compare can't compare, given the
+// structs it is compared inside of, or returns "" when it can: the funcs,
+// the interfaces, whose dynamic types are only known at run time, and the
+// structs without an Equal method that can't be compared member by member,
+// having unexported members of another package or holding themselves.
+func (g *genEqual) uncomparable(t *types.Type, inside sets.String) string {
+	if g.hasEqual(t) {
+		return ""
+	}
+	u := underlyingType(t)
+	switch {
+	case u.Kind == types.Pointer || u.Kind == types.Slice || u.Kind == types.Array || u.Kind == types.Map:
+		return g.uncomparable(u.Elem, inside)
+	case comparableType(u):
+		return ""
+	case u.Kind == types.Func:
+		return "func values"
+	case u.Kind == types.Interface:
+		return "interface values"
+	case u.Kind != types.Struct:
+		return fmt.Sprintf("%s values", u.Kind)
+	case inside.Has(u.Name.String()):
+		return fmt.Sprintf("%v, which holds itself and has no Equal method", u)
+	}
+	inside = inside.Union(sets.NewString(u.Name.String()))
+	for _, m := range u.Members {
+		if u.Name.Package != g.targetPackage && namer.IsPrivateGoName(m.Name) {
+			return fmt.Sprintf("%v, which has unexported members and no Equal method", u)
+		}
+		if reason := g.uncomparable(m.Type, inside); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+// comparableType reports whether == compares values of t by their contents
+// without panicking, which excludes pointers and interfaces holding
+// uncomparable values.
+func comparableType(t *types.Type) bool {
+	t = underlyingType(t)
+	switch t.Kind {
+	case types.Builtin, types.Chan:
+		return true
+	case types.Array:
+		return comparableType(t.Elem)
+	case types.Struct:
+		for _, m := range t.Members {
+			if !comparableType(m.Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,258 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"go/build"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/gengo/args"
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/parser"
+	"k8s.io/klog/v2"
+)
+
+// Execute parses the input packages and generates their builders, like
+// args.GeneratorArgs.Execute does, but generating up to --parallelism
+// packages at once. The shared generators then run on the parsed packages.
+func Execute(arguments *args.GeneratorArgs, shared ...SharedGenerator) error {
+	var err error
+	customArgs, ok := arguments.CustomArgs.(*CustomArgs)
+	if !ok {
+		return fmt.Errorf("unexpected custom arguments %T", arguments.CustomArgs)
+	}
+	if err := checkSharedGenerators(arguments, customArgs, shared); err != nil {
+		return err
+	}
+	customArgs.sharedBuildTags = nil
+	for _, gen := range shared {
+		if tag := gen.Arguments.GeneratedBuildTag; tag != "" {
+			customArgs.sharedBuildTags = append(customArgs.sharedBuildTags, tag)
+		}
+	}
+
+	customArgs.summary.begin()
+	defer customArgs.summary.done()
+	if customArgs.includeTypes, customArgs.excludeTypes, err = customArgs.typeFilters(); err != nil {
+		return err
+	}
+	if customArgs.config, customArgs.handlers, err = customArgs.readConfig(); err != nil {
+		return err
+	}
+	arguments = withInputGroups(arguments, customArgs)
+	if customArgs.Closure {
+		if arguments, err = withClosure(arguments, customArgs); err != nil {
+			return err
+		}
+	}
+
+	b, err := newParser(arguments, customArgs, "")
+	if err != nil {
+		return fmt.Errorf("Failed making a parser: %v", err)
+	}
+	c, err := generator.NewContext(b, NameSystems(), DefaultNameSystem())
+	if err != nil {
+		return fmt.Errorf("Failed making a context: %v", err)
+	}
+
+	// ExecutePackage appends the separator to the prefix of the shared
+	// context, do it once before the workers start.
+	c.TrimPathPrefix = arguments.TrimPathPrefix
+	if c.TrimPathPrefix != "" && !strings.HasSuffix(c.TrimPathPrefix, string(filepath.Separator)) {
+		c.TrimPathPrefix += string(filepath.Separator)
+	}
+	c.Verify = arguments.VerifyOnly
+
+	pkgs, err := packages(c, arguments, "")
+	if err != nil {
+		return err
+	}
+	if err := executePackages(c, arguments.OutputBase, pkgs, customArgs.workers(), &customArgs.summary); err != nil {
+		return fmt.Errorf("Failed executing generator: %v", err)
+	}
+
+	platforms, err := inputPlatforms(c.Universe, c.Inputs, customArgs.sourceContext(arguments.GeneratedBuildTag))
+	if err != nil {
+		return fmt.Errorf("Failed reading the platform files: %v", err)
+	}
+	for _, goos := range sets.StringKeySet(platforms).List() {
+		platformArgs := *arguments
+		platformArgs.InputDirs = platforms[goos]
+		if err := executePlatform(&platformArgs, customArgs, goos, c); err != nil {
+			return err
+		}
+	}
+	if err := executeShared(b, arguments, c, shared); err != nil {
+		return err
+	}
+	if ft, ok := c.FileTypes[generator.GolangFileType].(printedFileType); ok {
+		return ft.flush(os.Stdout)
+	}
+	return nil
+}
+
+// printedFileType is a file type printing what it collected once all the
+// packages are generated, with --dry-run or --stdout.
+type printedFileType interface {
+	flush(out io.Writer) error
+}
+
+// executePlatform parses the input packages with files specific to goos
+// again for goos, and generates the builders of the types of these files,
+// writing them with the file type of the context of the first parse.
+func executePlatform(arguments *args.GeneratorArgs, customArgs *CustomArgs, goos string, first *generator.Context) error {
+	klog.V(2).Infof("Generating the builders of the files specific to %s", goos)
+	b, err := newParser(arguments, customArgs, goos)
+	if err != nil {
+		return fmt.Errorf("Failed making a parser for %s: %v", goos, err)
+	}
+	c, err := generator.NewContext(b, NameSystems(), DefaultNameSystem())
+	if err != nil {
+		return fmt.Errorf("Failed making a context for %s: %v", goos, err)
+	}
+	c.TrimPathPrefix = first.TrimPathPrefix
+	c.Verify = first.Verify
+	c.FileTypes[generator.GolangFileType] = first.FileTypes[generator.GolangFileType]
+
+	pkgs, err := packages(c, arguments, goos)
+	if err != nil {
+		return err
+	}
+	if err := executePackages(c, arguments.OutputBase, pkgs, customArgs.workers(), &customArgs.summary); err != nil {
+		return fmt.Errorf("Failed executing generator for %s: %v", goos, err)
+	}
+	return nil
+}
+
+// newParser returns the parser of the input packages, like
+// args.GeneratorArgs.NewBuilder does, selecting their files with --build-tags
+// too, and for goos when set.
+func newParser(arguments *args.GeneratorArgs, customArgs *CustomArgs, goos string) (*parser.Builder, error) {
+	// The parser copies the default build context when created.
+	host := build.Default.GOOS
+	if goos != "" {
+		build.Default.GOOS = goos
+	}
+	b := parser.New()
+	build.Default.GOOS = host
+	b.IncludeTestFiles = arguments.IncludeTestFiles
+	b.AddBuildTags(customArgs.BuildTags...)
+	b.AddBuildTags(arguments.GeneratedBuildTag)
+	b.AddBuildTags(customArgs.sharedBuildTags...)
+	for _, d := range arguments.InputDirs {
+		var err error
+		if strings.HasSuffix(d, "/...") {
+			err = b.AddDirRecursive(strings.TrimSuffix(d, "/..."))
+		} else {
+			err = b.AddDir(d)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to add directory %q: %v", d, err)
+		}
+	}
+	return b, nil
+}
+
+// withClosure parses the input packages to find the packages under the output
+// base their structs refer to, and returns a copy of arguments adding them to
+// the inputs. Their builders only include the types reachable from the
+// inputs.
+func withClosure(arguments *args.GeneratorArgs, customArgs *CustomArgs) (*args.GeneratorArgs, error) {
+	b, err := newParser(arguments, customArgs, "")
+	if err != nil {
+		return nil, fmt.Errorf("Failed making a parser: %v", err)
+	}
+	u, err := b.FindTypes()
+	if err != nil {
+		return nil, fmt.Errorf("Failed parsing the inputs: %v", err)
+	}
+	if err := customArgs.findEnabledPackages(u); err != nil {
+		return nil, err
+	}
+
+	roots := sets.NewString()
+	for _, pkg := range b.FindPackages() {
+		if !customArgs.skipsPackage(pkg) {
+			roots.Insert(pkg)
+		}
+	}
+	underOutput := packageDirUnder(arguments.OutputBase)
+	eligible := func(pkg string) bool {
+		return underOutput(pkg) && !customArgs.skipsPackage(pkg)
+	}
+	cl := computeClosure(u, roots, eligible, customArgs.generates)
+	customArgs.closurePackages = cl.packages
+	klog.V(2).Infof("Adding the packages %v referred to by the inputs", cl.packages.List())
+
+	extended := *arguments
+	extended.InputDirs = append(append([]string{}, arguments.InputDirs...), cl.packages.List()...)
+	return &extended, nil
+}
+
+// executePackages runs the generators of the packages with a pool of workers,
+// reporting the errors in the order of the packages and their durations to
+// report.
+func executePackages(c *generator.Context, outDir string, packages generator.Packages, workers int, report *summaryReport) error {
+	if workers > len(packages) {
+		workers = len(packages)
+	}
+
+	errs := make([]error, len(packages))
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				start := time.Now()
+				errs[i] = c.ExecutePackage(outDir, packages[i])
+				report.addDuration(packages[i].Path(), time.Since(start))
+			}
+		}()
+	}
+	for i := range packages {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+
+	var messages []string
+	for _, err := range errs {
+		if err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+	if len(messages) > 0 {
+		return fmt.Errorf("some packages had errors:\n%v\n", strings.Join(messages, "\n"))
+	}
+	return nil
+}
+
+// workers returns the number of packages generated at once.
+func (ca *CustomArgs) workers() int {
+	if ca.Parallelism > 0 {
+		return ca.Parallelism
+	}
+	return runtime.GOMAXPROCS(0)
+}
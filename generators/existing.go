@@ -0,0 +1,86 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/types"
+)
+
+// handWrittenSymbols returns the functions and the builder methods already
+// declared by the source files of pkg, as "Func" and "Receiver.Method".
+//
+// Functions are taken from the universe. Methods of the builders are not,
+// their receivers are declared in the generated file which is excluded by
+// the build tag, so the package files are parsed again to find them.
+func handWrittenSymbols(pkg *types.Package, outputFileName string, ctx build.Context) (sets.String, error) {
+	symbols := sets.NewString()
+	for name := range pkg.Functions {
+		symbols.Insert(name)
+	}
+	if pkg.SourcePath == "" {
+		return symbols, nil
+	}
+
+	entries, err := os.ReadDir(pkg.SourcePath)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == outputFileName {
+			continue
+		}
+		if match, err := ctx.MatchFile(pkg.SourcePath, name); err != nil || !match {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(pkg.SourcePath, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+				continue
+			}
+			if receiver := receiverName(fn.Recv.List[0].Type); receiver != "" {
+				symbols.Insert(receiver + "." + fn.Name.Name)
+			}
+		}
+	}
+	return symbols, nil
+}
+
+// receiverName returns the base type name of a method receiver.
+func receiverName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(e.X)
+	case *ast.ParenExpr:
+		return receiverName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
@@ -0,0 +1,297 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// The external section of the --config file maps the types of other
+// packages, without builders of their own, to the builder their members are
+// set with, or to the style of their setters:
+//
+//	external:
+//	  k8s.io/apimachinery/pkg/util/intstr.IntOrString: dual-setter
+//	  example.com/lib/geo.Point:
+//	    builder: example.com/lib/geo.PointBuilder
+//
+// Besides the setter taking the value, the builders holding them get a
+// <setter>With(build func(*Builder)) method setting the member to the value
+// built by a new builder, or with the dual-setter style a Set<Member><Form>
+// setter per conversion function of the type.
+
+// Setter styles of the external types.
+const (
+	externalSetterValue = "value"
+	externalSetterDual  = "dual-setter"
+)
+
+var externalSetterStyles = []string{externalSetterValue, externalSetterDual}
+
+// ExternalType configures how the builders set the members of a type of
+// another package. In YAML, a string is the setter style.
+type ExternalType struct {
+	// Builder is the builder the members of the type are set with, as
+	// <import path>.<name>, its Build method returning the type.
+	Builder string `json:"builder,omitempty"`
+	// Constructor is the function creating the builder, as
+	// <import path>.<name>, New<Builder> in the package of the builder by
+	// default.
+	Constructor string `json:"constructor,omitempty"`
+	// Setter is the style of the setters of the members of the type: value,
+	// the default, or dual-setter.
+	Setter string `json:"setter,omitempty"`
+	// Forms are, with the dual-setter style, the functions converting the
+	// inputs of the setters to the type, by setter suffix, as
+	// <import path>.<name>(<input type>). Those of intstr.IntOrString
+	// default to Int and String.
+	Forms map[string]string `json:"forms,omitempty"`
+}
+
+// UnmarshalJSON decodes the setter style of the type from a string, or the
+// type from an object.
+func (e *ExternalType) UnmarshalJSON(data []byte) error {
+	var setter string
+	if err := json.Unmarshal(data, &setter); err == nil {
+		*e = ExternalType{Setter: setter}
+		return nil
+	}
+	type externalType ExternalType
+	return json.Unmarshal(data, (*externalType)(e))
+}
+
+// intOrStringName is the name of the intstr.IntOrString of Kubernetes, whose
+// dual setters need no configured forms.
+var intOrStringName = types.Name{Package: "k8s.io/apimachinery/pkg/util/intstr", Name: "IntOrString"}
+
+// intOrStringForms are the default forms of intstr.IntOrString.
+var intOrStringForms = map[string]string{
+	"Int":    intOrStringName.Package + ".FromInt32(int32)",
+	"String": intOrStringName.Package + ".FromString(string)",
+}
+
+// parseQualifiedName parses an <import path>.<name> of the --config file.
+func parseQualifiedName(name string) (types.Name, error) {
+	i := strings.LastIndex(name, ".")
+	if i <= 0 || !token.IsIdentifier(name[i+1:]) {
+		return types.Name{}, fmt.Errorf("invalid name %q, must be <import path>.<name>", name)
+	}
+	return types.Name{Package: name[:i], Name: name[i+1:]}, nil
+}
+
+// newExternalHandler returns the handler of the members of the external type
+// name configured by external.
+func newExternalHandler(name types.Name, external ExternalType) (typeHandler, error) {
+	if external.Builder != "" {
+		if external.Setter != "" || len(external.Forms) > 0 {
+			return nil, fmt.Errorf("the builder and the setter style are mutually exclusive")
+		}
+		builder, err := parseQualifiedName(external.Builder)
+		if err != nil {
+			return nil, err
+		}
+		constructor := types.Name{Package: builder.Package, Name: "New" + builder.Name}
+		if external.Constructor != "" {
+			if constructor, err = parseQualifiedName(external.Constructor); err != nil {
+				return nil, err
+			}
+		}
+		return &externalBuilderHandler{builder: builder, constructor: constructor}, nil
+	}
+	if external.Constructor != "" {
+		return nil, fmt.Errorf("the constructor needs the builder")
+	}
+	switch external.Setter {
+	case "", externalSetterValue:
+		if len(external.Forms) > 0 {
+			return nil, fmt.Errorf("the forms need the %s style", externalSetterDual)
+		}
+		return nil, nil
+	case externalSetterDual:
+	default:
+		return nil, fmt.Errorf("unsupported setter style %q, must be one of %v", external.Setter, externalSetterStyles)
+	}
+
+	forms := external.Forms
+	if len(forms) == 0 && name == intOrStringName {
+		forms = intOrStringForms
+	}
+	if len(forms) == 0 {
+		return nil, fmt.Errorf("the %s style needs the forms of the type", externalSetterDual)
+	}
+	handler := &dualSetterHandler{}
+	for suffix, form := range forms {
+		if !token.IsIdentifier(suffix) || !token.IsExported(suffix) {
+			return nil, fmt.Errorf("the suffix %q of a form is not an exported Go identifier", suffix)
+		}
+		open := strings.Index(form, "(")
+		if open < 0 || !strings.HasSuffix(form, ")") {
+			return nil, fmt.Errorf("invalid form %q, must be <import path>.<name>(<input type>)", form)
+		}
+		fn, err := parseQualifiedName(form[:open])
+		if err != nil {
+			return nil, err
+		}
+		input := &types.Type{Name: types.Name{Name: form[open+1 : len(form)-1]}, Kind: types.Builtin}
+		if strings.Contains(input.Name.Name, ".") {
+			if input.Name, err = parseQualifiedName(input.Name.Name); err != nil {
+				return nil, err
+			}
+			input.Kind = types.Unknown
+		} else if !token.IsIdentifier(input.Name.Name) {
+			return nil, fmt.Errorf("invalid input type of the form %q", form)
+		}
+		handler.forms = append(handler.forms, dualSetterForm{suffix: suffix, fn: fn, input: input})
+	}
+	sort.Slice(handler.forms, func(i, j int) bool {
+		return handler.forms[i].suffix < handler.forms[j].suffix
+	})
+	return handler, nil
+}
+
+// handlesExternal reports whether the member m of t, holding a value of an
+// external type or a pointer to it, is neither embedded nor mixed in, and its
+// type of another package without a builder.
+func (g *genDeepCopy) handlesExternal(t *types.Type, m types.Member) bool {
+	mt := builderType(m.Type)
+	return !m.Embedded && !g.mixin(t, m) && !g.isLocalType(mt) && !g.hasBuilder(mt)
+}
+
+// valueSmokeCall returns the format of the call of the setter of the member m
+// taking its value in the smoke tests.
+func valueSmokeCall(m types.Member) string {
+	if zeroValue(m.Type) == "" {
+		return "b.$.setter$($.type|raw${})\n"
+	}
+	return "b.$.setter$($.zero$)\n"
+}
+
+// externalBuilderHandler sets the members of an external type with its
+// builder.
+type externalBuilderHandler struct {
+	builder, constructor types.Name
+}
+
+func (h *externalBuilderHandler) handles(g *genDeepCopy, t *types.Type, m types.Member) bool {
+	return g.handlesExternal(t, m)
+}
+
+func (h *externalBuilderHandler) check(g *genDeepCopy, t *types.Type) error {
+	return nil
+}
+
+func (h *externalBuilderHandler) methods(g *genDeepCopy, sw *generator.SnippetWriter, t *types.Type, m types.Member, argsMember generator.Args) {
+	g.valueSetter(sw, t, m, argsMember)
+	name := argsMember["setter"].(string) + "With"
+	if g.handWritten(t, name) {
+		return
+	}
+	argsMember["with"] = name
+	argsMember["externalBuilder"] = &types.Type{Name: h.builder}
+	argsMember["externalConstructor"] = &types.Type{Name: h.constructor}
+	sw.Do("// $.with$ sets $.name$ to the value built by a new builder, set by build.\n", argsMember)
+	sw.Do("func (b *$.typeBase|raw$Builder) $.with$(build func(*$.externalBuilder|raw$)) *$.typeBase|raw$Builder {\n", argsMember)
+	g.copyOnWrite(sw)
+	g.clearOneof(sw, t, m)
+	sw.Do("builder := $.externalConstructor|raw$()\n", argsMember)
+	sw.Do("build(builder)\n", argsMember)
+	if underlyingType(m.Type).Kind == types.Pointer {
+		sw.Do("value := builder.Build()\n", argsMember)
+		sw.Do("b.model.$.name$ = &value\n", argsMember)
+	} else {
+		sw.Do("b.model.$.name$ = builder.Build()\n", argsMember)
+	}
+	sw.Do("return b\n", argsMember)
+	sw.Do("}\n\n", argsMember)
+}
+
+func (h *externalBuilderHandler) packages() []string {
+	return []string{h.builder.Package, h.constructor.Package}
+}
+
+func (h *externalBuilderHandler) smokeCall(g *genDeepCopy, t *types.Type, m types.Member) (name, format string) {
+	return g.methodName(t, m), valueSmokeCall(m)
+}
+
+// dualSetterForm is a conversion function from the input of a dual setter.
+type dualSetterForm struct {
+	suffix string
+	fn     types.Name
+	input  *types.Type
+}
+
+// dualSetterHandler sets the members of an external type from the inputs of
+// its conversion functions.
+type dualSetterHandler struct {
+	forms []dualSetterForm
+}
+
+func (h *dualSetterHandler) handles(g *genDeepCopy, t *types.Type, m types.Member) bool {
+	return g.handlesExternal(t, m)
+}
+
+func (h *dualSetterHandler) check(g *genDeepCopy, t *types.Type) error {
+	return nil
+}
+
+func (h *dualSetterHandler) methods(g *genDeepCopy, sw *generator.SnippetWriter, t *types.Type, m types.Member, argsMember generator.Args) {
+	g.valueSetter(sw, t, m, argsMember)
+	for _, form := range h.forms {
+		name := "Set" + argsMember["base"].(string) + form.suffix
+		if g.handWritten(t, name) {
+			continue
+		}
+		argsMember["dualSetter"] = name
+		argsMember["form"] = &types.Type{Name: form.fn}
+		argsMember["input"] = form.input
+		sw.Do("// $.dualSetter$ sets $.name$ to the value $.form|raw$ converts input to.\n", argsMember)
+		sw.Do("func (b *$.typeBase|raw$Builder) $.dualSetter$(input $.input|raw$) *$.typeBase|raw$Builder {\n", argsMember)
+		g.copyOnWrite(sw)
+		g.clearOneof(sw, t, m)
+		if underlyingType(m.Type).Kind == types.Pointer {
+			sw.Do("value := $.form|raw$(input)\n", argsMember)
+			sw.Do("b.model.$.name$ = &value\n", argsMember)
+		} else {
+			sw.Do("b.model.$.name$ = $.form|raw$(input)\n", argsMember)
+		}
+		sw.Do("return b\n", argsMember)
+		sw.Do("}\n\n", argsMember)
+	}
+}
+
+func (h *dualSetterHandler) packages() []string {
+	var result []string
+	for _, form := range h.forms {
+		result = append(result, form.fn.Package, form.input.Name.Package)
+	}
+	return result
+}
+
+func (h *dualSetterHandler) smokeCall(g *genDeepCopy, t *types.Type, m types.Member) (name, format string) {
+	for _, form := range h.forms {
+		if zero := zeroValue(form.input); form.input.Kind == types.Builtin && zero != "" {
+			name = "Set" + g.memberName(m) + form.suffix
+			return name, "b." + name + "(" + zero + ")\n"
+		}
+	}
+	return g.methodName(t, m), valueSmokeCall(m)
+}
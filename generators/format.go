@@ -0,0 +1,328 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/imports"
+	"k8s.io/gengo/generator"
+)
+
+// preambleOptions format the header, package clause and imports of the
+// generated files as goimports does. The generators track the imports their
+// code needs, only the unused ones being removed.
+var preambleOptions = &imports.Options{Comments: true, TabIndent: true, TabWidth: 8, FormatOnly: true}
+
+// renderGolangFile writes the content of a Go file to w, formatted as the
+// default file type would write it. The snippets gengo buffered in f are
+// assembled into a temporary file, releasing the buffers, and formatted from
+// there by chunks of top-level declarations separated by blank lines, the
+// assembled and the formatted contents never being held whole in memory.
+// When it can't be formatted, the unformatted content is given to
+// unformatted, if not nil, before returning the error.
+func renderGolangFile(golang *generator.DefaultFileType, f *generator.File, pathname string, w io.Writer, unformatted func(io.Reader) error) error {
+	spool, err := os.CreateTemp("", "builder-gen-*.go")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	buffered := bufio.NewWriter(spool)
+	et := generator.NewErrorTracker(buffered)
+	golang.Assemble(et, f)
+	if et.Error() != nil {
+		return et.Error()
+	}
+	if err := buffered.Flush(); err != nil {
+		return err
+	}
+	f.Vars, f.Consts, f.Body = bytes.Buffer{}, bytes.Buffer{}, bytes.Buffer{}
+
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := formatChunks(spool, w); err != nil {
+		err = fmt.Errorf("unable to format file %q (%v)", pathname, err)
+		if unformatted == nil {
+			return err
+		}
+		if _, seekErr := spool.Seek(0, io.SeekStart); seekErr != nil {
+			return seekErr
+		}
+		if copyErr := unformatted(bufio.NewReader(spool)); copyErr != nil {
+			return copyErr
+		}
+		return err
+	}
+	return nil
+}
+
+// formatChunks formats the Go file read from r into w as goimports would,
+// reading it twice: once for the packages its declarations use, and once to
+// format it. The comments before the package clause, the clause and the
+// imports, less the unused ones, are formatted together, then each chunk of
+// declarations on its own: gofmt formats the top-level declarations
+// separated by blank lines independently of each other, leaving a single
+// blank line between them.
+func formatChunks(r io.ReadSeeker, w io.Writer) error {
+	used := map[string]bool{}
+	chunks := &chunkReader{r: bufio.NewReader(r)}
+	preamble, first, err := chunks.preamble()
+	if err != nil {
+		return err
+	}
+	for c := first; c != nil; {
+		usedPackages(c.src, used)
+		if c, err = chunks.next(); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	chunks = &chunkReader{r: bufio.NewReader(r)}
+	if preamble, first, err = chunks.preamble(); err != nil {
+		return err
+	}
+	if preamble, err = pruneImports(preamble, used); err != nil {
+		return err
+	}
+	formatted, err := imports.Process("", preamble, preambleOptions)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(formatted); err != nil {
+		return err
+	}
+	for c := first; c != nil; {
+		// The chunk is formatted as a file, gofmt only reformatting the doc
+		// comments of complete files.
+		formatted, err := format.Source(append([]byte(chunkPackage), c.src...))
+		if err != nil {
+			return offsetErrors(err, c.line-1-strings.Count(chunkPackage, "\n"))
+		}
+		formatted = bytes.TrimPrefix(formatted, []byte(chunkPackage))
+		if _, err := w.Write(append([]byte{'\n'}, formatted...)); err != nil {
+			return err
+		}
+		if c, err = chunks.next(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// usedPackages adds to used the identifiers src qualifies other identifiers
+// with, not declared by src, the names of the packages it uses. The syntax
+// errors are left to the formatting.
+func usedPackages(src []byte, used map[string]bool) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", append([]byte(chunkPackage), src...), 0)
+	if err != nil {
+		return
+	}
+	ast.Inspect(file, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok && ident.Obj == nil {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+}
+
+// pruneImports removes from preamble the lines of the named imports whose
+// names are not used, those the generators track for the types they skip,
+// and the import declarations left empty.
+func pruneImports(preamble []byte, used map[string]bool) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", preamble, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	removed := map[int]bool{}
+	removeLines := func(node ast.Node) {
+		for line := fset.Position(node.Pos()).Line; line <= fset.Position(node.End()).Line; line++ {
+			removed[line] = true
+		}
+	}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		var unused []ast.Spec
+		for _, spec := range gen.Specs {
+			name := spec.(*ast.ImportSpec).Name
+			if name != nil && name.Name != "_" && name.Name != "." && !used[name.Name] {
+				unused = append(unused, spec)
+			}
+		}
+		if len(unused) == len(gen.Specs) {
+			removeLines(gen)
+			continue
+		}
+		for _, spec := range unused {
+			removeLines(spec)
+		}
+	}
+	if len(removed) == 0 {
+		return preamble, nil
+	}
+	var pruned []byte
+	for line, rest := 1, preamble; len(rest) > 0; line++ {
+		end := bytes.IndexByte(rest, '\n') + 1
+		if end == 0 {
+			end = len(rest)
+		}
+		if !removed[line] {
+			pruned = append(pruned, rest[:end]...)
+		}
+		rest = rest[end:]
+	}
+	return pruned, nil
+}
+
+// chunkPackage is the package clause the chunks are formatted after.
+const chunkPackage = "package p\n\n"
+
+// chunk is a run of complete top-level declarations, or comments, of a Go
+// file.
+type chunk struct {
+	src []byte
+	// line is the line of the file the chunk starts at.
+	line int
+	// tok is the first token of the chunk other than a comment.
+	tok token.Token
+}
+
+// chunkReader splits a Go file into the chunks separated by the blank lines
+// outside of the declarations, the comments and the raw strings.
+type chunkReader struct {
+	r    *bufio.Reader
+	line int
+}
+
+// preamble returns the chunks up to the package clause and the imports
+// following it, and the first chunk after them, nil if there is none.
+func (cr *chunkReader) preamble() ([]byte, *chunk, error) {
+	var preamble []byte
+	for pastPackage := false; ; {
+		c, err := cr.next()
+		if err == io.EOF {
+			return preamble, nil, nil
+		} else if err != nil {
+			return nil, nil, err
+		}
+		if pastPackage && c.tok != token.IMPORT {
+			return preamble, c, nil
+		}
+		preamble = append(append(preamble, c.src...), '\n')
+		pastPackage = pastPackage || c.tok == token.PACKAGE
+	}
+}
+
+// next returns the next chunk, io.EOF after the last one. A chunk never
+// ends inside a declaration, the blank lines of their bodies being part of
+// the chunk, and can only be unbalanced at the end of the file.
+func (cr *chunkReader) next() (*chunk, error) {
+	c := &chunk{}
+	for {
+		line, err := cr.r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if len(line) > 0 {
+			cr.line++
+		}
+		blank := len(bytes.TrimSpace(line)) == 0
+		if !blank || len(c.src) > 0 {
+			if len(c.src) == 0 {
+				c.line = cr.line
+			}
+			c.src = append(c.src, line...)
+		}
+		if err == io.EOF {
+			if len(c.src) == 0 {
+				return nil, io.EOF
+			}
+			c.src = append(bytes.TrimRight(c.src, " \t\r\n"), '\n')
+			c.tok, _ = scanChunk(c.src)
+			return c, nil
+		}
+		if blank && len(c.src) > 0 {
+			if tok, complete := scanChunk(c.src); complete {
+				c.src = append(bytes.TrimRight(c.src, " \t\r\n"), '\n')
+				c.tok = tok
+				return c, nil
+			}
+		}
+	}
+}
+
+// scanChunk returns the first token of src other than a comment, and whether
+// src ends outside of any parenthesis, brace, bracket, comment or string.
+func scanChunk(src []byte) (token.Token, bool) {
+	complete := true
+	var s scanner.Scanner
+	s.Init(token.NewFileSet().AddFile("", -1, len(src)), src, func(token.Position, string) { complete = false }, 0)
+	first := token.EOF
+	depth := 0
+	for {
+		_, tok, _ := s.Scan()
+		if first == token.EOF {
+			first = tok
+		}
+		switch tok {
+		case token.EOF:
+			return first, complete && depth == 0
+		case token.LPAREN, token.LBRACE, token.LBRACK:
+			depth++
+		case token.RPAREN, token.RBRACE, token.RBRACK:
+			depth--
+		}
+	}
+}
+
+// offsetErrors moves the positions of the syntax errors of a chunk by the
+// lines before it in the file.
+func offsetErrors(err error, lines int) error {
+	list, ok := err.(scanner.ErrorList)
+	if !ok {
+		return err
+	}
+	moved := make(scanner.ErrorList, len(list))
+	for i, e := range list {
+		moved[i] = &scanner.Error{Pos: e.Pos, Msg: e.Msg}
+		moved[i].Pos.Line += lines
+	}
+	return moved
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/imports"
+)
+
+func TestFormatChunks(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{name: "package only", src: "package p\n"},
+		{
+			name: "header and imports",
+			src: "//go:build linux\n// +build linux\n\n// Code generated by builder-gen. DO NOT EDIT.\n\n\n" +
+				"package p\n\nimport (\n\"strings\"\n\"github.com/example/b\"\n\"fmt\"\n)\n\n" +
+				"var _ = fmt.Sprint\nvar _ = strings.Join\nvar _ = b.X\n",
+		},
+		{
+			name: "unused imports",
+			src: "package p\n\nimport (\nfmt \"fmt\"\nself \"example.com/p\"\n_ \"embed\"\nunused \"example.com/q\"\n)\n\n" +
+				"var x struct{ unused int }\n\nvar _ = fmt.Sprint(x.unused)\n\nfunc f(self struct{ x int }) int {\nreturn self.x\n}\n",
+		},
+		{
+			name: "only unused imports",
+			src:  "package p\n\nimport (\nself \"example.com/p\"\n)\n\ntype T struct{}\n",
+		},
+		{
+			name: "declarations",
+			src: "package p\n\n\n\ntype A struct {\nName string `json:\"name\"`\n\nAge int // age\n}\n\n" +
+				"// B is documented.\ntype B int\nconst c = 1\n\n\n\nfunc (a *A) Set(name string) *A {\n\n" +
+				"a.Name = name\n\n\nreturn a\n}\n",
+		},
+		{
+			name: "multi-line tokens",
+			src: "package p\n\nvar s = `first\n\n\nlast`\n\n/* a block\n\ncomment */\n" +
+				"var (\nx = 1\n\ny = []int{\n1,\n\n2,\n}\n)\n",
+		},
+		{
+			name: "blank lines with spaces",
+			src:  "package p\n \t\nfunc f() {\n\t\n}\n\t\n\t\nfunc g() {}",
+		},
+		{
+			name: "trailing comment",
+			src:  "package p\n\nfunc f() {}\n\n// The end.\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := imports.Process("", []byte(tt.src), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got bytes.Buffer
+			if err := formatChunks(strings.NewReader(tt.src), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != string(want) {
+				t.Errorf("formatChunks() =\n%s\nwant:\n%s", got.String(), want)
+			}
+		})
+	}
+}
+
+func TestFormatChunksError(t *testing.T) {
+	src := "package p\n\nfunc f() {}\n\nfunc g() {\nreturn +\n}\n"
+	err := formatChunks(strings.NewReader(src), &bytes.Buffer{})
+	if err == nil || !strings.HasPrefix(err.Error(), "7:1: ") {
+		t.Errorf("formatChunks() = %v, want an error at 7:1", err)
+	}
+
+	src = "package p\n\nfunc f() {\n\nfunc g() {}\n"
+	if err := formatChunks(strings.NewReader(src), &bytes.Buffer{}); err == nil {
+		t.Errorf("formatChunks() of an unbalanced file succeeded")
+	}
+}
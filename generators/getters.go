@@ -0,0 +1,145 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"go/token"
+	"io"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+	"k8s.io/klog/v2"
+)
+
+// generatesGetters reports whether t, a model of the package targetPackage,
+// gets the Get<Member> getters of --getters: the structs getting builders,
+// but for the anonymous structs.
+func generatesGetters(customArgs *CustomArgs, targetPackage string, t *types.Type) bool {
+	return customArgs.Getters && t.Name.Package == targetPackage && t.Kind == types.Struct && customArgs.generates(t) && inlineParent(t) == nil
+}
+
+// genGetters produces the nil-safe Get<Member> getters of the models of the
+// builders, like those of the protobuf messages, with --getters. It writes
+// to the file of the builders.
+type genGetters struct {
+	generator.DefaultGen
+	targetPackage string
+	imports       namer.ImportTracker
+	customArgs    *CustomArgs
+	// declared holds the methods hand-written in the target package, which
+	// are not generated.
+	declared sets.String
+}
+
+func newGenGetters(sanitizedName, targetPackage string, customArgs *CustomArgs, declared sets.String) *genGetters {
+	return &genGetters{
+		DefaultGen: generator.DefaultGen{
+			OptionalName: sanitizedName,
+		},
+		targetPackage: targetPackage,
+		imports:       generator.NewImportTracker(),
+		customArgs:    customArgs,
+		declared:      declared,
+	}
+}
+
+func (g *genGetters) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.targetPackage, g.imports),
+	}
+}
+
+func (g *genGetters) Filter(c *generator.Context, t *types.Type) bool {
+	return generatesGetters(g.customArgs, g.targetPackage, t)
+}
+
+func (g *genGetters) Imports(c *generator.Context) []string {
+	return g.imports.ImportLines()
+}
+
+// getterName returns the name of the getter of the member m of t, empty when
+// it would clash with a member, a method of t or a method its embedded
+// members promote, like the GetObjectMeta of metav1.ObjectMeta.
+func (g *genGetters) getterName(t *types.Type, m types.Member) string {
+	name := "Get" + m.Name
+	if _, ok := t.Methods[name]; ok || g.declared.Has(t.Name.Name+"."+name) {
+		klog.V(2).Infof("Skipping %s.%s, it is already declared in %s", t.Name.Name, name, g.targetPackage)
+		return ""
+	}
+	for _, other := range t.Members {
+		if other.Name == name {
+			klog.V(2).Infof("Skipping %s.%s, it is a member of %s", t.Name.Name, name, t.Name.Name)
+			return ""
+		}
+		if !other.Embedded {
+			continue
+		}
+		et := underlyingType(other.Type)
+		if et.Kind == types.Pointer {
+			et = et.Elem
+		}
+		if _, ok := et.Methods[name]; ok {
+			klog.V(2).Infof("Skipping %s.%s, it is promoted from %s", t.Name.Name, name, other.Name)
+			return ""
+		}
+	}
+	return name
+}
+
+func (g *genGetters) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	for _, m := range t.Members {
+		if !token.IsExported(m.Name) {
+			continue
+		}
+		name := g.getterName(t, m)
+		if name == "" {
+			continue
+		}
+		mt := inlineMemberType(t, m)
+		args := generator.Args{
+			"type":   t,
+			"getter": name,
+			"name":   m.Name,
+			"member": mt,
+		}
+		// The structs with getters are returned by pointer, so that the
+		// getters chain.
+		if generatesGetters(g.customArgs, g.targetPackage, mt) {
+			sw.Do("// $.getter$ returns a pointer to $.name$, nil for a nil receiver.\n", args)
+			sw.Do("func (in *$.type|raw$) $.getter$() *$.member|raw$ {\n", args)
+			sw.Do("if in != nil {\n", args)
+			sw.Do("return &in.$.name$\n", args)
+			sw.Do("}\n", args)
+			sw.Do("return nil\n", args)
+			sw.Do("}\n\n", args)
+			continue
+		}
+		sw.Do("// $.getter$ returns $.name$, its zero value for a nil receiver.\n", args)
+		sw.Do("func (in *$.type|raw$) $.getter$() $.member|raw$ {\n", args)
+		sw.Do("if in != nil {\n", args)
+		sw.Do("return in.$.name$\n", args)
+		sw.Do("}\n", args)
+		if zero := zeroValue(mt); zero != "" {
+			sw.Do("return "+zero+"\n", args)
+		} else {
+			sw.Do("return $.member|raw${}\n", args)
+		}
+		sw.Do("}\n\n", args)
+	}
+	return sw.Error()
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// With --go-version, the generated code uses the features of the versions of
+// Go from which it is compiled: any for the empty interfaces from Go 1.18,
+// and errors.Join for the errors of the builders from Go 1.20.
+
+var errorsJoinFunc = &types.Type{Name: types.Name{Package: "errors", Name: "Join"}}
+
+// anyNamer names the types like its raw namer, the empty interfaces being
+// named any. The raw names of the types only hold interface{} for empty
+// interfaces, the struct types being named without their tags.
+type anyNamer struct {
+	namer.Namer
+}
+
+func (n anyNamer) Name(t *types.Type) string {
+	return strings.ReplaceAll(n.Namer.Name(t), "interface{}", "any")
+}
+
+// rawNamer returns the raw namer of the files of targetPackage, tracking
+// their imports with tracker.
+func (ca *CustomArgs) rawNamer(targetPackage string, tracker namer.ImportTracker) namer.Namer {
+	raw := namer.NewRawNamer(targetPackage, tracker)
+	if ca.goAtLeast(18) {
+		return anyNamer{raw}
+	}
+	return raw
+}
+
+// joinErrors reports whether the errors of the builders are joined by
+// errors.Join, rather than by the builderErrors type of the package.
+func (ca *CustomArgs) joinErrors() bool {
+	return ca.goAtLeast(20)
+}
+
+// errorsType returns the type of the slices of errors joined by the builders.
+func (g *genDeepCopy) errorsType() string {
+	if g.customArgs.joinErrors() {
+		return "[]error"
+	}
+	return builderErrorsName
+}
+
+// returnErrors writes the return of the errors collected in errs, after the
+// results listed by results.
+func (g *genDeepCopy) returnErrors(sw *generator.SnippetWriter, results string) {
+	args := generator.Args{"results": results, "join": errorsJoinFunc}
+	if g.customArgs.joinErrors() {
+		sw.Do("return $.results$$.join|raw$(errs...)\n", args)
+	} else {
+		sw.Do("return $.results$errs.err()\n", args)
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"go/build"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/gengo/args"
+)
+
+// InputGroup is a set of input packages generated in the same run as the
+// other inputs, with their own settings. The empty fields take the value of
+// the matching flag.
+type InputGroup struct {
+	// InputDirs are the packages of the group, "/..." suffixes included
+	// recursively.
+	InputDirs []string
+	// OutputFileBaseName is the name of the generated files, without the
+	// ".go" extension.
+	OutputFileBaseName string
+	// GoHeaderFilePath is the file holding the license header of the
+	// generated files.
+	GoHeaderFilePath string
+	// SetterPrefix is prepended to the names of the builder methods named
+	// after the members.
+	SetterPrefix string
+}
+
+// Keys of the --input-group fields, named after the matching flags.
+const (
+	groupInputDirsKey          = "input-dirs"
+	groupOutputFileBaseNameKey = "output-file-base-name"
+	groupGoHeaderFileKey       = "go-header-file"
+	groupSetterPrefixKey       = "setter-prefix"
+)
+
+// parseInputGroup parses the value of --input-group, "key=value" fields
+// separated by semicolons, the input directories separated by commas.
+func parseInputGroup(value string) (InputGroup, error) {
+	var group InputGroup
+	for _, field := range strings.Split(value, ";") {
+		if field == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(field, "=")
+		if !ok {
+			return InputGroup{}, fmt.Errorf("invalid input group field %q, expected key=value", field)
+		}
+		switch key {
+		case groupInputDirsKey:
+			for _, dir := range strings.Split(val, ",") {
+				if dir != "" {
+					group.InputDirs = append(group.InputDirs, dir)
+				}
+			}
+		case groupOutputFileBaseNameKey:
+			group.OutputFileBaseName = val
+		case groupGoHeaderFileKey:
+			group.GoHeaderFilePath = val
+		case groupSetterPrefixKey:
+			group.SetterPrefix = val
+		default:
+			return InputGroup{}, fmt.Errorf("unknown input group field %q", key)
+		}
+	}
+	if len(group.InputDirs) == 0 {
+		return InputGroup{}, fmt.Errorf("input group %q has no %s", value, groupInputDirsKey)
+	}
+	return group, nil
+}
+
+// String formats the group as parsed by --input-group.
+func (group InputGroup) String() string {
+	fields := []string{groupInputDirsKey + "=" + strings.Join(group.InputDirs, ",")}
+	if group.OutputFileBaseName != "" {
+		fields = append(fields, groupOutputFileBaseNameKey+"="+group.OutputFileBaseName)
+	}
+	if group.GoHeaderFilePath != "" {
+		fields = append(fields, groupGoHeaderFileKey+"="+group.GoHeaderFilePath)
+	}
+	if group.SetterPrefix != "" {
+		fields = append(fields, groupSetterPrefixKey+"="+group.SetterPrefix)
+	}
+	return strings.Join(fields, ";")
+}
+
+// inputGroupsValue is the pflag.Value of --input-group, adding a group per
+// occurrence of the flag.
+type inputGroupsValue struct {
+	groups *[]InputGroup
+}
+
+func (v inputGroupsValue) String() string {
+	var groups []string
+	for _, group := range *v.groups {
+		groups = append(groups, group.String())
+	}
+	return "[" + strings.Join(groups, " ") + "]"
+}
+
+func (v inputGroupsValue) Set(value string) error {
+	group, err := parseInputGroup(value)
+	if err != nil {
+		return err
+	}
+	*v.groups = append(*v.groups, group)
+	return nil
+}
+
+func (v inputGroupsValue) Type() string {
+	return "group"
+}
+
+// withInputGroups returns a copy of arguments also parsing the packages of the
+// input groups.
+func withInputGroups(arguments *args.GeneratorArgs, customArgs *CustomArgs) *args.GeneratorArgs {
+	if len(customArgs.InputGroups) == 0 {
+		return arguments
+	}
+	extended := *arguments
+	extended.InputDirs = append([]string{}, arguments.InputDirs...)
+	for _, group := range customArgs.InputGroups {
+		extended.InputDirs = append(extended.InputDirs, group.InputDirs...)
+	}
+	return &extended
+}
+
+// packageSettings are the settings a package is generated with, those of the
+// flags or of its input group, with the header of its boilerplate tag.
+type packageSettings struct {
+	outputFileBaseName string
+	header             []byte
+	// headers are the headers of the files of the builders of the
+	// constrained types, by file name.
+	headers      map[string][]byte
+	setterPrefix string
+	// fingerprint hashes the generator and the settings, with --cache-file.
+	fingerprint []byte
+}
+
+// loadHeader returns the header of the generated files with the boilerplate
+// of goHeaderFilePath.
+func loadHeader(arguments *args.GeneratorArgs, constraintHeader []byte, goHeaderFilePath string) ([]byte, error) {
+	headerArgs := *arguments
+	headerArgs.GoHeaderFilePath = goHeaderFilePath
+	boilerplate, err := headerArgs.LoadGoBoilerplate()
+	if err != nil {
+		return nil, fmt.Errorf("Failed loading boilerplate: %v", err)
+	}
+	return append(append([]byte{}, constraintHeader...), boilerplate...), nil
+}
+
+// groupSettings are the settings of an input group and the directories of its
+// packages.
+type groupSettings struct {
+	settings *packageSettings
+	// dirs are the absolute directories of the packages, and recursive
+	// whether their subdirectories are included.
+	dirs      []string
+	recursive []bool
+}
+
+// newGroupSettings resolves the directories of group and loads its
+// boilerplate, defaults holding the settings of the flags.
+func newGroupSettings(arguments *args.GeneratorArgs, constraintHeader []byte, defaults *packageSettings, group InputGroup) (*groupSettings, error) {
+	settings := *defaults
+	if group.OutputFileBaseName != "" {
+		settings.outputFileBaseName = group.OutputFileBaseName
+	}
+	if group.SetterPrefix != "" {
+		settings.setterPrefix = group.SetterPrefix
+	}
+	if group.GoHeaderFilePath != "" {
+		header, err := loadHeader(arguments, constraintHeader, group.GoHeaderFilePath)
+		if err != nil {
+			return nil, err
+		}
+		settings.header = header
+	}
+
+	g := &groupSettings{settings: &settings}
+	for _, dir := range group.InputDirs {
+		recursive := strings.HasSuffix(dir, "/...")
+		dir = strings.TrimSuffix(dir, "/...")
+		p, err := build.Import(dir, ".", build.FindOnly)
+		if err != nil {
+			return nil, fmt.Errorf("Failed locating the input group directory %q: %v", dir, err)
+		}
+		abs, err := filepath.Abs(p.Dir)
+		if err != nil {
+			return nil, err
+		}
+		g.dirs = append(g.dirs, abs)
+		g.recursive = append(g.recursive, recursive)
+	}
+	return g, nil
+}
+
+// contains reports whether the package with its sources in sourcePath belongs
+// to the group.
+func (g *groupSettings) contains(sourcePath string) bool {
+	abs, err := filepath.Abs(sourcePath)
+	if err != nil {
+		return false
+	}
+	for i, dir := range g.dirs {
+		if abs == dir || (g.recursive[i] && isSubdir(dir, abs)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,234 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// The members of interface types tagged +builder-gen:implementations=Foo,Bar
+// are set through the builders of the structs implementing them, one nested
+// builder per implementation: Set<Member>Foo() returns a new FooBuilder, and
+// Build stores the value it builds into the member, or a pointer to it when
+// the methods of the interface have pointer receivers. The structs are named
+// as in the package of the member, or by <import path>.<name>.
+//
+//	type Machine struct {
+//		// +builder-gen:implementations=Start,End
+//		State State
+//	}
+
+// implementation is a struct implementing the interface of a member, set by
+// its nested builder.
+type implementation struct {
+	t *types.Type
+	// pointer tells whether the interface holds pointers to the struct.
+	pointer bool
+	// field is the builder field holding the nested builder.
+	field string
+	// setter is the Set<Member><Struct> method setting the nested builder.
+	setter string
+}
+
+// extractMemberImplementationsTag returns the structs of the
+// +builder-gen:implementations tag of m, as written.
+func extractMemberImplementationsTag(m types.Member) []string {
+	var result []string
+	for _, value := range types.ExtractCommentTags("+", m.CommentLines)[implementationsTagName] {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				result = append(result, name)
+			}
+		}
+	}
+	return result
+}
+
+// implementations returns the implementations of the interface of the member
+// m of t named by its +builder-gen:implementations tag, and the reasons the
+// others are left out: the types which are not structs with builders or
+// which do not implement the interface.
+func (g *genDeepCopy) implementations(t *types.Type, m types.Member) ([]implementation, []string) {
+	names := extractMemberImplementationsTag(m)
+	if len(names) == 0 {
+		return nil, nil
+	}
+	iface := underlyingType(m.Type)
+	if iface.Kind != types.Interface {
+		return nil, []string{fmt.Sprintf("the implementations of %v are not of an interface", m.Type)}
+	}
+	var result []implementation
+	var skipped []string
+	for _, fullName := range names {
+		name := types.Name{Package: t.Name.Package, Name: fullName}
+		if strings.Contains(fullName, ".") {
+			name = types.ParseFullyQualifiedName(fullName)
+		}
+		var it *types.Type
+		if pkg, ok := g.universe[name.Package]; ok {
+			it = pkg.Types[name.Name]
+		}
+		if it == nil || !g.hasBuilder(it) {
+			skipped = append(skipped, fmt.Sprintf("the implementation %s is not a struct with a builder", fullName))
+			continue
+		}
+		impl := implementation{
+			t:      it,
+			field:  propertyName(m) + it.Name.Name,
+			setter: "Set" + g.memberName(m) + it.Name.Name,
+		}
+		implements := true
+		for method := range iface.Methods {
+			found, ok := it.Methods[method]
+			if !ok {
+				implements = false
+				break
+			}
+			if found.Signature != nil && found.Signature.Receiver != nil && found.Signature.Receiver.Kind == types.Pointer {
+				impl.pointer = true
+			}
+		}
+		if !implements {
+			skipped = append(skipped, fmt.Sprintf("the implementation %s does not implement %v", fullName, m.Type))
+			continue
+		}
+		result = append(result, impl)
+	}
+	return result, skipped
+}
+
+// implementationArgs returns the arguments of the snippets of the
+// implementation impl of the member m of t.
+func (g *genDeepCopy) implementationArgs(t *types.Type, m types.Member, impl implementation) generator.Args {
+	return generator.Args{
+		"typeBase":   t,
+		"name":       m.Name,
+		"impl":       impl.t,
+		"field":      impl.field,
+		"setter":     impl.setter,
+		"builder":    builderOf(impl.t),
+		"newBuilder": g.constructorOf(impl.t),
+		"build":      g.buildName(impl.t),
+	}
+}
+
+// implementationFields writes the builder fields of the nested builders of
+// the implementations of the member m of t.
+func (g *genDeepCopy) implementationFields(sw *generator.SnippetWriter, t *types.Type, m types.Member) {
+	impls, _ := g.implementations(t, m)
+	for _, impl := range impls {
+		sw.Do("$.field$ *$.builder|raw$\n", g.implementationArgs(t, m, impl))
+	}
+}
+
+// implementationSetters writes the Set<Member><Struct> methods of the member
+// m of t, setting the nested builder of an implementation and dropping those
+// of the others. Without --copy-on-write they return the new builder, with it
+// they set it with an update function.
+func (g *genDeepCopy) implementationSetters(sw *generator.SnippetWriter, t *types.Type, m types.Member) {
+	impls, skipped := g.implementations(t, m)
+	for _, reason := range skipped {
+		g.warn(t, m, reason)
+	}
+	for _, impl := range impls {
+		args := g.implementationArgs(t, m, impl)
+		if g.handWritten(t, impl.setter) {
+			continue
+		}
+		var others []string
+		for _, other := range impls {
+			if other.field != impl.field {
+				others = append(others, other.field)
+			}
+		}
+		if g.customArgs.CopyOnWrite {
+			sw.Do("// $.setter$ sets $.name$ to a new $.impl|raw$, set by update.\n", args)
+			sw.Do("func (b *$.typeBase|raw$Builder) $.setter$(update func(*$.builder|raw$) *$.builder|raw$) *$.typeBase|raw$Builder {\n", args)
+			sw.Do("b = b.copyOnWrite()\n", args)
+			g.clearOneof(sw, t, m)
+			for _, field := range others {
+				sw.Do("b."+field+" = nil\n", args)
+			}
+			sw.Do("b.$.field$ = update($.newBuilder|raw$())\n", args)
+			sw.Do("return b\n", args)
+			sw.Do("}\n\n", args)
+			continue
+		}
+		sw.Do("// $.setter$ sets $.name$ to a new $.impl|raw$, returning its builder.\n", args)
+		sw.Do("func (b *$.typeBase|raw$Builder) $.setter$() *$.builder|raw$ {\n", args)
+		g.clearOneof(sw, t, m)
+		for _, field := range others {
+			sw.Do("b."+field+" = nil\n", args)
+		}
+		sw.Do("b.$.field$ = $.newBuilder|raw$()\n", args)
+		sw.Do("return b.$.field$\n", args)
+		sw.Do("}\n\n", args)
+	}
+}
+
+// clearImplementations writes, in the setters of the member m of t, the
+// statements dropping the nested builders of its implementations.
+func (g *genDeepCopy) clearImplementations(sw *generator.SnippetWriter, t *types.Type, m types.Member) {
+	impls, _ := g.implementations(t, m)
+	for _, impl := range impls {
+		sw.Do("b.$.field$ = nil\n", g.implementationArgs(t, m, impl))
+	}
+}
+
+// implementationsBuild writes the building of the nested builder of the
+// implementation of the member m of t set, if any, into the model.
+func (g *genDeepCopy) implementationsBuild(sw *generator.SnippetWriter, t *types.Type, m types.Member) {
+	impls, _ := g.implementations(t, m)
+	for _, impl := range impls {
+		args := g.implementationArgs(t, m, impl)
+		sw.Do("if b.$.field$ != nil {\n", args)
+		if impl.pointer {
+			sw.Do("$.field$ := b.$.field$.$.build$()\n", args)
+			sw.Do("b.model.$.name$ = &$.field$\n", args)
+		} else {
+			sw.Do("b.model.$.name$ = b.$.field$.$.build$()\n", args)
+		}
+		sw.Do("}\n", args)
+	}
+}
+
+// implementationsFromModel writes the replacement of the nested builders of
+// the implementations of the member m of t by a builder of the value of
+// model, when it holds one of them.
+func (g *genDeepCopy) implementationsFromModel(sw *generator.SnippetWriter, t *types.Type, m types.Member) {
+	impls, _ := g.implementations(t, m)
+	if len(impls) == 0 {
+		return
+	}
+	g.clearImplementations(sw, t, m)
+	sw.Do("switch v := model.$.name$.(type) {\n", generator.Args{"name": m.Name})
+	for _, impl := range impls {
+		args := g.implementationArgs(t, m, impl)
+		if impl.pointer {
+			sw.Do("case *$.impl|raw$:\n", args)
+			sw.Do("if v != nil {\n", args)
+			g.builderFromModel(sw, "b."+impl.field, false, "*v", impl.t)
+			sw.Do("}\n", args)
+		} else {
+			sw.Do("case $.impl|raw$:\n", args)
+			g.builderFromModel(sw, "b."+impl.field, false, "v", impl.t)
+		}
+	}
+	sw.Do("}\n", generator.Args{})
+}
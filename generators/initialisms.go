@@ -0,0 +1,80 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"strings"
+	"unicode"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+)
+
+// commonInitialisms are the initialisms of golint, upper-cased in the names
+// of the builder methods unless --initialisms lists others.
+var commonInitialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP",
+	"HTTPS", "ID", "IP", "JSON", "LHS", "QPS", "RAM", "RHS", "RPC", "SLA",
+	"SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID", "UUID",
+	"URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS",
+}
+
+// initialisms returns the initialisms upper-cased in the names of the
+// builder methods, those of golint when --initialisms is not set.
+func (ca *CustomArgs) initialisms() sets.String {
+	if ca.Initialisms == nil {
+		return sets.NewString(commonInitialisms...)
+	}
+	result := sets.NewString()
+	for _, initialism := range ca.Initialisms {
+		result.Insert(strings.ToUpper(initialism))
+	}
+	return result
+}
+
+// initialismName upper-cases the words of name which are initialisms, like
+// golint does: UserId becomes UserID and HttpServer HTTPServer. The words
+// start at the upper-case letters following another letter case, or opening
+// a word after a run of upper-case letters, like Server in HTTPServer.
+func initialismName(name string, initialisms sets.String) string {
+	if initialisms.Len() == 0 {
+		return name
+	}
+	runes := []rune(name)
+	var result strings.Builder
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		if i < len(runes) && !wordStart(runes, i) {
+			continue
+		}
+		word := string(runes[start:i])
+		if upper := strings.ToUpper(word); initialisms.Has(upper) {
+			word = upper
+		}
+		result.WriteString(word)
+		start = i
+	}
+	return result.String()
+}
+
+// wordStart reports whether the rune i of a camel-cased name starts a word.
+func wordStart(runes []rune, i int) bool {
+	if !unicode.IsUpper(runes[i]) {
+		return false
+	}
+	if !unicode.IsUpper(runes[i-1]) {
+		return true
+	}
+	return i+1 < len(runes) && unicode.IsLower(runes[i+1])
+}
@@ -0,0 +1,169 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// inlineStructs holds the named types standing for the anonymous structs of
+// the members, named after the struct declaring them and the member. The
+// generated files declare them as aliases of the anonymous structs, so that
+// they get builders like the other structs.
+var inlineStructs = struct {
+	sync.Mutex
+	// members are the member types using the named types, by declaring
+	// struct and member name.
+	members map[*types.Type]map[string]*types.Type
+	// parents are the structs declaring the anonymous structs.
+	parents map[*types.Type]*types.Type
+}{
+	members: map[*types.Type]map[string]*types.Type{},
+	parents: map[*types.Type]*types.Type{},
+}
+
+// isAnonymousStruct reports whether t is a struct type literal.
+func isAnonymousStruct(t *types.Type) bool {
+	return t.Kind == types.Struct && t.Name.Package == ""
+}
+
+// inlineMemberType returns the type of the member m of parent, the anonymous
+// struct it holds, possibly behind a pointer or as the elements of a slice or
+// map, replaced by its named type.
+func inlineMemberType(parent *types.Type, m types.Member) *types.Type {
+	inlineStructs.Lock()
+	defer inlineStructs.Unlock()
+	if mt, ok := inlineStructs.members[parent][m.Name]; ok {
+		return mt
+	}
+
+	mt := replaceAnonymousStruct(m.Type, func(anonymous *types.Type) *types.Type {
+		named := &types.Type{
+			Name:    types.Name{Package: parent.Name.Package, Name: parent.Name.Name + m.Name},
+			Kind:    types.Struct,
+			Members: anonymous.Members,
+		}
+		inlineStructs.parents[named] = parent
+		return named
+	})
+	if inlineStructs.members[parent] == nil {
+		inlineStructs.members[parent] = map[string]*types.Type{}
+	}
+	inlineStructs.members[parent][m.Name] = mt
+	return mt
+}
+
+// replaceAnonymousStruct returns t, with the anonymous struct it holds
+// directly, behind a pointer or in a slice or map replaced by named(struct).
+func replaceAnonymousStruct(t *types.Type, named func(*types.Type) *types.Type) *types.Type {
+	switch {
+	case isAnonymousStruct(t):
+		return named(t)
+	case t.Kind == types.Pointer || t.Kind == types.Slice || t.Kind == types.Map:
+		if t.Name.Package != "" {
+			// Named slices and maps are declared with their own types.
+			return t
+		}
+		elem := replaceAnonymousStruct(t.Elem, named)
+		if elem == t.Elem {
+			return t
+		}
+		return &types.Type{Kind: t.Kind, Key: t.Key, Elem: elem}
+	}
+	return t
+}
+
+// inlineParent returns the struct declaring the anonymous struct standing for
+// t, nil when t is not one of them.
+func inlineParent(t *types.Type) *types.Type {
+	inlineStructs.Lock()
+	defer inlineStructs.Unlock()
+	return inlineStructs.parents[t]
+}
+
+// inlineStructsOf returns the named types of the anonymous structs of the
+// members of t.
+func inlineStructsOf(t *types.Type) []*types.Type {
+	var result []*types.Type
+	for _, m := range builderMembers(t) {
+		if st := builderType(m.Type); st.Kind == types.Slice || st.Kind == types.Map {
+			if st = builderType(st.Elem); inlineParent(st) != nil {
+				result = append(result, st)
+			}
+		} else if inlineParent(st) != nil {
+			result = append(result, st)
+		}
+	}
+	return result
+}
+
+// inlineStructAlias declares the named type of an anonymous struct as its
+// alias, tags included for the types to be identical.
+func (g *genDeepCopy) inlineStructAlias(sw *generator.SnippetWriter, t *types.Type) {
+	parent := inlineParent(t)
+	args := generator.Args{
+		"type":   t,
+		"parent": parent,
+		"member": strings.TrimPrefix(t.Name.Name, parent.Name.Name),
+	}
+	sw.Do("// $.type|raw$ is the anonymous struct of $.parent|raw$.$.member$.\n", args)
+	sw.Do("type $.type|raw$ = ", args)
+	g.writeStructLiteral(sw, t.Members)
+	sw.Do("\n\n", nil)
+}
+
+// writeStructLiteral writes the struct type literal with members.
+func (g *genDeepCopy) writeStructLiteral(sw *generator.SnippetWriter, members []types.Member) {
+	sw.Do("struct {\n", nil)
+	for _, m := range members {
+		if !m.Embedded {
+			sw.Do("$.$ ", m.Name)
+		}
+		g.writeTypeLiteral(sw, m.Type)
+		if m.Tags != "" {
+			tags := "`" + m.Tags + "`"
+			if strings.Contains(m.Tags, "`") {
+				tags = strconv.Quote(m.Tags)
+			}
+			sw.Do(" $.$", tags)
+		}
+		sw.Do("\n", nil)
+	}
+	sw.Do("}", nil)
+}
+
+// writeTypeLiteral writes t, spelling out the anonymous structs it holds.
+func (g *genDeepCopy) writeTypeLiteral(sw *generator.SnippetWriter, t *types.Type) {
+	switch {
+	case isAnonymousStruct(t):
+		g.writeStructLiteral(sw, t.Members)
+	case t.Name.Package == "" && t.Kind == types.Pointer:
+		sw.Do("*", nil)
+		g.writeTypeLiteral(sw, t.Elem)
+	case t.Name.Package == "" && t.Kind == types.Slice:
+		sw.Do("[]", nil)
+		g.writeTypeLiteral(sw, t.Elem)
+	case t.Name.Package == "" && t.Kind == types.Map:
+		sw.Do("map[$.|raw$]", t.Key)
+		g.writeTypeLiteral(sw, t.Elem)
+	default:
+		sw.Do("$.|raw$", t)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+	"k8s.io/klog/v2"
+)
+
+// metaField is a field of metav1.ObjectMeta or metav1.TypeMeta the builders
+// of the types holding them set directly.
+type metaField struct {
+	name string
+	// typ is the Go type of the field, made of builtin types.
+	typ string
+}
+
+// metaFields are the fields set by the builders, by metadata type.
+var metaFields = map[types.Name][]metaField{
+	objectMetaName: {
+		{name: "Name", typ: "string"},
+		{name: "Namespace", typ: "string"},
+		{name: "Labels", typ: "map[string]string"},
+		{name: "Annotations", typ: "map[string]string"},
+	},
+	typeMetaName: {
+		{name: "APIVersion", typ: "string"},
+		{name: "Kind", typ: "string"},
+	},
+}
+
+// metaMembers returns the members of t holding, directly or by pointer, a
+// metav1.ObjectMeta or a metav1.TypeMeta without a builder, by metadata
+// type. The types holding several members of the same metadata type are left
+// out, the setters of their fields being ambiguous.
+func (g *genDeepCopy) metaMembers(t *types.Type) map[types.Name]types.Member {
+	result := map[types.Name]types.Member{}
+	ambiguous := map[types.Name]bool{}
+	for _, m := range builderMembers(t) {
+		mt := builderType(m.Type)
+		if _, ok := metaFields[mt.Name]; !ok || g.hasBuilder(mt) {
+			continue
+		}
+		if _, ok := result[mt.Name]; ok {
+			ambiguous[mt.Name] = true
+		}
+		result[mt.Name] = m
+	}
+	for name := range ambiguous {
+		klog.V(2).Infof("Type %v holds several %v members, their fields get no setters", t, name)
+		delete(result, name)
+	}
+	return result
+}
+
+// metaSetters writes the setters of the builder of t setting the name,
+// namespace, labels and annotations of its metav1.ObjectMeta member, and the
+// API version and kind of its metav1.TypeMeta member, but those whose names
+// are taken by the other methods of the builder, promoted setters included.
+func (g *genDeepCopy) metaSetters(sw *generator.SnippetWriter, t *types.Type, promoted []promotedSetter) {
+	members := g.metaMembers(t)
+	if len(members) == 0 {
+		return
+	}
+	taken := sets.NewString(g.buildName(t), g.buildName(t)+"Ptr")
+	for _, m := range builderMembers(t) {
+		taken.Insert(g.methodName(t, m))
+	}
+	for _, setter := range promoted {
+		taken.Insert(setter.name)
+	}
+
+	for _, metaName := range []types.Name{typeMetaName, objectMetaName} {
+		m, ok := members[metaName]
+		if !ok {
+			continue
+		}
+		for _, field := range metaFields[metaName] {
+			setter := g.typeSetterPrefix(t) + field.name
+			if taken.Has(setter) || g.reservedMethodName(t, setter) {
+				klog.V(2).Infof("Member %s of %v has no %s() setter, the name is taken", m.Name, t, setter)
+				continue
+			}
+			taken.Insert(setter)
+			if g.handWritten(t, setter) {
+				continue
+			}
+			args := generator.Args{
+				"typeBase":  t,
+				"meta":      builderType(m.Type),
+				"name":      m.Name,
+				"field":     field.name,
+				"fieldType": field.typ,
+				"setter":    setter,
+			}
+			sw.Do("// $.setter$ sets the $.field$ of $.name$.\n", args)
+			sw.Do("func (b *$.typeBase|raw$Builder) $.setter$(input $.fieldType$) *$.typeBase|raw$Builder {\n", args)
+			g.copyOnWrite(sw)
+			g.clearOneof(sw, t, m)
+			if underlyingType(m.Type).Kind == types.Pointer {
+				// The value pointed to may be shared with the model of
+				// another builder, it is copied.
+				sw.Do("meta := $.meta|raw${}\n", args)
+				sw.Do("if b.model.$.name$ != nil {\n", args)
+				sw.Do("meta = *b.model.$.name$\n", args)
+				sw.Do("}\n", args)
+				sw.Do("meta.$.field$ = input\n", args)
+				sw.Do("b.model.$.name$ = &meta\n", args)
+			} else {
+				sw.Do("b.model.$.name$.$.field$ = input\n", args)
+			}
+			sw.Do("return b\n", args)
+			sw.Do("}\n\n", args)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"go/token"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// With --kubernetes, the builders of the controller-runtime client.Objects,
+// the Kubernetes objects embedding metav1.ObjectMeta, also create the built
+// objects with a client and convert them to unstructured objects, and their
+// constructors set the API version and the kind of the objects.
+
+// controllerClientPackage is the package of the clients of controller-runtime,
+// which the module of the objects requires with --kubernetes.
+const controllerClientPackage = "sigs.k8s.io/controller-runtime/pkg/client"
+
+// Types and values used by the Kubernetes helpers.
+var (
+	controllerClientType  = &types.Type{Name: types.Name{Package: controllerClientPackage, Name: "Client"}}
+	createOptionType      = &types.Type{Name: types.Name{Package: controllerClientPackage, Name: "CreateOption"}}
+	unstructuredType      = &types.Type{Name: types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured", Name: "Unstructured"}}
+	unstructuredConverter = &types.Type{Name: types.Name{Package: "k8s.io/apimachinery/pkg/runtime", Name: "DefaultUnstructuredConverter"}}
+)
+
+// groupNameTagName is the tag of the doc.go of the Kubernetes API packages
+// naming their API group, in which the objects of the package are registered
+// with the version of the package.
+const groupNameTagName = "groupName"
+
+// apiVersionPattern matches the versions of the Kubernetes APIs, like v1 or
+// v1beta2.
+var apiVersionPattern = regexp.MustCompile(`^v[0-9]+((alpha|beta)[0-9]+)?$`)
+
+// extractGVKTag returns the API version and the kind of the
+// +builder-gen:gvk=group/version,Kind=Foo tag of t, the kind defaulting to
+// the name of t. ok is false when t has no such tag.
+func extractGVKTag(t *types.Type) (apiVersion, kind string, ok bool, err error) {
+	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
+	values := types.ExtractCommentTags("+", comments)[gvkTagName]
+	if len(values) == 0 {
+		return "", "", false, nil
+	}
+	if len(values) > 1 {
+		return "", "", true, fmt.Errorf("%v: the %s tag is set several times", t, gvkTagName)
+	}
+	parts := strings.Split(values[0], ",")
+	apiVersion, kind = strings.TrimSpace(parts[0]), t.Name.Name
+	for _, part := range parts[1:] {
+		value := strings.TrimPrefix(strings.TrimSpace(part), "Kind=")
+		if value == strings.TrimSpace(part) || !token.IsIdentifier(value) || !token.IsExported(value) {
+			return "", "", true, fmt.Errorf("%v: unsupported %s value %q, expecting group/version,Kind=Kind", t, gvkTagName, values[0])
+		}
+		kind = value
+	}
+	group, version := "", apiVersion
+	if i := strings.LastIndex(apiVersion, "/"); i >= 0 {
+		group, version = apiVersion[:i], apiVersion[i+1:]
+	}
+	if !apiVersionPattern.MatchString(version) || strings.ContainsAny(group, "/ ") || (group == "" && version != apiVersion) {
+		return "", "", true, fmt.Errorf("%v: the %s tag names the unsupported API version %q", t, gvkTagName, apiVersion)
+	}
+	return apiVersion, kind, true, nil
+}
+
+// checkGVKTag returns an error if the +builder-gen:gvk tag of t is malformed,
+// or set on a type not embedding metav1.TypeMeta.
+func checkGVKTag(t *types.Type) error {
+	_, _, ok, err := extractGVKTag(t)
+	if err != nil || !ok {
+		return err
+	}
+	if !embedsTypeMeta(t) {
+		return fmt.Errorf("%v: the %s tag only applies to types embedding metav1.TypeMeta", t, gvkTagName)
+	}
+	return nil
+}
+
+// groupVersionKind returns, with --kubernetes, the API version and the kind
+// set by the constructor of the builder of t: those of its
+// +builder-gen:gvk tag, or those the Kubernetes objects of t's package are
+// registered with, the group of the groupName tag of its doc.go and the
+// version of its import path. Both are empty when they are unknown.
+func (g *genDeepCopy) groupVersionKind(t *types.Type) (apiVersion, kind string) {
+	if !g.customArgs.Kubernetes || !embedsTypeMeta(t) {
+		return "", ""
+	}
+	if apiVersion, kind, ok, err := extractGVKTag(t); ok && err == nil {
+		return apiVersion, kind
+	}
+	pkg := g.universe.Package(t.Name.Package)
+	if pkg == nil || !isKubernetesObject(t) {
+		return "", ""
+	}
+	groups, ok := types.ExtractCommentTags("+", pkg.Comments)[groupNameTagName]
+	version := path.Base(t.Name.Package)
+	if !ok || len(groups) != 1 || !apiVersionPattern.MatchString(version) {
+		return "", ""
+	}
+	if groups[0] == "" {
+		return version, t.Name.Name
+	}
+	return groups[0] + "/" + version, t.Name.Name
+}
+
+// newGroupVersionKind writes, in the constructor of the builder of t, the
+// statements setting the API version and the kind of its metav1.TypeMeta.
+func (g *genDeepCopy) newGroupVersionKind(sw *generator.SnippetWriter, t *types.Type) {
+	apiVersion, kind := g.groupVersionKind(t)
+	if apiVersion == "" {
+		return
+	}
+	args := generator.Args{
+		"apiVersion": strconv.Quote(apiVersion),
+		"kind":       strconv.Quote(kind),
+	}
+	sw.Do("builder.model.TypeMeta.APIVersion = $.apiVersion$\n", args)
+	sw.Do("builder.model.TypeMeta.Kind = $.kind$\n", args)
+}
+
+// isClientObject reports whether the pointers to t are controller-runtime
+// client.Objects: t is a Kubernetes object embedding metav1.ObjectMeta.
+func isClientObject(t *types.Type) bool {
+	if !isKubernetesObject(t) {
+		return false
+	}
+	for _, m := range t.Members {
+		if m.Embedded && m.Type.Name == objectMetaName {
+			return true
+		}
+	}
+	return false
+}
+
+// structMethodBuildAndCreate writes, with --kubernetes, the BuildAndCreate
+// method of the builders of the client.Objects, creating the built object
+// with a controller-runtime client.
+func (g *genDeepCopy) structMethodBuildAndCreate(sw *generator.SnippetWriter, t *types.Type) {
+	if !g.customArgs.Kubernetes || !isClientObject(t) || g.handWritten(t, "BuildAndCreate") {
+		return
+	}
+
+	args := generator.Args{
+		"type":         t,
+		"build":        g.buildName(t),
+		"context":      &types.Type{Name: contextName},
+		"client":       controllerClientType,
+		"createOption": createOptionType,
+	}
+	sw.Do("// BuildAndCreate builds the object and creates it with c, returning the\n", args)
+	sw.Do("// object updated by the API server.\n", args)
+	sw.Do("func (b *$.type|raw$Builder) BuildAndCreate(ctx $.context|raw$, c $.client|raw$, opts ...$.createOption|raw$) (*$.type|raw$, error) {\n", args)
+	sw.Do("model := b.$.build$()\n", args)
+	sw.Do("if err := c.Create(ctx, &model, opts...); err != nil {\n", args)
+	sw.Do("return nil, err\n", args)
+	sw.Do("}\n", args)
+	sw.Do("return &model, nil\n", args)
+	sw.Do("}\n\n", args)
+}
+
+// structMethodBuildUnstructured writes, with --kubernetes, the
+// BuildUnstructured method of the builders of the client.Objects, converting
+// the built object to an unstructured object.
+func (g *genDeepCopy) structMethodBuildUnstructured(sw *generator.SnippetWriter, t *types.Type) {
+	if !g.customArgs.Kubernetes || !isClientObject(t) || g.handWritten(t, "BuildUnstructured") {
+		return
+	}
+
+	args := generator.Args{
+		"type":         t,
+		"build":        g.buildName(t),
+		"unstructured": unstructuredType,
+		"converter":    unstructuredConverter,
+	}
+	sw.Do("// BuildUnstructured builds the object and converts it to an unstructured\n", args)
+	sw.Do("// object.\n", args)
+	sw.Do("func (b *$.type|raw$Builder) BuildUnstructured() (*$.unstructured|raw$, error) {\n", args)
+	sw.Do("model := b.$.build$()\n", args)
+	sw.Do("content, err := $.converter|raw$.ToUnstructured(&model)\n", args)
+	sw.Do("if err != nil {\n", args)
+	sw.Do("return nil, err\n", args)
+	sw.Do("}\n", args)
+	sw.Do("return &$.unstructured|raw${Object: content}, nil\n", args)
+	sw.Do("}\n\n", args)
+}
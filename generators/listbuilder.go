@@ -0,0 +1,432 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// The members holding slices or maps of slices of structs with builders, like
+// [][]TestB, map[string][]*TestB and deeper, are held by the builders as
+// slices or maps of list builders, one per element: the <Elem>ListBuilder of []TestB adds a nested builder
+// per element of the list, the <Elem>ListListBuilder of [][]TestB a list
+// builder per element, and so on. The list builders are declared once per
+// package, by the first builder using them, and the named slices of the
+// package get theirs as their builder, with its New<Slice>Builder
+// constructor.
+
+// builderLists returns the slice type of the elements of the member m when
+// it is a slice or a map, not behind a pointer, of slices of structs with
+// builders, possibly nested deeper, nil otherwise.
+func (g *genDeepCopy) builderLists(m types.Member) *types.Type {
+	u := underlyingType(m.Type)
+	if (u.Kind != types.Slice && u.Kind != types.Map) || !g.isBuilderList(u.Elem) {
+		return nil
+	}
+	return u.Elem
+}
+
+// isBuilderList reports whether t is a slice of structs with builders, or of
+// pointers to them, or a slice of such slices.
+func (g *genDeepCopy) isBuilderList(t *types.Type) bool {
+	u := underlyingType(t)
+	return u.Kind == types.Slice && (g.hasBuilder(u.Elem) || g.isBuilderList(u.Elem))
+}
+
+// listBuilderOf returns the list builder of the slice list, named after the
+// named slice type, like the builders, or the structs it holds:
+// TestBListBuilder for []TestB, TestBPointerListBuilder for []*TestB and
+// TestBListListBuilder for [][]TestB.
+func (g *genDeepCopy) listBuilderOf(list *types.Type) *types.Type {
+	return &types.Type{Name: types.Name{Package: g.targetPackage, Name: listBaseName(list) + "Builder"}}
+}
+
+func listBaseName(t *types.Type) string {
+	if t.Kind == types.Alias && t.Name.Package != "" {
+		return t.Name.Name
+	}
+	u := underlyingType(t)
+	switch u.Kind {
+	case types.Slice:
+		return listBaseName(u.Elem) + "List"
+	case types.Pointer:
+		return builderType(u).Name.Name + "Pointer"
+	}
+	return builderType(u).Name.Name
+}
+
+// listItemArgs returns the arguments of the snippets of the list builder of
+// list about its items: the nested builders of its elements, or the list
+// builders of its slices.
+func (g *genDeepCopy) listItemArgs(list *types.Type) generator.Args {
+	elem := underlyingType(list).Elem
+	args := generator.Args{
+		"listBuilder": g.listBuilderOf(list),
+		"list":        list,
+		"elem":        elem,
+		"pointer":     underlyingType(elem).Kind == types.Pointer,
+		"sprintf":     sprintfFunc,
+		"errors":      g.errorsType(),
+	}
+	if g.isBuilderList(elem) {
+		args["item"] = g.listBuilderOf(elem)
+		args["build"] = "Build"
+	} else {
+		args["item"] = builderOf(builderType(elem))
+		args["newBuilder"] = g.constructorOf(builderType(elem))
+		args["build"] = g.buildName(builderType(elem))
+	}
+	return args
+}
+
+// listBuilders writes the list builders used by the members of t, those of
+// the named slices they hold included, and by their list builders, not yet
+// declared in the package.
+func (g *genDeepCopy) listBuilders(sw *generator.SnippetWriter, t *types.Type) {
+	for _, m := range builderMembers(t) {
+		list := g.builderLists(m)
+		if named := g.namedList(m); named != nil {
+			list = named
+		}
+		for ; list != nil; list = underlyingType(list).Elem {
+			if !g.isBuilderList(list) {
+				break
+			}
+			g.listBuilder(sw, list)
+		}
+	}
+}
+
+// listBuilder writes the list builder of the slice list, unless declared.
+func (g *genDeepCopy) listBuilder(sw *generator.SnippetWriter, list *types.Type) {
+	args := g.listItemArgs(list)
+	name := args["listBuilder"].(*types.Type).Name.Name
+	if g.lists.Has(name) || g.handWritten(nil, name) {
+		return
+	}
+	g.lists.Insert(name)
+	nested := g.isBuilderList(args["elem"].(*types.Type))
+
+	if list.Kind == types.Alias && list.Name.Package == g.targetPackage {
+		args["constructor"] = g.newBuilderOf(list)
+		sw.Do("// $.constructor|raw$ creates a list builder for $.list|raw$.\n", args)
+		sw.Do("func $.constructor|raw$() *$.listBuilder|raw$ {\n", args)
+		sw.Do("return &$.listBuilder|raw${}\n", args)
+		sw.Do("}\n\n", args)
+	}
+
+	sw.Do("// $.listBuilder|raw$ builds the $.list|raw$ lists of the members holding\n", args)
+	sw.Do("// slices of them, with a builder per element.\n", args)
+	sw.Do("type $.listBuilder|raw$ struct {\n", args)
+	sw.Do("items []*$.item|raw$\n", args)
+	sw.Do("}\n\n", args)
+
+	sw.Do("// Add appends a new builder to the list and returns it.\n", args)
+	sw.Do("func (b *$.listBuilder|raw$) Add() *$.item|raw$ {\n", args)
+	if nested {
+		sw.Do("builder := &$.item|raw${}\n", args)
+	} else {
+		sw.Do("builder := $.newBuilder|raw$()\n", args)
+	}
+	sw.Do("b.items = append(b.items, builder)\n", args)
+	sw.Do("return builder\n", args)
+	sw.Do("}\n\n", args)
+
+	sw.Do("// Build returns the list built by the builders added, in their order.\n", args)
+	sw.Do("func (b *$.listBuilder|raw$) Build() $.list|raw$ {\n", args)
+	sw.Do("list := make($.list|raw$, 0, len(b.items))\n", args)
+	sw.Do("for _, v := range b.items {\n", args)
+	if args["pointer"].(bool) {
+		sw.Do("vv := v.$.build$()\n", args)
+		sw.Do("list = append(list, &vv)\n", args)
+	} else {
+		sw.Do("list = append(list, v.$.build$())\n", args)
+	}
+	sw.Do("}\n", args)
+	sw.Do("return list\n", args)
+	sw.Do("}\n\n", args)
+
+	if g.customArgs.AccumulateErrors {
+		sw.Do("// Err returns the errors of the builders of the list, nil if none failed.\n", args)
+		sw.Do("func (b *$.listBuilder|raw$) Err() error {\n", args)
+		sw.Do("var errs $.errors$\n", args)
+		sw.Do("for _, v := range b.items {\n", args)
+		sw.Do("if err := v.Err(); err != nil {\n", args)
+		sw.Do("errs = append(errs, err)\n", args)
+		sw.Do("}\n", args)
+		sw.Do("}\n", args)
+		g.returnErrors(sw, "")
+		sw.Do("}\n\n", args)
+	}
+
+	sw.Do("// GoString lists the builders of the list, for %#v.\n", args)
+	sw.Do("func (b *$.listBuilder|raw$) GoString() string {\n", args)
+	sw.Do("return $.sprintf|raw$(\"&$.listBuilder|raw${items: %#v}\", b.items)\n", args)
+	sw.Do("}\n\n", args)
+
+	sw.Do("// Clone returns a copy of the list builder and of its builders.\n", args)
+	sw.Do("func (b *$.listBuilder|raw$) Clone() *$.listBuilder|raw$ {\n", args)
+	sw.Do("clone := &$.listBuilder|raw${items: make([]*$.item|raw$, len(b.items))}\n", args)
+	sw.Do("for i, v := range b.items {\n", args)
+	sw.Do("clone.items[i] = v.Clone()\n", args)
+	sw.Do("}\n", args)
+	sw.Do("return clone\n", args)
+	sw.Do("}\n\n", args)
+
+	sw.Do("func (b *$.listBuilder|raw$) fromModel(model $.list|raw$) {\n", args)
+	sw.Do("b.items = make([]*$.item|raw$, 0, len(model))\n", args)
+	sw.Do("for _, v := range model {\n", args)
+	switch elem := args["elem"].(*types.Type); {
+	case nested:
+		sw.Do("builder := &$.item|raw${}\n", args)
+		sw.Do("builder.fromModel(v)\n", args)
+	case args["pointer"].(bool):
+		sw.Do("if v == nil {\n", args)
+		sw.Do("continue\n", args)
+		sw.Do("}\n", args)
+		g.builderFromModel(sw, "builder", true, "*v", builderType(elem))
+	default:
+		g.builderFromModel(sw, "builder", true, "v", builderType(elem))
+	}
+	sw.Do("b.items = append(b.items, builder)\n", args)
+	sw.Do("}\n", args)
+	sw.Do("}\n\n", args)
+}
+
+// listsArgs returns the arguments of the snippets of the member m holding a
+// slice or a map of the lists list of nested builders.
+func (g *genDeepCopy) listsArgs(t *types.Type, m types.Member, list *types.Type) generator.Args {
+	u := underlyingType(m.Type)
+	args := generator.Args{
+		"typeBase":    t,
+		"type":        u,
+		"typeAlias":   m.Type,
+		"name":        m.Name,
+		"nameMethod":  propertyName(m),
+		"setter":      g.methodName(t, m),
+		"base":        g.memberName(m),
+		"listBuilder": g.listBuilderOf(list),
+		"cap":         extractMemberCapTag(m),
+		"lists":       "[]*$.listBuilder|raw$",
+	}
+	if u.Kind == types.Map {
+		args["key"] = u.Key
+		args["lists"] = "map[$.key|raw$]*$.listBuilder|raw$"
+	}
+	return args
+}
+
+// listsMethods writes the setter of the member m of t, replacing its list
+// builders by list builders of the elements of input, and, without
+// --copy-on-write, the Add<Member> method adding a new list builder, appended
+// to the slices or set for its key in the maps.
+func (g *genDeepCopy) listsMethods(sw *generator.SnippetWriter, t *types.Type, m types.Member, list *types.Type) {
+	args := g.listsArgs(t, m, list)
+	if !g.handWritten(t, args["setter"].(string)) {
+		writeDoc(sw, docLines(m.CommentLines))
+		sw.Do("func (b *$.typeBase|raw$Builder) $.setter$(input $.typeAlias|raw$) *$.typeBase|raw$Builder {\n", args)
+		g.copyOnWrite(sw)
+		g.clearOneof(sw, t, m)
+		g.listsFromModel(sw, args, "input")
+		sw.Do("return b\n", args)
+		sw.Do("}\n\n", args)
+	}
+	g.conditionalSetter(sw, t, args)
+	if g.customArgs.CopyOnWrite || g.handWritten(t, "Add"+args["base"].(string)) {
+		return
+	}
+	if args["key"] != nil {
+		sw.Do("// Add$.base$ sets a new list builder, building the element of $.name$ for\n", args)
+		sw.Do("// key, and returns it.\n", args)
+		sw.Do("func (b *$.typeBase|raw$Builder) Add$.base$(key $.key|raw$) *$.listBuilder|raw$ {\n", args)
+		g.clearOneof(sw, t, m)
+		sw.Do("if b.$.nameMethod$ == nil {\n", args)
+		sw.Do("b.$.nameMethod$ = "+args["lists"].(string)+"{}\n", args)
+		sw.Do("}\n", args)
+		sw.Do("builder := &$.listBuilder|raw${}\n", args)
+		sw.Do("b.$.nameMethod$[key] = builder\n", args)
+		sw.Do("return builder\n", args)
+		sw.Do("}\n\n", args)
+		return
+	}
+	sw.Do("// Add$.base$ appends a new list builder, building an element of\n", args)
+	sw.Do("// $.name$, and returns it.\n", args)
+	sw.Do("func (b *$.typeBase|raw$Builder) Add$.base$() *$.listBuilder|raw$ {\n", args)
+	g.clearOneof(sw, t, m)
+	sw.Do("builder := &$.listBuilder|raw${}\n", args)
+	sw.Do("b.$.nameMethod$ = append(b.$.nameMethod$, builder)\n", args)
+	sw.Do("return builder\n", args)
+	sw.Do("}\n\n", args)
+}
+
+// listsFromModel writes the replacement of the list builders of a member by
+// list builders of the elements of the slice or map source.
+func (g *genDeepCopy) listsFromModel(sw *generator.SnippetWriter, args generator.Args, source string) {
+	args["source"] = source
+	if args["key"] != nil {
+		sw.Do("b.$.nameMethod$ = make("+args["lists"].(string)+", len($.source$))\n", args)
+		sw.Do("for k, v := range $.source$ {\n", args)
+	} else {
+		sw.Do("b.$.nameMethod$ = make("+args["lists"].(string)+", 0, len($.source$))\n", args)
+		sw.Do("for _, v := range $.source$ {\n", args)
+	}
+	sw.Do("builder := &$.listBuilder|raw${}\n", args)
+	sw.Do("builder.fromModel(v)\n", args)
+	if args["key"] != nil {
+		sw.Do("b.$.nameMethod$[k] = builder\n", args)
+	} else {
+		sw.Do("b.$.nameMethod$ = append(b.$.nameMethod$, builder)\n", args)
+	}
+	sw.Do("}\n", args)
+}
+
+// listsBuild writes the building of the list builders of a member into the
+// slice or map of the model.
+func (g *genDeepCopy) listsBuild(sw *generator.SnippetWriter, m types.Member, args generator.Args) {
+	switch {
+	case args["key"] != nil:
+		sw.Do("b.model.$.name$ = make($.type|raw$, len(b.$.nameMethod$))\n", args)
+	case extractMemberCapTag(m) > 0:
+		sw.Do("b.model.$.name$ = make($.type|raw$, 0, $.cap$)\n", args)
+	default:
+		sw.Do("b.model.$.name$ = make($.type|raw$, 0, len(b.$.nameMethod$))\n", args)
+	}
+	if args["key"] != nil {
+		sw.Do("for k, v := range b.$.nameMethod$ {\n", args)
+		sw.Do("b.model.$.name$[k] = v.Build()\n", args)
+	} else {
+		sw.Do("for _, v := range b.$.nameMethod$ {\n", args)
+		sw.Do("b.model.$.name$ = append(b.model.$.name$, v.Build())\n", args)
+	}
+	sw.Do("}\n", args)
+}
+
+// listsClone writes the cloning of the list builders of a member.
+func (g *genDeepCopy) listsClone(sw *generator.SnippetWriter, args generator.Args) {
+	sw.Do("if b.$.nameMethod$ != nil {\n", args)
+	sw.Do("clone.$.nameMethod$ = make("+args["lists"].(string)+", len(b.$.nameMethod$))\n", args)
+	sw.Do("for k, v := range b.$.nameMethod$ {\n", args)
+	sw.Do("clone.$.nameMethod$[k] = v.Clone()\n", args)
+	sw.Do("}\n", args)
+	sw.Do("}\n", args)
+}
+
+// namedList returns the named slice type of the member m, or of the pointer
+// it holds, when the package declares it with a list builder of its own, like
+// TestRow, nil otherwise. The builders delegate such members to that list
+// builder, created on first access.
+func (g *genDeepCopy) namedList(m types.Member) *types.Type {
+	t := m.Type
+	if t.Kind == types.Pointer {
+		t = t.Elem
+	}
+	if m.Embedded || t.Kind != types.Alias || t.Name.Package != g.targetPackage || !g.isBuilderList(t) {
+		return nil
+	}
+	return t
+}
+
+// namedListArgs returns the arguments of the snippets of the member m of t
+// delegated to the list builder of the named slice list.
+func (g *genDeepCopy) namedListArgs(t *types.Type, m types.Member, list *types.Type) generator.Args {
+	args := generator.Args{
+		"typeBase":    t,
+		"typeAlias":   m.Type,
+		"list":        list,
+		"name":        m.Name,
+		"nameMethod":  propertyName(m),
+		"setter":      g.methodName(t, m),
+		"valueSetter": "Set" + g.memberName(m),
+		"base":        g.memberName(m),
+		"listBuilder": g.listBuilderOf(list),
+		"newBuilder":  g.newBuilderOf(list),
+		"pointer":     m.Type.Kind == types.Pointer,
+		"sprintf":     sprintfFunc,
+	}
+	if args["valueSetter"] == args["setter"] {
+		args["valueSetter"] = args["valueSetter"].(string) + "Value"
+	}
+	return args
+}
+
+// namedListMethods writes the methods of the member m of t delegated to the
+// list builder of the named slice list: without --copy-on-write the method
+// returning the list builder, and the Set<Member> setter replacing it by a
+// list builder of the elements of input.
+func (g *genDeepCopy) namedListMethods(sw *generator.SnippetWriter, t *types.Type, m types.Member, list *types.Type) {
+	args := g.namedListArgs(t, m, list)
+	if !g.customArgs.CopyOnWrite && !g.handWritten(t, args["setter"].(string)) {
+		writeDoc(sw, docLines(m.CommentLines))
+		sw.Do("func (b *$.typeBase|raw$Builder) $.setter$() *$.listBuilder|raw$ {\n", args)
+		g.clearOneof(sw, t, m)
+		sw.Do("if b.$.nameMethod$ == nil {\n", args)
+		sw.Do("b.$.nameMethod$ = $.newBuilder|raw$()\n", args)
+		sw.Do("}\n", args)
+		sw.Do("return b.$.nameMethod$\n", args)
+		sw.Do("}\n\n", args)
+	}
+	if g.handWritten(t, args["valueSetter"].(string)) {
+		return
+	}
+	sw.Do("// $.valueSetter$ replaces the list builder of $.name$ by one of the\n", args)
+	if args["pointer"].(bool) {
+		sw.Do("// elements of the list input points to, nil if input is nil.\n", args)
+	} else {
+		sw.Do("// elements of input.\n", args)
+	}
+	sw.Do("func (b *$.typeBase|raw$Builder) $.valueSetter$(input $.typeAlias|raw$) *$.typeBase|raw$Builder {\n", args)
+	g.copyOnWrite(sw)
+	g.clearOneof(sw, t, m)
+	args["input"] = "input"
+	if args["pointer"].(bool) {
+		args["input"] = "*input"
+		sw.Do("b.$.nameMethod$ = nil\n", args)
+		sw.Do("if input == nil {\n", args)
+		sw.Do("return b\n", args)
+		sw.Do("}\n", args)
+	}
+	sw.Do("b.$.nameMethod$ = $.newBuilder|raw$()\n", args)
+	sw.Do("b.$.nameMethod$.fromModel($.input$)\n", args)
+	sw.Do("return b\n", args)
+	sw.Do("}\n\n", args)
+}
+
+// namedListBuild writes the building of the list builder of a member into
+// the model, when it was created.
+func (g *genDeepCopy) namedListBuild(sw *generator.SnippetWriter, args generator.Args) {
+	sw.Do("if b.$.nameMethod$ != nil {\n", args)
+	if args["pointer"].(bool) {
+		sw.Do("$.nameMethod$ := b.$.nameMethod$.Build()\n", args)
+		sw.Do("b.model.$.name$ = &$.nameMethod$\n", args)
+	} else {
+		sw.Do("b.model.$.name$ = b.$.nameMethod$.Build()\n", args)
+	}
+	sw.Do("}\n", args)
+}
+
+// namedListFromModel writes the replacement of the list builder of a member
+// by one of the elements of its slice in model, nil when it is nil.
+func (g *genDeepCopy) namedListFromModel(sw *generator.SnippetWriter, args generator.Args) {
+	sw.Do("b.$.nameMethod$ = nil\n", args)
+	sw.Do("if model.$.name$ != nil {\n", args)
+	sw.Do("b.$.nameMethod$ = $.newBuilder|raw$()\n", args)
+	if args["pointer"].(bool) {
+		sw.Do("b.$.nameMethod$.fromModel(*model.$.name$)\n", args)
+	} else {
+		sw.Do("b.$.nameMethod$.fromModel(model.$.name$)\n", args)
+	}
+	sw.Do("}\n", args)
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// The members holding slices of maps of structs with builders, like
+// []map[string]TestB, are held by the builders as slices of maps of nested
+// builders, one map per element, built into the slice of the model by Build.
+
+// builderMapSlice returns the map type of the elements of the member m when
+// it is a slice, not behind a pointer, of maps of structs with builders, nil
+// otherwise.
+func (g *genDeepCopy) builderMapSlice(m types.Member) *types.Type {
+	u := underlyingType(m.Type)
+	if u.Kind != types.Slice {
+		return nil
+	}
+	elem := underlyingType(u.Elem)
+	if elem.Kind != types.Map || !g.hasBuilder(elem.Elem) {
+		return nil
+	}
+	return elem
+}
+
+// mapSliceArgs returns the arguments of the snippets of the member m holding
+// a slice of the maps mapType of nested builders.
+func (g *genDeepCopy) mapSliceArgs(t *types.Type, m types.Member, mapType *types.Type) generator.Args {
+	return generator.Args{
+		"typeBase":   t,
+		"type":       underlyingType(m.Type),
+		"typeAlias":  m.Type,
+		"map":        underlyingType(m.Type).Elem,
+		"mapKey":     mapType.Key,
+		"name":       m.Name,
+		"nameMethod": propertyName(m),
+		"setter":     g.methodName(t, m),
+		"base":       g.memberName(m),
+		"builder":    builderOf(builderType(mapType.Elem)),
+		"newBuilder": g.constructorOf(builderType(mapType.Elem)),
+		"build":      g.buildName(builderType(mapType.Elem)),
+		"cap":        extractMemberCapTag(m),
+	}
+}
+
+// mapSliceMethods writes the setter of the member m of t, replacing the maps
+// of nested builders by builders of the values of input, and, without
+// --copy-on-write, the Add<Member> method appending a map of new builders.
+func (g *genDeepCopy) mapSliceMethods(sw *generator.SnippetWriter, t *types.Type, m types.Member, mapType *types.Type) {
+	args := g.mapSliceArgs(t, m, mapType)
+	if !g.handWritten(t, args["setter"].(string)) {
+		writeDoc(sw, docLines(m.CommentLines))
+		sw.Do("func (b *$.typeBase|raw$Builder) $.setter$(input $.typeAlias|raw$) *$.typeBase|raw$Builder {\n", args)
+		g.copyOnWrite(sw)
+		g.clearOneof(sw, t, m)
+		g.mapSliceFromModel(sw, mapType, args, "input")
+		sw.Do("return b\n", args)
+		sw.Do("}\n\n", args)
+	}
+	g.conditionalSetter(sw, t, args)
+	if g.customArgs.CopyOnWrite || g.handWritten(t, "Add"+args["base"].(string)) {
+		return
+	}
+	sw.Do("// Add$.base$ appends a map holding a new builder per key, and returns the\n", args)
+	sw.Do("// builders in the order of the keys.\n", args)
+	sw.Do("func (b *$.typeBase|raw$Builder) Add$.base$(keys ...$.mapKey|raw$) []*$.builder|raw$ {\n", args)
+	g.clearOneof(sw, t, m)
+	sw.Do("builders := make(map[$.mapKey|raw$]*$.builder|raw$, len(keys))\n", args)
+	sw.Do("result := make([]*$.builder|raw$, 0, len(keys))\n", args)
+	sw.Do("for _, key := range keys {\n", args)
+	sw.Do("builder := $.newBuilder|raw$()\n", args)
+	sw.Do("builders[key] = builder\n", args)
+	sw.Do("result = append(result, builder)\n", args)
+	sw.Do("}\n", args)
+	sw.Do("b.$.nameMethod$ = append(b.$.nameMethod$, builders)\n", args)
+	sw.Do("return result\n", args)
+	sw.Do("}\n\n", args)
+}
+
+// mapSliceFromModel writes the replacement of the maps of nested builders of
+// a member by builders of the values of the slice of maps source.
+func (g *genDeepCopy) mapSliceFromModel(sw *generator.SnippetWriter, mapType *types.Type, args generator.Args, source string) {
+	args["source"] = source
+	sw.Do("b.$.nameMethod$ = make([]map[$.mapKey|raw$]*$.builder|raw$, 0, len($.source$))\n", args)
+	sw.Do("for _, entries := range $.source$ {\n", args)
+	sw.Do("builders := make(map[$.mapKey|raw$]*$.builder|raw$, len(entries))\n", args)
+	sw.Do("for k, v := range entries {\n", args)
+	if mapType.Elem.Kind == types.Pointer {
+		sw.Do("if v == nil {\n", args)
+		sw.Do("continue\n", args)
+		sw.Do("}\n", args)
+		g.builderFromModel(sw, "builder", true, "*v", builderType(mapType.Elem))
+	} else {
+		g.builderFromModel(sw, "builder", true, "v", builderType(mapType.Elem))
+	}
+	sw.Do("builders[k] = builder\n", args)
+	sw.Do("}\n", args)
+	sw.Do("b.$.nameMethod$ = append(b.$.nameMethod$, builders)\n", args)
+	sw.Do("}\n", args)
+}
+
+// mapSliceBuild writes the building of the maps of nested builders of a
+// member into the slice of maps of the model.
+func (g *genDeepCopy) mapSliceBuild(sw *generator.SnippetWriter, m types.Member, mapType *types.Type, args generator.Args) {
+	if extractMemberCapTag(m) > 0 {
+		sw.Do("b.model.$.name$ = make($.type|raw$, 0, $.cap$)\n", args)
+	} else {
+		sw.Do("b.model.$.name$ = make($.type|raw$, 0, len(b.$.nameMethod$))\n", args)
+	}
+	sw.Do("for _, builders := range b.$.nameMethod$ {\n", args)
+	sw.Do("entries := make($.map|raw$, len(builders))\n", args)
+	sw.Do("for k, v := range builders {\n", args)
+	if mapType.Elem.Kind == types.Pointer {
+		sw.Do("vv := v.$.build$()\n", args)
+		sw.Do("entries[k] = &vv\n", args)
+	} else {
+		sw.Do("entries[k] = v.$.build$()\n", args)
+	}
+	sw.Do("}\n", args)
+	sw.Do("b.model.$.name$ = append(b.model.$.name$, entries)\n", args)
+	sw.Do("}\n", args)
+}
+
+// mapSliceClone writes the cloning of the maps of nested builders of a
+// member.
+func (g *genDeepCopy) mapSliceClone(sw *generator.SnippetWriter, args generator.Args) {
+	sw.Do("if b.$.nameMethod$ != nil {\n", args)
+	sw.Do("clone.$.nameMethod$ = make([]map[$.mapKey|raw$]*$.builder|raw$, len(b.$.nameMethod$))\n", args)
+	sw.Do("for i, builders := range b.$.nameMethod$ {\n", args)
+	sw.Do("clone.$.nameMethod$[i] = make(map[$.mapKey|raw$]*$.builder|raw$, len(builders))\n", args)
+	sw.Do("for k, v := range builders {\n", args)
+	sw.Do("clone.$.nameMethod$[i][k] = v.Clone()\n", args)
+	sw.Do("}\n", args)
+	sw.Do("}\n", args)
+	sw.Do("}\n", args)
+}
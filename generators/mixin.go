@@ -0,0 +1,147 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"go/build"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/types"
+)
+
+// A +builder-gen:mixin=<pkg.Type> tag on a struct makes its builder embed the
+// builder of its member of type pkg.Type, or Type for the structs of the same
+// package, like the builders of the embedded structs: the setters of the
+// mixed in builder are forwarded, and Build sets the member to the model it
+// builds. The builders of the other packages mixed in export their
+// New<T>BuilderFromModel.
+
+// mixinTargets returns the structs named by the +builder-gen:mixin tags of the
+// structs of the inputs getting builders, as types.Name.String(), declared in
+// other packages of the inputs and created without arguments. Each is listed
+// under its import path and, when the inputs name its package by directory,
+// under that name too.
+func mixinTargets(universe types.Universe, inputs sets.String, generates func(*types.Type) bool) sets.String {
+	result := sets.NewString()
+	for _, path := range inputs.List() {
+		pkg := universe[path]
+		if pkg == nil {
+			continue
+		}
+		for _, t := range pkg.Types {
+			if t.Kind != types.Struct || !generates(t) {
+				continue
+			}
+			for _, name := range extractTag(t, mixinTagName) {
+				target := types.ParseFullyQualifiedName(name)
+				targetPkg := universe[target.Package]
+				if target.Package == "" || targetPkg == nil {
+					continue
+				}
+				st := targetPkg.Types[target.Name]
+				if st == nil || st.Kind != types.Struct || !generates(st) || hasRequiredMembers(st) {
+					continue
+				}
+				// The packages imported by the inputs have no source path, but
+				// may be inputs named by their directory.
+				dir := targetPkg.SourcePath
+				if dir == "" {
+					if found, err := build.Import(target.Package, ".", build.FindOnly); err == nil {
+						dir = found.Dir
+					}
+				}
+				for _, input := range inputs.List() {
+					if input == target.Package {
+						result.Insert(name)
+					} else if universe[input] != nil && universe[input].SourcePath == dir {
+						result.Insert(name, types.Name{Package: input, Name: target.Name}.String())
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+// mixin reports whether the member m of t embeds the builder of its struct,
+// named by a +builder-gen:mixin tag of t. Only the first member of the type
+// does, the builders embedding one builder per type.
+func (g *genDeepCopy) mixin(t *types.Type, m types.Member) bool {
+	if m.Embedded {
+		return false
+	}
+	target := builderType(m.Type)
+	if !g.hasBuilder(target) && !g.mixins.Has(target.Name.String()) {
+		return false
+	}
+	if first, ok := mixinMember(t, target.Name.String()); !ok || first.Name != m.Name {
+		return false
+	}
+	for _, name := range extractTag(t, mixinTagName) {
+		if mixinNames(t, target, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// mixinNames reports whether the value name of a +builder-gen:mixin tag of t
+// names the struct target, by its package and name or, in the package of t,
+// by its name.
+func mixinNames(t, target *types.Type, name string) bool {
+	return name == target.Name.String() || (target.Name.Package == t.Name.Package && name == target.Name.Name)
+}
+
+// mixinMember returns the first member of t, not embedded, holding the
+// struct named by the value name of a +builder-gen:mixin tag.
+func mixinMember(t *types.Type, name string) (types.Member, bool) {
+	for _, m := range builderMembers(t) {
+		if mt := builderType(m.Type); !m.Embedded && mt.Kind == types.Struct && mixinNames(t, mt, name) {
+			return m, true
+		}
+	}
+	return types.Member{}, false
+}
+
+// embedsBuilder reports whether the builder of t embeds the builder of the
+// struct umt of the member m, embedded or mixed in.
+func (g *genDeepCopy) embedsBuilder(t *types.Type, m types.Member, umt *types.Type) bool {
+	return (m.Embedded || g.mixin(t, m)) && g.memberBuilder(t, m, umt)
+}
+
+// embeddedField returns the name of the field of the builders embedding the
+// builder of m.
+func embeddedField(m types.Member) string {
+	return builderOf(builderType(m.Type)).Name.Name
+}
+
+// checkMixins warns of the +builder-gen:mixin tags of t naming no member of
+// t, or a struct whose builder is not generated.
+func (g *genDeepCopy) checkMixins(t *types.Type) {
+	for _, name := range extractTag(t, mixinTagName) {
+		m, ok := mixinMember(t, name)
+		switch {
+		case !ok:
+			g.warnings = append(g.warnings, Warning{
+				Package: t.Name.Package,
+				Type:    t.Name.Name,
+				Member:  name,
+				Reason:  "mixin naming no member",
+			})
+		case !g.mixin(t, m):
+			g.warn(t, m, "mixin "+name+" has no generated builder, set as a member")
+		}
+	}
+}
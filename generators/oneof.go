@@ -0,0 +1,207 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// The +builder-gen:oneof=A,B,C tags of a type list mutually exclusive
+// members: the setters of each member clear the others, and BuildSafe fails
+// when more than one of them is set, a member being set when it is not nil,
+// empty or zero.
+
+// extractOneofTags returns the members of each +builder-gen:oneof tag of t.
+func extractOneofTags(t *types.Type) [][]string {
+	comments := append(append([]string{}, t.SecondClosestCommentLines...), t.CommentLines...)
+	var groups [][]string
+	for _, value := range types.ExtractCommentTags("+", comments)[oneofTagName] {
+		var names []string
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		groups = append(groups, names)
+	}
+	return groups
+}
+
+// checkOneofTags returns an error if a +builder-gen:oneof tag of t lists
+// less than two members, a member unknown to the builder, of a type without
+// an empty value, or already listed by another tag.
+func checkOneofTags(t *types.Type) error {
+	members := map[string]types.Member{}
+	for _, m := range builderMembers(t) {
+		members[m.Name] = m
+	}
+	listed := map[string]bool{}
+	for _, names := range extractOneofTags(t) {
+		if len(names) < 2 {
+			return fmt.Errorf("%v: the %s tag must list at least two members", t, oneofTagName)
+		}
+		for _, name := range names {
+			m, ok := members[name]
+			if !ok {
+				return fmt.Errorf("%v: the %s tag lists %q, which is not a member of the builder", t, oneofTagName, name)
+			}
+			if listed[name] {
+				return fmt.Errorf("%v: the member %s is listed by several %s tags", t, name, oneofTagName)
+			}
+			listed[name] = true
+			if m.Embedded {
+				return fmt.Errorf("%v: the %s tag lists the embedded member %s", t, oneofTagName, name)
+			}
+			if oneofSetCondition(m) == "" {
+				return fmt.Errorf("%v.%s: the %s tag only applies to pointers, slices, maps, interfaces and builtin types", t, name, oneofTagName)
+			}
+		}
+	}
+	return nil
+}
+
+// oneofOthers returns the members of t sharing a +builder-gen:oneof tag with
+// m, which its setters clear.
+func oneofOthers(t *types.Type, m types.Member) []types.Member {
+	groups := extractOneofTags(t)
+	if len(groups) == 0 {
+		return nil
+	}
+	members := map[string]types.Member{}
+	for _, bm := range builderMembers(t) {
+		members[bm.Name] = bm
+	}
+	var result []types.Member
+	for _, names := range groups {
+		found := false
+		for _, name := range names {
+			found = found || name == m.Name
+		}
+		if !found {
+			continue
+		}
+		for _, name := range names {
+			if other, ok := members[name]; ok && name != m.Name {
+				result = append(result, other)
+			}
+		}
+	}
+	return result
+}
+
+// oneofSetCondition returns the condition true when the member m of model is
+// set, or an empty string if its type has no empty value.
+func oneofSetCondition(m types.Member) string {
+	value := "model." + m.Name
+	u := underlyingType(m.Type)
+	switch u.Kind {
+	case types.Pointer, types.Interface:
+		return value + " != nil"
+	case types.Slice, types.Map:
+		return "len(" + value + ") != 0"
+	case types.Builtin:
+		switch u.Name.Name {
+		case "string":
+			return value + ` != ""`
+		case "bool":
+			return value
+		case "error":
+			return value + " != nil"
+		}
+		return value + " != 0"
+	}
+	return ""
+}
+
+// oneofZero returns the empty value of the member m.
+func oneofZero(m types.Member) string {
+	u := underlyingType(m.Type)
+	if u.Kind == types.Builtin {
+		switch u.Name.Name {
+		case "string":
+			return `""`
+		case "bool":
+			return "false"
+		case "error":
+			return "nil"
+		}
+		return "0"
+	}
+	return "nil"
+}
+
+// clearOneof writes, in the setters of the member m of t, the statements
+// clearing the members sharing a +builder-gen:oneof tag with m, and their
+// nested builders.
+func (g *genDeepCopy) clearOneof(sw *generator.SnippetWriter, t *types.Type, m types.Member) {
+	for _, other := range oneofOthers(t, m) {
+		args := generator.Args{
+			"name":       other.Name,
+			"nameMethod": propertyName(other),
+			"zero":       oneofZero(other),
+		}
+		sw.Do("b.model.$.name$ = $.zero$\n", args)
+		umt := underlyingType(other.Type)
+		if umt.Kind == types.Pointer {
+			umt = umt.Elem
+		}
+		switch {
+		case g.builderMapSlice(other) != nil, g.builderLists(other) != nil, g.namedList(other) != nil:
+			sw.Do("b.$.nameMethod$ = nil\n", args)
+		case (umt.Kind == types.Slice || umt.Kind == types.Map) && g.hasBuilder(umt.Elem):
+			sw.Do("b.$.nameMethod$ = nil\n", args)
+			if g.orderedMap(other) {
+				sw.Do("b.$.nameMethod$Keys = nil\n", args)
+			}
+		case umt.Kind == types.Struct && g.memberBuilder(t, other, umt):
+			sw.Do("b.$.nameMethod$ = nil\n", args)
+		case umt.Kind == types.Interface:
+			g.clearImplementations(sw, t, other)
+		}
+	}
+}
+
+// validateOneofs writes the checks of BuildSafe appending an error to errs
+// for each +builder-gen:oneof tag of t with more than one member set.
+func (g *genDeepCopy) validateOneofs(sw *generator.SnippetWriter, t *types.Type) {
+	members := map[string]types.Member{}
+	for _, m := range builderMembers(t) {
+		members[m.Name] = m
+	}
+	for i, names := range extractOneofTags(t) {
+		args := generator.Args{
+			"assign":    ":=",
+			"errorsNew": errorsNewFunc,
+			"message":   strconv.Quote(strings.Join(names, ", ") + ": at most one of them must be set"),
+		}
+		if i > 0 {
+			args["assign"] = "="
+		}
+		sw.Do("set $.assign$ 0\n", args)
+		for _, name := range names {
+			sw.Do("if $.$ {\n", oneofSetCondition(members[name]))
+			sw.Do("set++\n", nil)
+			sw.Do("}\n", nil)
+		}
+		sw.Do("if set > 1 {\n", args)
+		sw.Do("errs = append(errs, $.errorsNew|raw$($.message$))\n", args)
+		sw.Do("}\n", args)
+	}
+}
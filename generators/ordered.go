@@ -0,0 +1,150 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// With --ordered-maps, the builders keep the keys of their maps of nested
+// builders in a slice next to each map, in the order Add<Member> added them,
+// so Build builds the entries in that order and <Member>Keys returns them.
+// The maps set whole, by the setters or from a model, have their keys
+// sorted.
+
+var (
+	sortSliceFunc = &types.Type{Name: types.Name{Package: "sort", Name: "Slice"}}
+	sprintFunc    = &types.Type{Name: types.Name{Package: "fmt", Name: "Sprint"}}
+)
+
+// orderedMap reports whether the keys of the member m, holding a map of
+// nested builders, are kept in order.
+func (g *genDeepCopy) orderedMap(m types.Member) bool {
+	if !g.customArgs.OrderedMaps {
+		return false
+	}
+	umt := underlyingType(m.Type)
+	if umt.Kind == types.Pointer {
+		umt = umt.Elem
+	}
+	return umt.Kind == types.Map && g.hasBuilder(umt.Elem)
+}
+
+// orderedKeysArgs adds to args the builder field holding the keys of the
+// member m and their type.
+func orderedKeysArgs(m types.Member, args generator.Args) generator.Args {
+	umt := underlyingType(m.Type)
+	if umt.Kind == types.Pointer {
+		umt = umt.Elem
+	}
+	args["keys"] = propertyName(m) + "Keys"
+	args["keyType"] = umt.Key
+	args["sort"] = sortSliceFunc
+	args["sprint"] = sprintFunc
+	return args
+}
+
+// orderedMapAdd writes, before the nested builder of key is stored in the
+// map of the member m, the recording of key when it is new.
+func (g *genDeepCopy) orderedMapAdd(sw *generator.SnippetWriter, m types.Member, key string) {
+	if !g.orderedMap(m) {
+		return
+	}
+	args := orderedKeysArgs(m, generator.Args{"nameMethod": propertyName(m), "key": key})
+	args["slice"] = g.appendable("b." + args["keys"].(string))
+	sw.Do("if _, ok := b.$.nameMethod$[$.key$]; !ok {\n", args)
+	sw.Do("b.$.keys$ = append($.slice$, $.key$)\n", args)
+	sw.Do("}\n", args)
+}
+
+// orderedMapRemove writes, before the nested builder of key is dropped from
+// the map of the member m, the removal of key from the keys, into a new slice
+// which the copies of --copy-on-write do not share.
+func (g *genDeepCopy) orderedMapRemove(sw *generator.SnippetWriter, m types.Member, key string) {
+	if !g.orderedMap(m) {
+		return
+	}
+	args := orderedKeysArgs(m, generator.Args{"nameMethod": propertyName(m), "key": key})
+	sw.Do("keys := make([]$.keyType|raw$, 0, len(b.$.keys$))\n", args)
+	sw.Do("for _, k := range b.$.keys$ {\n", args)
+	sw.Do("if k != $.key$ {\n", args)
+	sw.Do("keys = append(keys, k)\n", args)
+	sw.Do("}\n", args)
+	sw.Do("}\n", args)
+	sw.Do("b.$.keys$ = keys\n", args)
+}
+
+// orderedMapSort writes, after the map of nested builders of the member m is
+// set whole, the sorting of its keys: by their order when they have one, by
+// their formatting otherwise.
+func (g *genDeepCopy) orderedMapSort(sw *generator.SnippetWriter, m types.Member) {
+	if !g.orderedMap(m) {
+		return
+	}
+	args := orderedKeysArgs(m, generator.Args{"nameMethod": propertyName(m)})
+	sw.Do("b.$.keys$ = make([]$.keyType|raw$, 0, len(b.$.nameMethod$))\n", args)
+	sw.Do("for k := range b.$.nameMethod$ {\n", args)
+	sw.Do("b.$.keys$ = append(b.$.keys$, k)\n", args)
+	sw.Do("}\n", args)
+	sw.Do("$.sort|raw$(b.$.keys$, func(i, j int) bool {\n", args)
+	if orderedType(args["keyType"].(*types.Type)) {
+		sw.Do("return b.$.keys$[i] < b.$.keys$[j]\n", args)
+	} else {
+		sw.Do("return $.sprint|raw$(b.$.keys$[i]) < $.sprint|raw$(b.$.keys$[j])\n", args)
+	}
+	sw.Do("})\n", args)
+}
+
+// orderedMapRange writes the head of the loop over the entries k and v of the
+// map of nested builders of the member m of receiver, in the order of its
+// keys when they are kept.
+func (g *genDeepCopy) orderedMapRange(sw *generator.SnippetWriter, m types.Member, receiver string) {
+	args := orderedKeysArgs(m, generator.Args{"nameMethod": propertyName(m), "receiver": receiver})
+	if !g.orderedMap(m) {
+		sw.Do("for k, v := range $.receiver$.$.nameMethod$ {\n", args)
+		return
+	}
+	sw.Do("for _, k := range $.receiver$.$.keys$ {\n", args)
+	sw.Do("v := $.receiver$.$.nameMethod$[k]\n", args)
+}
+
+// orderedMapKeysMethod writes the <Member>Keys method of the member m of t,
+// returning a copy of the keys of its map of nested builders.
+func (g *genDeepCopy) orderedMapKeysMethod(sw *generator.SnippetWriter, t *types.Type, m types.Member) {
+	base := g.memberName(m)
+	if !g.orderedMap(m) || g.handWritten(t, base+"Keys") {
+		return
+	}
+	args := orderedKeysArgs(m, generator.Args{"typeBase": t, "base": base})
+	sw.Do("// $.base$Keys returns the keys of the nested builders of $.base$, in the\n", args)
+	sw.Do("// order they were added.\n", args)
+	sw.Do("func (b *$.typeBase|raw$Builder) $.base$Keys() []$.keyType|raw$ {\n", args)
+	sw.Do("return append([]$.keyType|raw$(nil), b.$.keys$...)\n", args)
+	sw.Do("}\n\n", args)
+}
+
+// orderedType reports whether the values of t can be compared with <.
+func orderedType(t *types.Type) bool {
+	u := underlyingType(t)
+	if u.Kind != types.Builtin {
+		return false
+	}
+	switch u.Name.Name {
+	case "bool", "complex64", "complex128", "error":
+		return false
+	}
+	return true
+}
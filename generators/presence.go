@@ -0,0 +1,112 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+	"k8s.io/klog/v2"
+)
+
+// With --has-methods, the builders get a Has<Member>() bool method per member,
+// reporting whether it was set: the members held by the model when they are
+// not zero, the optional nested builders when they were created and the
+// slices and maps of nested builders when they hold some. The members whose
+// nested builder always exists, like the structs held by value, get none.
+
+// presence returns the template of the expression of Has<Member> for the
+// member m of t, empty when it gets none.
+func (g *genDeepCopy) presence(t *types.Type, m types.Member) string {
+	umt := underlyingType(m.Type)
+	pointer := umt.Kind == types.Pointer
+	if pointer {
+		umt = umt.Elem
+	}
+	handler := g.typeHandler(t, m)
+	if _, ok := handler.(*unionHandler); ok {
+		if !pointer {
+			return ""
+		}
+		return "b.$.nameMethod$ != nil"
+	}
+	switch {
+	case handler != nil:
+	case g.namedList(m) != nil:
+		return "b.$.nameMethod$ != nil"
+	case umt.Kind == types.Slice || umt.Kind == types.Map:
+		if g.hasNestedBuilder(t, m) {
+			return "len(b.$.nameMethod$) != 0"
+		}
+	case umt.Kind == types.Struct:
+		if !g.memberBuilder(t, m, umt) {
+			break
+		}
+		if !pointer {
+			return ""
+		}
+		if g.embedsBuilder(t, m, umt) {
+			return "b.$.embedded$ != nil"
+		}
+		return "b.$.nameMethod$ != nil"
+	case umt.Kind == types.Interface:
+		// The member is also set by the nested builder of an
+		// implementation.
+		presence := "b.model.$.name$ != nil"
+		impls, _ := g.implementations(t, m)
+		for _, impl := range impls {
+			presence += " || b." + impl.field + " != nil"
+		}
+		return presence
+	case umt.IsPrimitive():
+	default:
+		return ""
+	}
+	switch zero := zeroValue(m.Type); zero {
+	case "":
+		return "!$.valueOf|raw$(b.model.$.name$).IsZero()"
+	case "false":
+		return "b.model.$.name$"
+	default:
+		return "b.model.$.name$ != " + zero
+	}
+}
+
+// presenceMethod writes, with --has-methods, the Has<Member> method of the
+// member m of t, unless it would clash with a method of the builder.
+func (g *genDeepCopy) presenceMethod(sw *generator.SnippetWriter, t *types.Type, m types.Member, argsMember generator.Args) {
+	if !g.customArgs.HasMethods {
+		return
+	}
+	name := "Has" + argsMember["base"].(string)
+	if g.handWritten(t, name) || g.reservedMethodName(t, name) {
+		return
+	}
+	for _, other := range builderMembers(t) {
+		if g.methodName(t, other) == name {
+			klog.V(2).Infof("Skipping %sBuilder.%s, it is the setter of %s", t.Name.Name, name, other.Name)
+			return
+		}
+	}
+	presence := g.presence(t, m)
+	if presence == "" {
+		return
+	}
+	argsMember["has"] = name
+	argsMember["valueOf"] = valueOfFunc
+	sw.Do("// $.has$ reports whether $.name$ was set.\n", argsMember)
+	sw.Do("func (b *$.typeBase|raw$Builder) $.has$() bool {\n", argsMember)
+	sw.Do("return "+presence+"\n", argsMember)
+	sw.Do("}\n\n", argsMember)
+}
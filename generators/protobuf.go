@@ -0,0 +1,159 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// The messages generated by protoc-gen-go carry the internal state of the
+// protobuf runtime, which their builders leave alone, and hold their oneof
+// fields in members of unexported interface types, implemented by a wrapper
+// struct per field of the oneof:
+//
+//	type Value struct {
+//		state         protoimpl.MessageState
+//		sizeCache     protoimpl.SizeCache
+//		unknownFields protoimpl.UnknownFields
+//
+//		// Types that are assignable to Kind:
+//		//	*Value_Number
+//		//	*Value_Text
+//		Kind isValue_Kind `protobuf_oneof:"kind"`
+//	}
+//
+//	type Value_Number struct {
+//		Number float64 `protobuf:"fixed64,1,opt,name=number,proto3,oneof"`
+//	}
+//
+//	func (*Value_Number) isValue_Kind() {}
+
+// protobufInternalMembers are the members of the messages of protoc-gen-go
+// holding the state of the protobuf runtime.
+var protobufInternalMembers = map[string]bool{
+	"state":         true,
+	"sizeCache":     true,
+	"unknownFields": true,
+}
+
+// isProtobufMessage reports whether t is a message generated by protoc-gen-go,
+// one of its members carrying a protobuf struct tag.
+func isProtobufMessage(t *types.Type) bool {
+	for _, m := range t.Members {
+		tag := reflect.StructTag(m.Tags)
+		if _, ok := tag.Lookup("protobuf"); ok {
+			return true
+		}
+		if _, ok := tag.Lookup("protobuf_oneof"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isProtobufInternalMember reports whether m is a member of the protobuf
+// message t holding the state of the protobuf runtime, like its size cache,
+// its unknown fields or the XXX_ members of the older versions of
+// protoc-gen-go.
+func isProtobufInternalMember(t *types.Type, m types.Member) bool {
+	if !protobufInternalMembers[m.Name] && !strings.HasPrefix(m.Name, "XXX_") {
+		return false
+	}
+	return isProtobufMessage(t)
+}
+
+// isProtobufOneofWrapper reports whether t is the wrapper struct of a field of
+// a oneof, which has no builder of its own: its field is set by the builder
+// of the message.
+func isProtobufOneofWrapper(t *types.Type) bool {
+	if t.Kind != types.Struct || len(t.Members) != 1 {
+		return false
+	}
+	for _, option := range strings.Split(reflect.StructTag(t.Members[0].Tags).Get("protobuf"), ",") {
+		if option == "oneof" {
+			return true
+		}
+	}
+	return false
+}
+
+// oneofVariants returns the wrapper structs of the fields of the oneof held by
+// the member m of the protobuf message t, sorted by name: the wrappers of the
+// package of t implementing the interface of m.
+func (g *genDeepCopy) oneofVariants(t *types.Type, m types.Member) []*types.Type {
+	if _, ok := reflect.StructTag(m.Tags).Lookup("protobuf_oneof"); !ok {
+		return nil
+	}
+	iface := underlyingType(m.Type)
+	if iface.Kind != types.Interface || len(iface.Methods) == 0 {
+		return nil
+	}
+	pkg := g.universe.Package(t.Name.Package)
+	if pkg == nil {
+		return nil
+	}
+	var result []*types.Type
+	for _, wt := range pkg.Types {
+		if !isProtobufOneofWrapper(wt) {
+			continue
+		}
+		implements := true
+		for name := range iface.Methods {
+			if _, ok := wt.Methods[name]; !ok {
+				implements = false
+				break
+			}
+		}
+		if implements {
+			result = append(result, wt)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name.Name < result[j].Name.Name
+	})
+	return result
+}
+
+// oneofSetters writes, for the member m of the protobuf message t holding a
+// oneof, a setter per field of the oneof, named after the field, storing its
+// wrapper into the member.
+func (g *genDeepCopy) oneofSetters(sw *generator.SnippetWriter, t *types.Type, m types.Member) {
+	for _, wt := range g.oneofVariants(t, m) {
+		field := wt.Members[0]
+		setter := g.methodName(t, field)
+		if g.handWritten(t, setter) {
+			continue
+		}
+		args := generator.Args{
+			"typeBase": t,
+			"wrapper":  wt,
+			"field":    field.Name,
+			"type":     field.Type,
+			"name":     m.Name,
+			"setter":   setter,
+		}
+		sw.Do("// $.setter$ sets $.name$ to the $.field$ field of the oneof.\n", args)
+		sw.Do("func (b *$.typeBase|raw$Builder) $.setter$(input $.type|raw$) *$.typeBase|raw$Builder {\n", args)
+		g.copyOnWrite(sw)
+		sw.Do("b.model.$.name$ = &$.wrapper|raw${$.field$: input}\n", args)
+		sw.Do("return b\n", generator.Args{})
+		sw.Do("}\n\n", generator.Args{})
+	}
+}
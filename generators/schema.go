@@ -0,0 +1,284 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// With --json-schema, the structs getting builders are described by a JSON
+// Schema per package, written next to the builders, from the members the
+// builders set: each struct is a definition of its $defs, with the structs of
+// other packages it refers to.
+
+const (
+	// schemaFileType is the file type of the JSON schemas.
+	schemaFileType = "jsonschema"
+	// schemaFileSuffix is added to the base name of the files of the
+	// builders for the JSON schemas.
+	schemaFileSuffix = ".schema.json"
+	// jsonSchemaDialect is the version of JSON Schema of the schemas.
+	jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	// dateTimeFormat is the format of the times.
+	dateTimeFormat = "date-time"
+)
+
+// Types encoded as strings by their JSON methods.
+var (
+	timeName      = types.Name{Package: "time", Name: "Time"}
+	metaTimeName  = types.Name{Package: typeMetaName.Package, Name: "Time"}
+	metaMicroTime = types.Name{Package: typeMetaName.Package, Name: "MicroTime"}
+	quantityName  = types.Name{Package: "k8s.io/apimachinery/pkg/api/resource", Name: "Quantity"}
+)
+
+// jsonSchema is a JSON Schema, the empty one accepting any value.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	ContentEncoding      string                 `json:"contentEncoding,omitempty"`
+	AnyOf                []*jsonSchema          `json:"anyOf,omitempty"`
+	AllOf                []*jsonSchema          `json:"allOf,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Defs                 map[string]*jsonSchema `json:"$defs,omitempty"`
+}
+
+// genJSONSchema writes the JSON schema of the structs of a package getting
+// builders.
+type genJSONSchema struct {
+	generator.DefaultGen
+	targetPackage string
+	fileName      string
+	// builders is the generator of the builders described, telling which
+	// types get builders.
+	builders *genDeepCopy
+	// defs are the definitions of the structs by name, and referred the
+	// structs referred to without a definition yet.
+	defs     map[string]*jsonSchema
+	referred map[string]*types.Type
+}
+
+func newGenJSONSchema(outputFileBaseName, targetPackage string, builders *genDeepCopy) *genJSONSchema {
+	return &genJSONSchema{
+		DefaultGen: generator.DefaultGen{
+			OptionalName: outputFileBaseName,
+		},
+		targetPackage: targetPackage,
+		fileName:      outputFileBaseName + schemaFileSuffix,
+		builders:      builders,
+		defs:          map[string]*jsonSchema{},
+		referred:      map[string]*types.Type{},
+	}
+}
+
+func (g *genJSONSchema) Filename() string {
+	return g.fileName
+}
+
+func (g *genJSONSchema) FileType() string {
+	return schemaFileType
+}
+
+func (g *genJSONSchema) Filter(c *generator.Context, t *types.Type) bool {
+	return t.Kind == types.Struct && g.builders.customArgs.generates(t)
+}
+
+func (g *genJSONSchema) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	g.defs[g.defName(t)] = g.structSchema(t)
+	return nil
+}
+
+// Finalize writes the schema, once the definitions of the structs of other
+// packages referred to are added.
+func (g *genJSONSchema) Finalize(c *generator.Context, w io.Writer) error {
+	for len(g.referred) > 0 {
+		names := make([]string, 0, len(g.referred))
+		for name := range g.referred {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			t := g.referred[name]
+			delete(g.referred, name)
+			if g.defs[name] == nil {
+				g.defs[name] = g.structSchema(t)
+			}
+		}
+	}
+	data, err := json.MarshalIndent(&jsonSchema{Schema: jsonSchemaDialect, Defs: g.defs}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// defName returns the name of the definition of the struct t, its name for
+// those of the package and its qualified name for the others.
+func (g *genJSONSchema) defName(t *types.Type) string {
+	if t.Name.Package == g.targetPackage {
+		return t.Name.Name
+	}
+	return t.Name.String()
+}
+
+// ref returns the reference to the definition of the struct t.
+func (g *genJSONSchema) ref(t *types.Type) *jsonSchema {
+	name := g.defName(t)
+	if g.defs[name] == nil {
+		g.referred[name] = t
+	}
+	pointer := strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+	return &jsonSchema{Ref: "#/$defs/" + pointer}
+}
+
+// structSchema returns the schema of the objects the struct t is encoded to,
+// with the members set by its builder.
+func (g *genJSONSchema) structSchema(t *types.Type) *jsonSchema {
+	s := &jsonSchema{Type: "object", Description: schemaDescription(t.CommentLines)}
+	for _, m := range builderMembers(t) {
+		name, options, _ := strings.Cut(reflect.StructTag(m.Tags).Get("json"), ",")
+		if name == "-" && options == "" {
+			continue
+		}
+		// The members of the embedded structs are those of the object,
+		// like the inline members of the Kubernetes API types.
+		if st := builderType(m.Type); m.Embedded && name == "" && st.Kind == types.Struct {
+			s.AllOf = append(s.AllOf, g.typeSchema(m.Type))
+			continue
+		}
+		if !m.Embedded && !token.IsExported(m.Name) {
+			continue
+		}
+		if name == "" {
+			name = m.Name
+		}
+		ms := g.typeSchema(m.Type)
+		ms.Description = schemaDescription(m.CommentLines)
+		if s.Properties == nil {
+			s.Properties = map[string]*jsonSchema{}
+		}
+		s.Properties[name] = ms
+		if extractMemberRequiredTag(m) {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// typeSchema returns the schema of the values of type t.
+func (g *genJSONSchema) typeSchema(t *types.Type) *jsonSchema {
+	switch t.Name {
+	case timeName, metaTimeName, metaMicroTime:
+		return &jsonSchema{Type: "string", Format: dateTimeFormat}
+	case intOrStringName, quantityName:
+		return &jsonSchema{AnyOf: []*jsonSchema{{Type: "integer"}, {Type: "string"}}}
+	}
+	if _, ok := t.Methods["MarshalJSON"]; ok {
+		// Their encoding is their own.
+		return &jsonSchema{}
+	}
+	switch t.Kind {
+	case types.Alias:
+		return g.typeSchema(t.Underlying)
+	case types.Pointer:
+		return g.typeSchema(t.Elem)
+	case types.Builtin:
+		return builtinSchema(t)
+	case types.Slice, types.Array:
+		if elem := underlyingType(t.Elem); t.Kind == types.Slice && elem.Kind == types.Builtin && (elem.Name.Name == "byte" || elem.Name.Name == "uint8") {
+			return &jsonSchema{Type: "string", ContentEncoding: "base64"}
+		}
+		return &jsonSchema{Type: "array", Items: g.typeSchema(t.Elem)}
+	case types.Map:
+		return &jsonSchema{Type: "object", AdditionalProperties: g.typeSchema(t.Elem)}
+	case types.Struct:
+		if t.Name.Package == "" || inlineParent(t) != nil {
+			return g.structSchema(t)
+		}
+		return g.ref(t)
+	}
+	return &jsonSchema{}
+}
+
+// builtinSchema returns the schema of the values of the builtin type t.
+func builtinSchema(t *types.Type) *jsonSchema {
+	switch name := t.Name.Name; {
+	case name == "string":
+		return &jsonSchema{Type: "string"}
+	case name == "bool":
+		return &jsonSchema{Type: "boolean"}
+	case strings.HasPrefix(name, "int"), strings.HasPrefix(name, "uint"), name == "byte", name == "rune":
+		return &jsonSchema{Type: "integer"}
+	case strings.HasPrefix(name, "float"):
+		return &jsonSchema{Type: "number"}
+	}
+	return &jsonSchema{}
+}
+
+// schemaDescription returns the comment lines as a description, without the
+// comment tags.
+func schemaDescription(lines []string) string {
+	var description []string
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "+") {
+			description = append(description, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(description, "\n"))
+}
+
+// contentWriter is implemented by the file types of the output modes,
+// writing the rendered content of the files.
+type contentWriter interface {
+	writeContent(pathname string, content []byte) error
+}
+
+// schemaFile is the file type of the JSON schemas, written as they are by the
+// file type of the Go files.
+type schemaFile struct {
+	writer contentWriter
+}
+
+func (ft *schemaFile) AssembleFile(f *generator.File, pathname string) error {
+	return ft.writer.writeContent(pathname, f.Body.Bytes())
+}
+
+func (ft *schemaFile) VerifyFile(f *generator.File, pathname string) error {
+	existing, err := os.ReadFile(pathname)
+	if err != nil {
+		return fmt.Errorf("unable to read file %q for verification: %v", pathname, err)
+	}
+	if !bytes.Equal(existing, f.Body.Bytes()) {
+		return fmt.Errorf("output for %q differs from the generated schema", pathname)
+	}
+	return nil
+}
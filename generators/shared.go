@@ -0,0 +1,99 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+
+	"k8s.io/gengo/args"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/parser"
+	"k8s.io/klog/v2"
+)
+
+// SharedGenerator is another gengo generator, like deepcopy-gen or
+// defaulter-gen, run by Execute on the packages parsed for the builders
+// instead of parsing them again.
+type SharedGenerator struct {
+	// Name identifies the generator in the logs and the errors.
+	Name string
+	// Arguments are the arguments of the generator, its custom arguments
+	// included. The generator runs on the input packages of the builders,
+	// its own input directories are ignored.
+	Arguments *args.GeneratorArgs
+	// NameSystems and DefaultNameSystem are the name systems of the
+	// generator, and the one ordering its types.
+	NameSystems       namer.NameSystems
+	DefaultNameSystem string
+	// Packages returns the packages the generator writes, like the function
+	// given to args.GeneratorArgs.Execute.
+	Packages func(*generator.Context, *args.GeneratorArgs) generator.Packages
+}
+
+// checkSharedGenerators returns an error if a shared generator lacks its
+// arguments or its packages, or writes files named like those of the
+// builders or of another shared generator.
+func checkSharedGenerators(arguments *args.GeneratorArgs, customArgs *CustomArgs, shared []SharedGenerator) error {
+	writers := map[string]string{
+		arguments.OutputFileBaseName: "the builders",
+		smokeTestFileBaseName:        "the smoke tests",
+	}
+	for _, group := range customArgs.InputGroups {
+		if group.OutputFileBaseName != "" {
+			writers[group.OutputFileBaseName] = "the builders"
+		}
+	}
+	for i, gen := range shared {
+		if gen.Name == "" {
+			return fmt.Errorf("the shared generator %d has no name", i)
+		}
+		if gen.Arguments == nil || gen.Packages == nil {
+			return fmt.Errorf("the shared generator %s needs its arguments and its packages", gen.Name)
+		}
+		name := gen.Arguments.OutputFileBaseName
+		if writer, ok := writers[name]; ok {
+			return fmt.Errorf("the shared generator %s writes %s.go like %s", gen.Name, name, writer)
+		}
+		writers[name] = "the shared generator " + gen.Name
+	}
+	return nil
+}
+
+// executeShared runs the shared generators on the packages of b, parsed for
+// the builders. Each gets a context of its own name systems, the type-checked
+// packages of b only being walked again into its universe. In dry runs, or
+// with --stdout, they write their files with the file type of the builders.
+func executeShared(b *parser.Builder, arguments *args.GeneratorArgs, first *generator.Context, shared []SharedGenerator) error {
+	for _, gen := range shared {
+		klog.V(2).Infof("Running the shared generator %s", gen.Name)
+		c, err := generator.NewContext(b, gen.NameSystems, gen.DefaultNameSystem)
+		if err != nil {
+			return fmt.Errorf("Failed making a context for %s: %v", gen.Name, err)
+		}
+		genArgs := *gen.Arguments
+		genArgs.InputDirs = arguments.InputDirs
+		c.TrimPathPrefix = genArgs.TrimPathPrefix
+		c.Verify = genArgs.VerifyOnly
+		switch first.FileTypes[generator.GolangFileType].(type) {
+		case *dryRunFile, *stdoutFile:
+			c.FileTypes[generator.GolangFileType] = first.FileTypes[generator.GolangFileType]
+		}
+		if err := c.ExecutePackages(genArgs.OutputBase, gen.Packages(c, &genArgs)); err != nil {
+			return fmt.Errorf("Failed executing %s: %v", gen.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"io"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// smokeTestFileBaseName is the name of the files of the smoke tests, without
+// the ".go" extension.
+const smokeTestFileBaseName = "zz_generated_builder_smoke_test"
+
+// testingT is the parameter of the smoke test.
+var testingT = &types.Type{Name: types.Name{Package: "testing", Name: "T"}}
+
+// genSmokeTest produces, with --smoke-tests, a test creating every builder of
+// the package, calling one method per member and building the model.
+type genSmokeTest struct {
+	generator.DefaultGen
+	targetPackage string
+	imports       namer.ImportTracker
+	// builders is the generator of the builders tested, telling which
+	// methods they have.
+	builders *genDeepCopy
+}
+
+func newGenSmokeTest(targetPackage string, builders *genDeepCopy) *genSmokeTest {
+	return &genSmokeTest{
+		DefaultGen: generator.DefaultGen{
+			OptionalName: smokeTestFileBaseName,
+		},
+		targetPackage: targetPackage,
+		imports:       generator.NewImportTracker(),
+		builders:      builders,
+	}
+}
+
+func (g *genSmokeTest) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": g.builders.customArgs.rawNamer(g.targetPackage, g.imports),
+	}
+}
+
+func (g *genSmokeTest) Filter(c *generator.Context, t *types.Type) bool {
+	return g.builders.customArgs.generates(t)
+}
+
+func (g *genSmokeTest) Imports(c *generator.Context) []string {
+	return g.imports.ImportLines()
+}
+
+func (g *genSmokeTest) Init(c *generator.Context, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	sw.Do("// TestGeneratedBuildersSmoke creates each builder, calls one of its methods\n", nil)
+	sw.Do("// per member and builds the model.\n", nil)
+	sw.Do("func TestGeneratedBuildersSmoke(t *$.|raw$) {\n", testingT)
+	return sw.Error()
+}
+
+func (g *genSmokeTest) Finalize(c *generator.Context, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	sw.Do("}\n", nil)
+	return sw.Error()
+}
+
+func (g *genSmokeTest) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	args := generator.Args{
+		"type":       t,
+		"newBuilder": g.builders.constructorOf(t),
+		"testingT":   testingT,
+		"build":      g.builders.buildName(t),
+	}
+	sw.Do("t.Run(\"$.type|raw$\", func(t *$.testingT|raw$) {\n", args)
+	sw.Do("b := $.newBuilder|raw$()\n", args)
+	if t.Kind == types.Struct {
+		for _, m := range builderMembers(t) {
+			g.exercise(sw, t, m)
+		}
+	}
+	sw.Do("_ = b.$.build$()\n", args)
+	sw.Do("})\n", args)
+	return sw.Error()
+}
+
+// exercise writes the call of the builder method of m, mirroring the branches
+// of genDeepCopy.structMethods. The members without a generated method are
+// skipped.
+func (g *genSmokeTest) exercise(sw *generator.SnippetWriter, t *types.Type, m types.Member) {
+	b := g.builders
+	mt := m.Type
+	umt := underlyingType(mt)
+	if umt.Kind == types.Pointer {
+		umt = umt.Elem
+	}
+	setter, base := b.methodName(t, m), b.memberName(m)
+	args := generator.Args{
+		"setter":  setter,
+		"base":    base,
+		"type":    mt,
+		"zero":    zeroValue(mt),
+		"builder": builderOf(builderType(umt)),
+	}
+	if umt.Kind == types.Slice || umt.Kind == types.Map {
+		args["builder"] = builderOf(builderType(umt.Elem))
+	}
+	// update is the argument of the methods of the nested builders, which
+	// are set with update functions with --copy-on-write.
+	update, keyUpdate := "", ""
+	if b.customArgs.CopyOnWrite {
+		update = "func(nested *$.builder|raw$) *$.builder|raw$ { return nested }"
+		keyUpdate = ", " + update
+	}
+
+	call := func(name, format string) {
+		if !b.declared.Has(t.Name.Name + "Builder." + name) {
+			sw.Do(format, args)
+		}
+	}
+	if handler := b.typeHandler(t, m); handler != nil {
+		name, format := handler.smokeCall(b, t, m)
+		call(name, format)
+		return
+	}
+	switch {
+	case umt.Kind == types.Unsupported:
+	case umt.IsPrimitive():
+		call(setter, "b.$.setter$($.zero$)\n")
+	case b.builderMapSlice(m) != nil && !b.customArgs.CopyOnWrite:
+		mapType := b.builderMapSlice(m)
+		args["key"], args["keyType"] = zeroValue(mapType.Key), mapType.Key
+		if args["key"] == "" {
+			call("Add"+base, "b.Add$.base$($.keyType|raw${})\n")
+		} else {
+			call("Add"+base, "b.Add$.base$($.key$)\n")
+		}
+	case b.builderLists(m) != nil && !b.customArgs.CopyOnWrite && umt.Kind == types.Map:
+		args["key"], args["keyType"] = zeroValue(umt.Key), umt.Key
+		if args["key"] == "" {
+			call("Add"+base, "b.Add$.base$($.keyType|raw${}).Add()\n")
+		} else {
+			call("Add"+base, "b.Add$.base$($.key$).Add()\n")
+		}
+	case b.builderLists(m) != nil && !b.customArgs.CopyOnWrite:
+		call("Add"+base, "b.Add$.base$().Add()\n")
+	case b.namedList(m) != nil && !b.customArgs.CopyOnWrite:
+		call(setter, "b.$.setter$().Add()\n")
+	case b.namedList(m) != nil:
+		args["valueSetter"] = b.namedListArgs(t, m, b.namedList(m))["valueSetter"]
+		call(args["valueSetter"].(string), "b.$.valueSetter$(nil)\n")
+	case umt.Kind == types.Slice && b.hasBuilder(umt.Elem):
+		call("Add"+base, "b.Add$.base$("+update+")\n")
+	case umt.Kind == types.Map && b.hasBuilder(umt.Elem):
+		args["key"], args["keyType"] = zeroValue(umt.Key), umt.Key
+		if args["key"] == "" {
+			call("Add"+base, "b.Add$.base$($.keyType|raw${}"+keyUpdate+")\n")
+		} else {
+			call("Add"+base, "b.Add$.base$($.key$"+keyUpdate+")\n")
+		}
+	case umt.Kind == types.Slice || umt.Kind == types.Map || umt.Kind == types.Interface:
+		call(setter, "b.$.setter$(nil)\n")
+	case umt.Kind == types.Struct && b.embedsBuilder(t, m, umt):
+		if !b.embeddedAccessor(t, m) {
+			return
+		}
+		call(setter, "b.$.setter$("+update+")\n")
+	case umt.Kind == types.Struct && b.memberBuilder(t, m, umt):
+		call(setter, "b.$.setter$("+update+")\n")
+	case umt.Kind == types.Struct && args["zero"] == "":
+		call(setter, "b.$.setter$($.type|raw${})\n")
+	case umt.Kind == types.Struct:
+		call(setter, "b.$.setter$($.zero$)\n")
+	}
+}
+
+// zeroValue returns the literal of the zero value of t, empty for the structs
+// and arrays needing a composite literal.
+func zeroValue(t *types.Type) string {
+	if t.Kind == types.Pointer {
+		return "nil"
+	}
+	u := underlyingType(t)
+	switch u.Kind {
+	case types.Builtin:
+		switch u.Name.Name {
+		case "string":
+			return `""`
+		case "bool":
+			return "false"
+		case "error":
+			return "nil"
+		}
+		return "0"
+	case types.Pointer, types.Slice, types.Map, types.Interface, types.Func, types.Chan:
+		return "nil"
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"k8s.io/gengo/generator"
+)
+
+// stdoutFile is a Go file type collecting the generated files, to print them
+// once all the packages are generated instead of writing them.
+type stdoutFile struct {
+	golang *generator.DefaultFileType
+	lock   sync.Mutex
+	files  map[string][]byte
+}
+
+func newStdoutFile() *stdoutFile {
+	return &stdoutFile{
+		golang: generator.NewGolangFile(),
+		files:  map[string][]byte{},
+	}
+}
+
+func (ft *stdoutFile) AssembleFile(f *generator.File, pathname string) error {
+	formatted := &bytes.Buffer{}
+	if err := renderGolangFile(ft.golang, f, pathname, formatted, nil); err != nil {
+		return err
+	}
+	return ft.writeContent(pathname, formatted.Bytes())
+}
+
+// writeContent collects the content of the file pathname.
+func (ft *stdoutFile) writeContent(pathname string, content []byte) error {
+	ft.lock.Lock()
+	defer ft.lock.Unlock()
+	ft.files[pathname] = content
+	return nil
+}
+
+func (ft *stdoutFile) VerifyFile(f *generator.File, pathname string) error {
+	return ft.golang.VerifyFile(f, pathname)
+}
+
+// flush writes the collected files sorted by path, each preceded by a
+// "// file: <path>" line.
+func (ft *stdoutFile) flush(out io.Writer) error {
+	ft.lock.Lock()
+	defer ft.lock.Unlock()
+	paths := make([]string, 0, len(ft.files))
+	for path := range ft.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if _, err := fmt.Fprintf(out, "// file: %s\n%s", path, ft.files[path]); err != nil {
+			return err
+		}
+	}
+	ft.files = map[string][]byte{}
+	return nil
+}
@@ -0,0 +1,224 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PackageSummary counts what the generation of a package produced.
+type PackageSummary struct {
+	Package string
+	// Types is the number of builders generated.
+	Types int
+	// Setters is the number of builder methods generated for the members.
+	Setters int
+	// Warnings is the number of members without builder methods.
+	Warnings int
+	// Cached is true when the package did not change since its file was
+	// written, with --cache-file, and was not generated again.
+	Cached bool
+	// Duration is the time spent generating, formatting and writing the
+	// files of the package, for all its platforms.
+	Duration time.Duration
+}
+
+// Summary counts what a run of the generator produced, by package.
+type Summary struct {
+	Packages []PackageSummary
+	// Duration is the time of the whole run, parsing included.
+	Duration time.Duration
+}
+
+// Totals returns the sums of the counts of the packages.
+func (s Summary) Totals() PackageSummary {
+	var total PackageSummary
+	for _, pkg := range s.Packages {
+		total.Types += pkg.Types
+		total.Setters += pkg.Setters
+		total.Warnings += pkg.Warnings
+		total.Duration += pkg.Duration
+	}
+	return total
+}
+
+func (s Summary) String() string {
+	total := s.Totals()
+	cached := 0
+	for _, pkg := range s.Packages {
+		if pkg.Cached {
+			cached++
+		}
+	}
+	return fmt.Sprintf("%d package(s) (%d cached), %d builder(s), %d setter(s), %d warning(s) in %v",
+		len(s.Packages), cached, total.Types, total.Setters, total.Warnings, s.Duration.Round(time.Millisecond))
+}
+
+// WriteTable writes the counts of each package, one line per package, after
+// the totals.
+func (s Summary) WriteTable(w io.Writer) error {
+	lines := []string{s.String()}
+	for _, pkg := range s.Packages {
+		status := pkg.Duration.Round(time.Millisecond).String()
+		if pkg.Cached {
+			status = "cached"
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %d builder(s), %d setter(s), %d warning(s), %s",
+			pkg.Package, pkg.Types, pkg.Setters, pkg.Warnings, status))
+	}
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// summaryReport collects the counts of all the generated packages.
+type summaryReport struct {
+	lock     sync.Mutex
+	start    time.Time
+	end      time.Time
+	packages map[string]*PackageSummary
+	// outputs are the packages generated into each output path.
+	outputs map[string]string
+}
+
+// begin resets the report at the start of a run.
+func (r *summaryReport) begin() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.start, r.end = time.Now(), time.Time{}
+	r.packages = map[string]*PackageSummary{}
+	r.outputs = map[string]string{}
+}
+
+// done records the end of the run.
+func (r *summaryReport) done() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.end = time.Now()
+}
+
+// pkg returns the counts of the package pkg. The lock must be held.
+func (r *summaryReport) pkg(pkg string) *PackageSummary {
+	if r.packages == nil {
+		r.packages = map[string]*PackageSummary{}
+	}
+	if r.packages[pkg] == nil {
+		r.packages[pkg] = &PackageSummary{Package: pkg}
+	}
+	return r.packages[pkg]
+}
+
+// expect records that the package pkg is generated into outputPath.
+func (r *summaryReport) expect(outputPath, pkg string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.outputs == nil {
+		r.outputs = map[string]string{}
+	}
+	r.outputs[outputPath] = pkg
+	r.pkg(pkg)
+}
+
+// cached records the package pkg skipped by the cache.
+func (r *summaryReport) cached(pkg string, warnings int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	summary := r.pkg(pkg)
+	summary.Cached = true
+	summary.Warnings = warnings
+}
+
+// addType records a builder generated in the package pkg, with its setters.
+func (r *summaryReport) addType(pkg string, setters int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	summary := r.pkg(pkg)
+	summary.Types++
+	summary.Setters += setters
+}
+
+// addWarnings records the members of the package pkg without builder
+// methods.
+func (r *summaryReport) addWarnings(pkg string, warnings int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.pkg(pkg).Warnings += warnings
+}
+
+// addDuration records the time spent generating the package written into
+// outputPath.
+func (r *summaryReport) addDuration(outputPath string, d time.Duration) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	pkg, ok := r.outputs[outputPath]
+	if !ok {
+		pkg = outputPath
+	}
+	r.pkg(pkg).Duration += d
+}
+
+// summary returns the counts of the packages, sorted by package.
+func (r *summaryReport) summary() Summary {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	result := Summary{Packages: make([]PackageSummary, 0, len(r.packages))}
+	for _, pkg := range r.packages {
+		result.Packages = append(result.Packages, *pkg)
+	}
+	sort.Slice(result.Packages, func(i, j int) bool {
+		return result.Packages[i].Package < result.Packages[j].Package
+	})
+	if !r.start.IsZero() {
+		end := r.end
+		if end.IsZero() {
+			end = time.Now()
+		}
+		result.Duration = end.Sub(r.start)
+	}
+	return result
+}
+
+// declarationCounter is a writer counting the lines starting with prefix
+// written through it.
+type declarationCounter struct {
+	w      io.Writer
+	prefix string
+	// matched is the length of the prefix matched by the current line, -1
+	// when it does not start with the prefix.
+	matched int
+	count   int
+}
+
+func (d *declarationCounter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		switch {
+		case b == '\n':
+			d.matched = 0
+		case d.matched < 0 || d.matched == len(d.prefix):
+		case b == d.prefix[d.matched]:
+			d.matched++
+			if d.matched == len(d.prefix) {
+				d.count++
+			}
+		default:
+			d.matched = -1
+		}
+	}
+	return d.w.Write(p)
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/klog/v2"
+)
+
+// unchangedFile is a Go file type leaving the existing files with the
+// generated content untouched, keeping their modification times for the
+// build caches and the file watchers. The files are formatted as they are
+// streamed to a temporary file next to them, renamed over them once complete
+// if their content changed, so an interrupted run never leaves a truncated
+// file behind.
+type unchangedFile struct {
+	golang *generator.DefaultFileType
+}
+
+func newUnchangedFile() *unchangedFile {
+	return &unchangedFile{golang: generator.NewGolangFile()}
+}
+
+func (ft *unchangedFile) AssembleFile(f *generator.File, pathname string) error {
+	tmp, err := createTempFile(pathname)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	w := bufio.NewWriter(tmp)
+	unformatted := false
+	err = renderGolangFile(ft.golang, f, pathname, w, func(r io.Reader) error {
+		// Like the default file type, write the unformatted file for the
+		// errors of the generator to be seen.
+		unformatted = true
+		w.Reset(tmp)
+		if err := tmp.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		_, err := io.Copy(w, r)
+		return err
+	})
+	if err != nil && !unformatted {
+		return err
+	}
+	if flushErr := w.Flush(); flushErr != nil {
+		return flushErr
+	}
+	if err == nil && sameFile(tmp, pathname) {
+		klog.V(5).Infof("File %q is unchanged", pathname)
+		return nil
+	}
+	if replaceErr := replaceFile(tmp, pathname); replaceErr != nil {
+		return replaceErr
+	}
+	return err
+}
+
+func (ft *unchangedFile) writeContent(pathname string, content []byte) error {
+	tmp, err := createTempFile(pathname)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	if _, err := tmp.Write(content); err != nil {
+		return err
+	}
+	if sameFile(tmp, pathname) {
+		klog.V(5).Infof("File %q is unchanged", pathname)
+		return nil
+	}
+	return replaceFile(tmp, pathname)
+}
+
+func (ft *unchangedFile) VerifyFile(f *generator.File, pathname string) error {
+	return ft.golang.VerifyFile(f, pathname)
+}
+
+// sameFile reports whether the file pathname holds the content written to
+// tmp, reading both by chunks.
+func sameFile(tmp *os.File, pathname string) bool {
+	file, err := os.Open(pathname)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	if tmpInfo, err := tmp.Stat(); err != nil || tmpInfo.Size() != info.Size() {
+		return false
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return false
+	}
+	chunk, tmpChunk := make([]byte, 32*1024), make([]byte, 32*1024)
+	for remaining := info.Size(); remaining > 0; {
+		n := int64(len(chunk))
+		if n > remaining {
+			n = remaining
+		}
+		if _, err := io.ReadFull(file, chunk[:n]); err != nil {
+			return false
+		}
+		if _, err := io.ReadFull(tmp, tmpChunk[:n]); err != nil || !bytes.Equal(chunk[:n], tmpChunk[:n]) {
+			return false
+		}
+		remaining -= n
+	}
+	return true
+}
+
+// createTempFile creates the temporary file next to pathname the content of
+// the file is written to, renamed over it by replaceFile.
+func createTempFile(pathname string) (*os.File, error) {
+	tmpName := filepath.Join(filepath.Dir(pathname), fmt.Sprintf(".%s.%d.tmp", filepath.Base(pathname), os.Getpid()))
+	// A temporary file left by an interrupted run of the same process id
+	// would keep its permissions.
+	os.Remove(tmpName)
+	return os.OpenFile(tmpName, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0666)
+}
+
+// replaceFile renames the complete temporary file tmp to pathname. The file
+// keeps the permissions of the one it replaces, the new files getting those
+// of the default file type, 0666 less the umask.
+func replaceFile(tmp *os.File, pathname string) error {
+	if info, err := os.Stat(pathname); err == nil {
+		if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), pathname)
+}
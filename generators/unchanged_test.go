@@ -0,0 +1,66 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/gengo/generator"
+)
+
+// newFile returns a generated file of the package p with body.
+func newFile(body string) *generator.File {
+	f := &generator.File{PackageName: "p", Imports: map[string]struct{}{`strings "strings"`: {}, `unused "unused"`: {}}}
+	f.Body.WriteString(body)
+	return f
+}
+
+func TestUnchangedFile(t *testing.T) {
+	pathname := filepath.Join(t.TempDir(), "zz_generated.go")
+	ft := newUnchangedFile()
+	if err := ft.AssembleFile(newFile("func F() string {\nreturn strings.TrimSpace(\" f \")\n}\n"), pathname); err != nil {
+		t.Fatal(err)
+	}
+	want := "package p\n\nimport (\n\tstrings \"strings\"\n)\n\nfunc F() string {\n\treturn strings.TrimSpace(\" f \")\n}\n"
+	if got, err := os.ReadFile(pathname); err != nil || string(got) != want {
+		t.Fatalf("AssembleFile() wrote %q (%v), want %q", got, err, want)
+	}
+
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(pathname, past, past); err != nil {
+		t.Fatal(err)
+	}
+	if err := ft.AssembleFile(newFile("func F() string {\nreturn strings.TrimSpace(\" f \")\n}\n"), pathname); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(pathname); err != nil || !info.ModTime().Equal(past) {
+		t.Errorf("AssembleFile() of the same content wrote the file again")
+	}
+
+	if err := ft.AssembleFile(newFile("func F() {\nreturn +\n}\n"), pathname); err == nil {
+		t.Fatalf("AssembleFile() of an invalid file succeeded")
+	}
+	// The imports of the unformatted file are in the order of the map.
+	if got, err := os.ReadFile(pathname); err != nil || !strings.HasSuffix(string(got), ")\n\nfunc F() {\nreturn +\n}\n") {
+		t.Errorf("AssembleFile() of an invalid file wrote %q (%v), want the unformatted file", got, err)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(filepath.Dir(pathname), ".*.tmp")); len(matches) > 0 {
+		t.Errorf("AssembleFile() left the temporary files %v", matches)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"go/token"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// The union handler sets the members of the "string or object" union types,
+// structs decoded by their UnmarshalJSON method either from a string, held
+// by one of their members, or from an object:
+//
+//	types:
+//	  github.com/serverlessworkflow/sdk-go/v2/model.Start:
+//	    handler: union
+//	    string: StateName
+//
+// Instead of the method returning their nested builder, the builders holding
+// them get a Set<Member>String setter of the string form, and a
+// Set<Member>Object method replacing the member by a new object, returning
+// its builder.
+
+// unionHandlerName is the name of the handler of the union types.
+const unionHandlerName = "union"
+
+// unionHandler is the handler of a union type.
+type unionHandler struct {
+	// stringMember is the member of the union holding its string form.
+	stringMember string
+}
+
+func newUnionHandler(config TypeConfig) (typeHandler, error) {
+	if !token.IsIdentifier(config.String) {
+		return nil, fmt.Errorf("the %s handler needs the string member of the union", unionHandlerName)
+	}
+	return &unionHandler{stringMember: config.String}, nil
+}
+
+// handles reports whether m is a member of t with a nested builder, the
+// other members of the union type keeping their default methods.
+func (h *unionHandler) handles(g *genDeepCopy, t *types.Type, m types.Member) bool {
+	umt := builderType(m.Type)
+	return !m.Embedded && umt.Kind == types.Struct && g.memberBuilder(t, m, umt)
+}
+
+// check returns an error if the union t has no string member as configured.
+func (h *unionHandler) check(g *genDeepCopy, t *types.Type) error {
+	for _, m := range builderMembers(t) {
+		if m.Name != h.stringMember {
+			continue
+		}
+		if u := underlyingType(m.Type); m.Embedded || u.Kind != types.Builtin || u.Name.Name != "string" {
+			return fmt.Errorf("the string member %s of the union %v must be a string", m.Name, t)
+		}
+		if t.Name.Package != g.targetPackage && !token.IsExported(m.Name) {
+			return fmt.Errorf("the string member %s of the union %v is not exported", m.Name, t)
+		}
+		return nil
+	}
+	return fmt.Errorf("the union %v has no string member %s", t, h.stringMember)
+}
+
+func (h *unionHandler) methods(g *genDeepCopy, sw *generator.SnippetWriter, t *types.Type, m types.Member, argsMember generator.Args) {
+	base := argsMember["base"].(string)
+	argsMember["stringMember"] = h.stringMember
+	if !g.handWritten(t, "Set"+base+"String") {
+		sw.Do("// Set$.base$String sets $.name$ to its string form.\n", argsMember)
+		sw.Do("func (b *$.typeBase|raw$Builder) Set$.base$String(input string) *$.typeBase|raw$Builder {\n", argsMember)
+		g.copyOnWrite(sw)
+		g.clearOneof(sw, t, m)
+		sw.Do("model := $.type|raw${$.stringMember$: input}\n", argsMember)
+		g.builderFromModel(sw, "b."+propertyName(m), false, "model", builderType(m.Type))
+		sw.Do("return b\n", argsMember)
+		sw.Do("}\n\n", argsMember)
+	}
+	if g.handWritten(t, "Set"+base+"Object") {
+		return
+	}
+	if g.customArgs.CopyOnWrite {
+		sw.Do("// Set$.base$Object sets $.name$ to a new object, set by update.\n", argsMember)
+		sw.Do("func (b *$.typeBase|raw$Builder) Set$.base$Object(update func(*$.builder|raw$) *$.builder|raw$) *$.typeBase|raw$Builder {\n", argsMember)
+		sw.Do("b = b.copyOnWrite()\n", argsMember)
+		g.clearOneof(sw, t, m)
+		sw.Do("b.$.nameMethod$ = update($.newBuilder|raw$())\n", argsMember)
+		sw.Do("return b\n", argsMember)
+		sw.Do("}\n\n", argsMember)
+		return
+	}
+	sw.Do("// Set$.base$Object sets $.name$ to a new object, returning its builder.\n", argsMember)
+	sw.Do("func (b *$.typeBase|raw$Builder) Set$.base$Object() *$.builder|raw$ {\n", argsMember)
+	g.clearOneof(sw, t, m)
+	sw.Do("b.$.nameMethod$ = $.newBuilder|raw$()\n", argsMember)
+	sw.Do("return b.$.nameMethod$\n", argsMember)
+	sw.Do("}\n\n", argsMember)
+}
+
+func (h *unionHandler) packages() []string {
+	return nil
+}
+
+func (h *unionHandler) smokeCall(g *genDeepCopy, t *types.Type, m types.Member) (name, format string) {
+	name = "Set" + g.memberName(m) + "String"
+	return name, "b." + name + "(\"\")\n"
+}
@@ -0,0 +1,370 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// Rules of the +builder-gen:validate tags, one per tag.
+const (
+	validateNonEmpty = "nonempty"
+	validateMin      = "min"
+	validateMax      = "max"
+	validateRegexp   = "regexp"
+)
+
+// Functions called by the checks of BuildSafe.
+var (
+	errorsNewFunc   = &types.Type{Name: types.Name{Package: "errors", Name: "New"}}
+	mustCompileFunc = &types.Type{Name: types.Name{Package: "regexp", Name: "MustCompile"}}
+)
+
+// validatorPackage is the library validating the models carrying validate
+// struct tags with --struct-validator.
+const validatorPackage = "github.com/go-playground/validator/v10"
+
+// validatorNewFunc creates the validator of the package, named
+// structValidatorName.
+var validatorNewFunc = &types.Type{Name: types.Name{Package: validatorPackage, Name: "New"}}
+
+// structValidatorName is the validator declared once per package with
+// --struct-validator.
+const structValidatorName = "builderValidator"
+
+// validateRule is a +builder-gen:validate tag of a member, the kind of the
+// rule and its argument: nonempty, min=N, max=N or regexp=PATTERN.
+type validateRule struct {
+	kind  string
+	value string
+}
+
+// extractMemberValidateTags returns the rules of the +builder-gen:validate tags
+// of m, failing on the unknown rules and those not applying to its type.
+func extractMemberValidateTags(m types.Member) ([]validateRule, error) {
+	values := types.ExtractCommentTags("+", m.CommentLines)[validateTagName]
+	rules := make([]validateRule, 0, len(values))
+	for _, value := range values {
+		kind, arg, _ := strings.Cut(value, "=")
+		rule := validateRule{kind: kind, value: arg}
+		if err := rule.check(m.Type); err != nil {
+			return nil, fmt.Errorf("invalid %s tag %q: %w", validateTagName, value, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// check reports why the rule does not apply to the values of t.
+func (r validateRule) check(t *types.Type) error {
+	u := validatedType(t)
+	switch r.kind {
+	case validateNonEmpty:
+		if r.value != "" {
+			return fmt.Errorf("%s takes no argument", r.kind)
+		}
+		if underlyingType(t).Kind != types.Pointer && !hasLength(u) && !isNumber(u) {
+			return fmt.Errorf("%s needs a string, slice, map, number or pointer", r.kind)
+		}
+	case validateMin, validateMax:
+		switch {
+		case hasLength(u):
+			if _, err := strconv.Atoi(r.value); err != nil {
+				return fmt.Errorf("%s needs an integer length", r.kind)
+			}
+		case isNumber(u) && strings.HasPrefix(u.Name.Name, "float"):
+			if _, err := strconv.ParseFloat(r.value, 64); err != nil {
+				return fmt.Errorf("%s needs a number", r.kind)
+			}
+		case isNumber(u):
+			if _, err := strconv.ParseInt(r.value, 10, 64); err != nil {
+				return fmt.Errorf("%s needs an integer", r.kind)
+			}
+		default:
+			return fmt.Errorf("%s needs a string, slice, map or number", r.kind)
+		}
+	case validateRegexp:
+		if u.Kind != types.Builtin || u.Name.Name != "string" {
+			return fmt.Errorf("%s needs a string", r.kind)
+		}
+		if _, err := regexp.Compile(r.value); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown rule %q", r.kind)
+	}
+	return nil
+}
+
+// validatedType returns the underlying type of the values checked for t, the
+// element of the pointers.
+func validatedType(t *types.Type) *types.Type {
+	u := underlyingType(t)
+	if u.Kind == types.Pointer {
+		u = underlyingType(u.Elem)
+	}
+	return u
+}
+
+// hasLength reports whether the rules check the length of the values of u.
+func hasLength(u *types.Type) bool {
+	return u.Kind == types.Slice || u.Kind == types.Map || (u.Kind == types.Builtin && u.Name.Name == "string")
+}
+
+// isNumber reports whether u is a builtin numeric type.
+func isNumber(u *types.Type) bool {
+	if u.Kind != types.Builtin {
+		return false
+	}
+	switch u.Name.Name {
+	case "string", "bool", "error", "complex64", "complex128":
+		return false
+	}
+	return true
+}
+
+// hasValidation reports whether a member of t is tagged +builder-gen:validate,
+// or t is tagged +builder-gen:oneof.
+func hasValidation(t *types.Type) bool {
+	if len(extractOneofTags(t)) > 0 {
+		return true
+	}
+	for _, m := range builderMembers(t) {
+		if len(types.ExtractCommentTags("+", m.CommentLines)[validateTagName]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasValidatorTags reports whether a member of t carries a validate struct
+// tag, checked by the validator of --struct-validator.
+func hasValidatorTags(t *types.Type) bool {
+	for _, m := range t.Members {
+		if _, ok := reflect.StructTag(m.Tags).Lookup("validate"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// structValidated reports whether BuildSafe validates the models of t with
+// the validator of the package.
+func (g *genDeepCopy) structValidated(t *types.Type) bool {
+	return g.customArgs.StructValidator && hasValidatorTags(t)
+}
+
+// generatedTypes returns the structs of the package getting builders, those
+// of their anonymous struct members included.
+func (g *genDeepCopy) generatedTypes(c *generator.Context) []*types.Type {
+	var result []*types.Type
+	var add func(t *types.Type)
+	add = func(t *types.Type) {
+		result = append(result, t)
+		for _, st := range inlineStructsOf(t) {
+			add(st)
+		}
+	}
+	for _, t := range c.Universe.Package(g.targetPackage).Types {
+		if t.Kind == types.Struct && g.customArgs.generates(t) {
+			add(t)
+		}
+	}
+	return result
+}
+
+// needsStructValidator reports whether a BuildSafe method of the package
+// uses the validator of --struct-validator.
+func (g *genDeepCopy) needsStructValidator(c *generator.Context) bool {
+	if !g.customArgs.StructValidator {
+		return false
+	}
+	for _, t := range g.generatedTypes(c) {
+		if hasValidatorTags(t) && !g.declared.Has(t.Name.Name+"Builder.BuildSafe") {
+			return true
+		}
+	}
+	return false
+}
+
+// needsBuilderErrors reports whether the builders of the package join errors,
+// recorded by the setters or returned by the validations.
+func (g *genDeepCopy) needsBuilderErrors(c *generator.Context) bool {
+	if g.customArgs.AccumulateErrors {
+		return true
+	}
+	for _, t := range g.generatedTypes(c) {
+		if hasValidation(t) || g.structValidated(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePatternName returns the variable holding the compiled pattern of
+// the n-th regexp rule of the member m of t.
+func validatePatternName(t *types.Type, m types.Member, n int) string {
+	name := t.Name.Name + m.Name + "Pattern"
+	if n > 0 {
+		name += strconv.Itoa(n + 1)
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// structMethodBuildSafe writes BuildSafe, building the model and returning,
+// with --accumulate-errors, the errors of the setters, those of the
+// +builder-gen:validate rules of the members and of the +builder-gen:oneof tags
+// and, with --struct-validator, those of the validate struct tags.
+func (g *genDeepCopy) structMethodBuildSafe(sw *generator.SnippetWriter, t *types.Type) error {
+	if !(g.customArgs.AccumulateErrors || hasValidation(t) || g.structValidated(t)) || g.handWritten(t, "BuildSafe") {
+		return nil
+	}
+
+	members := builderMembers(t)
+	rules := make([][]validateRule, len(members))
+	for i, m := range members {
+		var err error
+		if rules[i], err = extractMemberValidateTags(m); err != nil {
+			return fmt.Errorf("%v.%s: %w", t, m.Name, err)
+		}
+	}
+
+	args := generator.Args{
+		"type":        t,
+		"build":       g.buildName(t),
+		"errors":      g.errorsType(),
+		"validator":   structValidatorName,
+		"mustCompile": mustCompileFunc,
+	}
+	for i, m := range members {
+		n := 0
+		for _, rule := range rules[i] {
+			if rule.kind == validateRegexp {
+				args["pattern"] = validatePatternName(t, m, n)
+				args["source"] = strconv.Quote(rule.value)
+				sw.Do("var $.pattern$ = $.mustCompile|raw$($.source$)\n\n", args)
+				n++
+			}
+		}
+	}
+
+	if g.customArgs.AccumulateErrors {
+		sw.Do("// BuildSafe builds the model, and returns the errors of the setters\n", args)
+		sw.Do("// called and of the validations of its members.\n", args)
+	} else {
+		sw.Do("// BuildSafe builds the model, and returns the errors of the validations of\n", args)
+		sw.Do("// its members.\n", args)
+	}
+	sw.Do("func (b *$.type|raw$Builder) BuildSafe() ($.type|raw$, error) {\n", args)
+	sw.Do("model := b.$.build$()\n", args)
+	sw.Do("var errs $.errors$\n", args)
+	if g.customArgs.AccumulateErrors {
+		sw.Do("if err := b.Err(); err != nil {\n", args)
+		sw.Do("errs = append(errs, err)\n", args)
+		sw.Do("}\n", args)
+	}
+	for i, m := range members {
+		if len(rules[i]) > 0 {
+			g.validateMember(sw, t, m, rules[i])
+		}
+	}
+	g.validateOneofs(sw, t)
+	if g.structValidated(t) {
+		sw.Do("if err := $.validator$.Struct(model); err != nil {\n", args)
+		sw.Do("errs = append(errs, err)\n", args)
+		sw.Do("}\n", args)
+	}
+	g.returnErrors(sw, "model, ")
+	sw.Do("}\n\n", args)
+	return nil
+}
+
+// validateMember writes the checks of the rules of the member m of t,
+// appending an error to errs for each rule the model breaks.
+func (g *genDeepCopy) validateMember(sw *generator.SnippetWriter, t *types.Type, m types.Member, rules []validateRule) {
+	pointer := underlyingType(m.Type).Kind == types.Pointer
+	u := validatedType(m.Type)
+	value := "model." + m.Name
+	if pointer {
+		value = "*" + value
+	}
+
+	var conditions, messages []string
+	patterns := 0
+	for _, rule := range rules {
+		switch rule.kind {
+		case validateNonEmpty:
+			if pointer {
+				// The other rules are checked on the values pointed to.
+				sw.Do("if model.$.$ == nil {\n", m.Name)
+				sw.Do("errs = append(errs, $.errorsNew|raw$($.message$))\n", generator.Args{
+					"errorsNew": errorsNewFunc,
+					"message":   strconv.Quote(m.Name + ": must not be empty"),
+				})
+				sw.Do("}\n", nil)
+				continue
+			}
+			if hasLength(u) {
+				conditions = append(conditions, "len("+value+") == 0")
+			} else {
+				conditions = append(conditions, value+" == 0")
+			}
+			messages = append(messages, "must not be empty")
+		case validateMin, validateMax:
+			operator, bound := "<", "at least"
+			if rule.kind == validateMax {
+				operator, bound = ">", "at most"
+			}
+			if hasLength(u) {
+				conditions = append(conditions, "len("+value+") "+operator+" "+rule.value)
+				messages = append(messages, "must have a length of "+bound+" "+rule.value)
+			} else {
+				conditions = append(conditions, value+" "+operator+" "+rule.value)
+				messages = append(messages, "must be "+bound+" "+rule.value)
+			}
+		case validateRegexp:
+			conditions = append(conditions, "!"+validatePatternName(t, m, patterns)+".MatchString("+value+")")
+			patterns++
+			messages = append(messages, "must match "+rule.value)
+		}
+	}
+	if len(conditions) == 0 {
+		return
+	}
+
+	if pointer {
+		sw.Do("if model.$.$ != nil {\n", m.Name)
+	}
+	for i, condition := range conditions {
+		args := generator.Args{
+			"condition": condition,
+			"errorsNew": errorsNewFunc,
+			"message":   strconv.Quote(m.Name + ": " + messages[i]),
+		}
+		sw.Do("if $.condition$ {\n", args)
+		sw.Do("errs = append(errs, $.errorsNew|raw$($.message$))\n", args)
+		sw.Do("}\n", args)
+	}
+	if pointer {
+		sw.Do("}\n", nil)
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"go/token"
+	pathpkg "path"
+	"strconv"
+	"strings"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+	"k8s.io/klog/v2"
+)
+
+// With --convert-versions, the input packages of the same directory named by
+// API versions, like api/v1alpha1 and api/v1, are versions of the same API
+// converted through its most stable version, the hub, which the other
+// versions import: the builders of their structs of the same name as a struct
+// of the hub get a ConvertTo<Hub>Builder method converting the built model to
+// it, and a New<T>BuilderFrom<Hub> constructor converting a model of the hub.
+// The members of the same name are copied when their types are the same, the
+// structs of the same name of the two versions, the named types of the same
+// name and builtin type, or the pointers, slices and maps of these. The other
+// members are left to their zero value.
+
+// apiVersionSiblings returns, by import path, the other input packages of the
+// same directory named by an API version, for the input packages named by an
+// API version themselves.
+func apiVersionSiblings(universe types.Universe, inputs sets.String) map[string][]string {
+	byDir := map[string][]string{}
+	for _, path := range inputs.List() {
+		dir, version := pathpkg.Split(strings.TrimSuffix(path, "/"))
+		if universe[path] != nil && apiVersionPattern.MatchString(version) {
+			byDir[dir] = append(byDir[dir], path)
+		}
+	}
+	siblings := map[string][]string{}
+	for _, paths := range byDir {
+		for _, path := range paths {
+			for _, sibling := range paths {
+				if sibling != path {
+					siblings[path] = append(siblings[path], sibling)
+				}
+			}
+		}
+	}
+	return siblings
+}
+
+// versionName returns the exported name of the API version of the package
+// path, like V1beta1.
+func versionName(path string) string {
+	version := pathpkg.Base(strings.TrimSuffix(path, "/"))
+	return strings.ToUpper(version[:1]) + version[1:]
+}
+
+// versionStability orders the API versions by stability, like Kubernetes: the
+// GA versions before the beta versions before the alpha versions, then the
+// latest first.
+func versionStability(path string) [3]int {
+	match := apiVersionPattern.FindStringSubmatch(pathpkg.Base(strings.TrimSuffix(path, "/")))
+	if match == nil {
+		return [3]int{}
+	}
+	major, _ := strconv.Atoi(strings.TrimPrefix(strings.TrimSuffix(match[0], match[1]), "v"))
+	stability := [3]int{3, major, 0}
+	switch match[2] {
+	case "beta":
+		stability[0] = 2
+	case "alpha":
+		stability[0] = 1
+	}
+	if match[2] != "" {
+		stability[2], _ = strconv.Atoi(strings.TrimPrefix(match[1], match[2]))
+	}
+	return stability
+}
+
+// hubVersion returns, with --convert-versions, the most stable of the API
+// versions of the package, itself included, which the structs of the others
+// are converted to and from. It is empty for the packages without versions.
+func (g *genDeepCopy) hubVersion() string {
+	if !g.customArgs.ConvertVersions || len(g.versions) == 0 {
+		return ""
+	}
+	hub := g.targetPackage
+	for _, version := range g.versions {
+		a, b := versionStability(version), versionStability(hub)
+		if a[0] > b[0] || a[0] == b[0] && (a[1] > b[1] || a[1] == b[1] && a[2] > b[2]) {
+			hub = version
+		}
+	}
+	return hub
+}
+
+// versionOf returns the struct of the same name as t in the API version
+// version, nil if it declares none getting a builder.
+func (g *genDeepCopy) versionOf(t *types.Type, version string) *types.Type {
+	pkg := g.universe[version]
+	if pkg == nil || !token.IsExported(t.Name.Name) || inlineParent(t) != nil {
+		return nil
+	}
+	vt := pkg.Types[t.Name.Name]
+	if vt == nil || vt.Kind != types.Struct || !g.customArgs.generates(vt) {
+		return nil
+	}
+	return vt
+}
+
+// convertedVersion reports whether t, getting a builder in the hub, is the
+// conversion target of a struct of the same name of another API version, its
+// builder then exporting its New<T>BuilderFromModel.
+func (g *genDeepCopy) convertedVersion(t *types.Type) bool {
+	if g.hubVersion() != g.targetPackage || inlineParent(t) != nil {
+		return false
+	}
+	for _, version := range g.versions {
+		if g.versionOf(t, version) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// versionConversion is the conversion of the struct from to the struct of
+// the same name to of another API version.
+type versionConversion struct {
+	from, to *types.Type
+}
+
+// conversionFunc returns the function of the package converting the struct
+// from to the struct to, convert<T>To<Hub> or convert<T>From<Hub>.
+func (g *genDeepCopy) conversionFunc(from, to *types.Type) *types.Type {
+	name := "convert" + from.Name.Name + "To" + versionName(to.Name.Package)
+	if to.Name.Package == g.targetPackage {
+		name = "convert" + to.Name.Name + "From" + versionName(from.Name.Package)
+	}
+	return &types.Type{Name: types.Name{Package: g.targetPackage, Name: name}}
+}
+
+// structMethodsConvertTo writes, with --convert-versions, the
+// ConvertTo<Hub>Builder method and the New<T>BuilderFrom<Hub> constructor of
+// the builder of t, for the structs of the same name of the hub, and the
+// functions converting the structs they need the package did not declare yet.
+func (g *genDeepCopy) structMethodsConvertTo(sw *generator.SnippetWriter, t *types.Type) {
+	hub := g.hubVersion()
+	// The conversion functions are left to the file of the types shared by
+	// the platforms.
+	if hub == "" || hub == g.targetPackage || g.platform != "" {
+		return
+	}
+	ht := g.versionOf(t, hub)
+	if ht == nil || g.versionOf(ht, g.targetPackage) == nil {
+		return
+	}
+
+	args := generator.Args{
+		"type":        t,
+		"hub":         ht,
+		"convertTo":   "ConvertTo" + versionName(hub) + "Builder",
+		"newFrom":     g.newBuilderOf(t).Name.Name + "From" + versionName(hub),
+		"build":       g.buildName(t),
+		"fromModel":   g.fromModelOf(ht),
+		"constructor": g.constructorOf(t),
+		"to":          g.conversionFunc(t, ht),
+		"from":        g.conversionFunc(ht, t),
+	}
+	var pending []versionConversion
+	if !g.handWritten(t, args["convertTo"].(string)) {
+		sw.Do("// $.convertTo$ builds the model and returns a builder of its conversion\n", args)
+		sw.Do("// to the $.hub|raw$ of the hub API version.\n", args)
+		sw.Do("func (b *$.type|raw$Builder) $.convertTo$() *$.hub|raw$Builder {\n", args)
+		sw.Do("model := b.$.build$()\n", args)
+		sw.Do("return $.fromModel|raw$($.to|raw$(&model))\n", args)
+		sw.Do("}\n\n", args)
+		pending = append(pending, versionConversion{from: t, to: ht})
+	}
+	if !g.handWritten(nil, args["newFrom"].(string)) {
+		sw.Do("// $.newFrom$ creates a builder for $.type|raw$ holding the conversion\n", args)
+		sw.Do("// of the model of the hub API version.\n", args)
+		sw.Do("func $.newFrom$(model $.hub|raw$) *$.type|raw$Builder {\n", args)
+		sw.Do("builder := $.constructor|raw$()\n", args)
+		sw.Do("builder.fromModel($.from|raw$(&model))\n", args)
+		sw.Do("return builder\n", args)
+		sw.Do("}\n\n", args)
+		pending = append(pending, versionConversion{from: ht, to: t})
+	}
+
+	for len(pending) > 0 {
+		conversion := pending[0]
+		pending = pending[1:]
+		fn := g.conversionFunc(conversion.from, conversion.to)
+		if g.converted.Has(fn.Name.Name) {
+			continue
+		}
+		g.converted.Insert(fn.Name.Name)
+		if !g.handWritten(nil, fn.Name.Name) {
+			pending = append(pending, g.writeConversionFunc(sw, conversion)...)
+		}
+	}
+}
+
+// writeConversionFunc writes the function converting the struct of the
+// conversion, returning the conversions of the structs it calls.
+func (g *genDeepCopy) writeConversionFunc(sw *generator.SnippetWriter, conversion versionConversion) []versionConversion {
+	args := generator.Args{
+		"from":    conversion.from,
+		"to":      conversion.to,
+		"convert": g.conversionFunc(conversion.from, conversion.to),
+	}
+	sw.Do("// $.convert|raw$ converts in to the $.to|raw$ of another API version,\n", args)
+	sw.Do("// copying the members of the same name and of convertible types.\n", args)
+	sw.Do("func $.convert|raw$(in *$.from|raw$) $.to|raw$ {\n", args)
+	sw.Do("var out $.to|raw$\n", args)
+	var pending []versionConversion
+	for _, m := range conversion.from.Members {
+		for _, vm := range conversion.to.Members {
+			if vm.Name != m.Name || vm.Embedded != m.Embedded || !token.IsExported(vm.Name) {
+				continue
+			}
+			if !g.convertible(m.Type, vm.Type) {
+				klog.V(2).Infof("%v.%s is not converted to %v, the types of the member differ", conversion.from, m.Name, conversion.to)
+				break
+			}
+			g.writeConversion(sw, "out."+vm.Name, "in."+m.Name, m.Type, vm.Type, 0, &pending)
+			break
+		}
+	}
+	sw.Do("return out\n", args)
+	sw.Do("}\n\n", args)
+	return pending
+}
+
+// versionTypes reports whether from and to are the named types of the same
+// name of the package generated and of its hub API version, in either order.
+func (g *genDeepCopy) versionTypes(from, to *types.Type) bool {
+	if from.Name.Name != to.Name.Name || from.Name.Package == to.Name.Package {
+		return false
+	}
+	hub := g.hubVersion()
+	for _, pkg := range []string{from.Name.Package, to.Name.Package} {
+		if pkg != g.targetPackage && pkg != hub {
+			return false
+		}
+	}
+	return true
+}
+
+// convertible reports whether the values of from convert to to, of the other
+// API version.
+func (g *genDeepCopy) convertible(from, to *types.Type) bool {
+	if from == to || from.Name == to.Name {
+		return true
+	}
+	if g.versionTypes(from, to) {
+		uf, ut := underlyingType(from), underlyingType(to)
+		if from.Kind == types.Struct && to.Kind == types.Struct {
+			return token.IsExported(to.Name.Name)
+		}
+		return from.Kind == types.Alias && to.Kind == types.Alias && uf.Kind == types.Builtin && uf.Name == ut.Name
+	}
+	if from.Kind != to.Kind || from.Name.Package != "" || to.Name.Package != "" {
+		return false
+	}
+	switch from.Kind {
+	case types.Pointer, types.Slice:
+		return g.convertible(from.Elem, to.Elem)
+	case types.Map:
+		return g.convertible(from.Elem, to.Elem) && (from.Key == to.Key || g.versionTypes(from.Key, to.Key) && underlyingType(from.Key).Name == underlyingType(to.Key).Name)
+	}
+	return false
+}
+
+// writeConversion writes the statements setting out to the value in of from
+// converted to to, adding the conversions of the structs it calls to
+// pending. The variables it declares are suffixed by the depth of the value.
+func (g *genDeepCopy) writeConversion(sw *generator.SnippetWriter, out, in string, from, to *types.Type, depth int, pending *[]versionConversion) {
+	suffix := strconv.Itoa(depth)
+	args := generator.Args{
+		"out":   out,
+		"in":    in,
+		"to":    to,
+		"i":     "i" + suffix,
+		"k":     "k" + suffix,
+		"v":     "v" + suffix,
+		"value": "value" + suffix,
+	}
+	switch {
+	case from == to || from.Name == to.Name:
+		sw.Do("$.out$ = $.in$\n", args)
+	case from.Kind == types.Struct:
+		*pending = append(*pending, versionConversion{from: from, to: to})
+		args["convert"] = g.conversionFunc(from, to)
+		sw.Do("$.out$ = $.convert|raw$(&$.in$)\n", args)
+	case from.Kind == types.Alias:
+		sw.Do("$.out$ = $.to|raw$($.in$)\n", args)
+	case from.Kind == types.Pointer:
+		args["elem"] = to.Elem
+		sw.Do("if $.in$ != nil {\n", args)
+		if from.Elem.Kind == types.Struct && g.versionTypes(from.Elem, to.Elem) {
+			*pending = append(*pending, versionConversion{from: from.Elem, to: to.Elem})
+			args["convert"] = g.conversionFunc(from.Elem, to.Elem)
+			sw.Do("$.value$ := $.convert|raw$($.in$)\n", args)
+		} else {
+			sw.Do("var $.value$ $.elem|raw$\n", args)
+			g.writeConversion(sw, "value"+suffix, "*"+in, from.Elem, to.Elem, depth+1, pending)
+		}
+		sw.Do("$.out$ = &$.value$\n", args)
+		sw.Do("}\n", args)
+	case from.Kind == types.Slice:
+		i := args["i"].(string)
+		sw.Do("if $.in$ != nil {\n", args)
+		sw.Do("$.out$ = make($.to|raw$, len($.in$))\n", args)
+		sw.Do("for $.i$ := range $.in$ {\n", args)
+		g.writeConversion(sw, out+"["+i+"]", in+"["+i+"]", from.Elem, to.Elem, depth+1, pending)
+		sw.Do("}\n", args)
+		sw.Do("}\n", args)
+	case from.Kind == types.Map:
+		key := args["k"].(string)
+		sw.Do("if $.in$ != nil {\n", args)
+		sw.Do("$.out$ = make($.to|raw$, len($.in$))\n", args)
+		sw.Do("for $.k$, $.v$ := range $.in$ {\n", args)
+		if from.Key != to.Key {
+			key = "key" + suffix
+			args["key"] = key
+			args["keyType"] = to.Key
+			sw.Do("$.key$ := $.keyType|raw$($.k$)\n", args)
+		}
+		if to.Elem.Kind == types.Pointer {
+			// The nil pointers are converted to nil, keeping their keys.
+			args["entry"] = out + "[" + key + "]"
+			sw.Do("$.entry$ = nil\n", args)
+		}
+		g.writeConversion(sw, out+"["+key+"]", args["v"].(string), from.Elem, to.Elem, depth+1, pending)
+		sw.Do("}\n", args)
+		sw.Do("}\n", args)
+	}
+}
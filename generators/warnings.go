@@ -0,0 +1,90 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Warning describes a member a builder could not handle. The builder has no
+// method setting it, so the member keeps its zero value unless it is set by
+// other means.
+type Warning struct {
+	Package string
+	Type    string
+	Member  string
+	Reason  string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s.%s: %s", w.Type, w.Member, w.Reason)
+}
+
+// warningReport collects the warnings of all the generated packages.
+type warningReport struct {
+	lock     sync.Mutex
+	warnings []Warning
+}
+
+func (r *warningReport) add(warnings ...Warning) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.warnings = append(r.warnings, warnings...)
+}
+
+// list returns the warnings sorted by package, type and member.
+func (r *warningReport) list() []Warning {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	result := append([]Warning{}, r.warnings...)
+	sortWarnings(result)
+	return result
+}
+
+// forPackage returns the warnings of the types of pkg.
+func (r *warningReport) forPackage(pkg string) []Warning {
+	var result []Warning
+	for _, w := range r.list() {
+		if w.Package == pkg {
+			result = append(result, w)
+		}
+	}
+	return result
+}
+
+func sortWarnings(warnings []Warning) {
+	sort.SliceStable(warnings, func(i, j int) bool {
+		a, b := warnings[i], warnings[j]
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Member < b.Member
+	})
+}
+
+// formatWarnings renders the report of one package.
+func formatWarnings(pkg string, warnings []Warning) string {
+	lines := []string{fmt.Sprintf("Package %q has %d member(s) without builder methods:", pkg, len(warnings))}
+	for _, w := range warnings {
+		lines = append(lines, "  "+w.String())
+	}
+	return strings.Join(lines, "\n")
+}
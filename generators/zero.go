@@ -0,0 +1,219 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generators
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+	"reflect"
+	"strings"
+
+	"k8s.io/gengo/examples/set-gen/sets"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// Methods generated on the models with --is-zero.
+const (
+	isZeroMethod  = "IsZero"
+	isEmptyMethod = "IsEmpty"
+)
+
+// generatesIsZero reports whether t, a model of the package targetPackage,
+// gets the method IsZero or IsEmpty of --is-zero: the structs getting
+// builders not declaring it, but for the anonymous structs.
+func generatesIsZero(customArgs *CustomArgs, targetPackage string, declared sets.String, t *types.Type, method string) bool {
+	if !customArgs.IsZero || t.Name.Package != targetPackage || t.Kind != types.Struct || !customArgs.generates(t) || inlineParent(t) != nil {
+		return false
+	}
+	_, ok := t.Methods[method]
+	return !ok && !declared.Has(t.Name.Name+"."+method)
+}
+
+// genIsZero produces the IsZero and IsEmpty methods of the models of the
+// builders, with --is-zero. It writes to the file of the builders.
+type genIsZero struct {
+	generator.DefaultGen
+	targetPackage string
+	imports       namer.ImportTracker
+	customArgs    *CustomArgs
+	// declared holds the methods hand-written in the target package, which
+	// are not generated.
+	declared sets.String
+}
+
+func newGenIsZero(sanitizedName, targetPackage string, customArgs *CustomArgs, declared sets.String) *genIsZero {
+	return &genIsZero{
+		DefaultGen: generator.DefaultGen{
+			OptionalName: sanitizedName,
+		},
+		targetPackage: targetPackage,
+		imports:       generator.NewImportTracker(),
+		customArgs:    customArgs,
+		declared:      declared,
+	}
+}
+
+func (g *genIsZero) Namers(c *generator.Context) namer.NameSystems {
+	return namer.NameSystems{
+		"raw": namer.NewRawNamer(g.targetPackage, g.imports),
+	}
+}
+
+func (g *genIsZero) Filter(c *generator.Context, t *types.Type) bool {
+	return generatesIsZero(g.customArgs, g.targetPackage, g.declared, t, isZeroMethod) ||
+		generatesIsZero(g.customArgs, g.targetPackage, g.declared, t, isEmptyMethod)
+}
+
+func (g *genIsZero) Imports(c *generator.Context) []string {
+	return g.imports.ImportLines()
+}
+
+// hasMethod reports whether the values of t are checked with their method,
+// either generated or declared as func (T) <method>() bool.
+func (g *genIsZero) hasMethod(t *types.Type, method string) bool {
+	if generatesIsZero(g.customArgs, g.targetPackage, g.declared, t, method) {
+		return true
+	}
+	return declaresPredicate(t, method)
+}
+
+// declaresPredicate reports whether t, or the type it aliases, declares the
+// method func (T) <method>() bool.
+func declaresPredicate(t *types.Type, method string) bool {
+	for t.Kind == types.Alias {
+		t = t.Underlying
+	}
+	m, ok := t.Methods[method]
+	if !ok || m.Signature == nil {
+		return false
+	}
+	sig := m.Signature
+	return len(sig.Parameters) == 0 && len(sig.Results) == 1 && sig.Results[0].Name == types.Bool.Name
+}
+
+func (g *genIsZero) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
+	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	args := generator.Args{
+		"type": t,
+	}
+	if generatesIsZero(g.customArgs, g.targetPackage, g.declared, t, isZeroMethod) {
+		sw.Do("// IsZero reports whether all the members of in hold their zero value, the\n", args)
+		sw.Do("// nested structs included.\n", args)
+		sw.Do("func (in $.type|raw$) IsZero() bool {\n", args)
+		for _, m := range t.Members {
+			g.check(sw, "in."+m.Name, inlineMemberType(t, m), isZeroMethod, 1)
+		}
+		sw.Do("return true\n", args)
+		sw.Do("}\n\n", args)
+	}
+	if generatesIsZero(g.customArgs, g.targetPackage, g.declared, t, isEmptyMethod) {
+		sw.Do("// IsEmpty reports whether all the members of in are empty, like the members\n", args)
+		sw.Do("// omitted by omitempty, the empty slices and maps and the nested structs\n", args)
+		sw.Do("// holding empty members included.\n", args)
+		sw.Do("func (in $.type|raw$) IsEmpty() bool {\n", args)
+		for _, m := range t.Members {
+			g.check(sw, "in."+m.Name, inlineMemberType(t, m), isEmptyMethod, 1)
+		}
+		sw.Do("return true\n", args)
+		sw.Do("}\n\n", args)
+	}
+	return sw.Error()
+}
+
+// check writes the statements returning false when x, of type t, is not
+// zero, or not empty, as method tells. depth numbers the loop variables of
+// the arrays.
+func (g *genIsZero) check(sw *generator.SnippetWriter, x string, t *types.Type, method string, depth int) {
+	args := generator.Args{
+		"x":       x,
+		"method":  method,
+		"i":       fmt.Sprintf("i%d", depth),
+		"valueOf": valueOfFunc,
+	}
+	switch {
+	case g.hasMethod(t, method):
+		sw.Do("if !$.x$.$.method$() {\nreturn false\n}\n", args)
+		return
+	case method == isEmptyMethod && g.hasMethod(t, isZeroMethod):
+		// Like time.Time, the values without IsEmpty are empty when zero.
+		sw.Do("if !$.x$.IsZero() {\nreturn false\n}\n", args)
+		return
+	}
+
+	u := underlyingType(t)
+	switch u.Kind {
+	case types.Slice, types.Map:
+		if method == isEmptyMethod {
+			sw.Do("if len($.x$) != 0 {\nreturn false\n}\n", args)
+		} else {
+			sw.Do("if $.x$ != nil {\nreturn false\n}\n", args)
+		}
+	case types.Pointer, types.Interface, types.Func, types.Chan:
+		sw.Do("if $.x$ != nil {\nreturn false\n}\n", args)
+	case types.Builtin:
+		switch name := u.Name.Name; {
+		case name == "bool":
+			sw.Do("if $.x$ {\nreturn false\n}\n", args)
+		case name == "string":
+			sw.Do("if $.x$ != \"\" {\nreturn false\n}\n", args)
+		case strings.Contains(name, "Pointer"):
+			sw.Do("if $.x$ != nil {\nreturn false\n}\n", args)
+		default:
+			sw.Do("if $.x$ != 0 {\nreturn false\n}\n", args)
+		}
+	case types.Array:
+		sw.Do("for $.i$ := range $.x$ {\n", args)
+		g.check(sw, x+"["+args["i"].(string)+"]", u.Elem, method, depth+1)
+		sw.Do("}\n", args)
+	case types.Struct:
+		// The structs without the method are checked member by member,
+		// but for those of other packages with unexported members.
+		local := u.Name.Package == "" || u.Name.Package == g.targetPackage
+		for _, m := range u.Members {
+			if !local && !token.IsExported(m.Name) {
+				sw.Do("if !$.valueOf|raw$($.x$).IsZero() {\nreturn false\n}\n", args)
+				return
+			}
+		}
+		for _, m := range u.Members {
+			mt := m.Type
+			if u.Name.Package == g.targetPackage {
+				mt = inlineMemberType(u, m)
+			}
+			g.check(sw, x+"."+m.Name, mt, method, depth)
+		}
+	default:
+		sw.Do("if !$.valueOf|raw$($.x$).IsZero() {\nreturn false\n}\n", args)
+	}
+}
+
+// omitsEmpty reports whether, with --is-zero, Build leaves the pointer member
+// m to the struct t nil when its nested builder builds an empty model, the
+// member being omitted from the JSON documents by omitempty.
+func (g *genDeepCopy) omitsEmpty(m types.Member, t *types.Type) bool {
+	if !g.customArgs.IsZero {
+		return false
+	}
+	_, options, _ := strings.Cut(reflect.StructTag(m.Tags).Get("json"), ",")
+	for _, option := range strings.Split(options, ",") {
+		if option == "omitempty" {
+			return generatesIsZero(g.customArgs, g.targetPackage, g.declared, t, isEmptyMethod) || declaresPredicate(t, isEmptyMethod)
+		}
+	}
+	return false
+}
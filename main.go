@@ -15,26 +15,101 @@
 package main
 
 import (
+	"flag"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+
+	"github.com/spf13/pflag"
 	"k8s.io/gengo/args"
 	"k8s.io/klog/v2"
 
+	"github.com/galgotech/builder-gen/builder"
 	"github.com/galgotech/builder-gen/generators"
 )
 
 func main() {
 	klog.InitFlags(nil)
-	arguments := args.Default()
+	arguments := args.Default().WithoutDefaultFlagParsing()
 
 	// Override defaults.
-	arguments.OutputFileBaseName = "zz_buildergen_generated"
+	arguments.OutputFileBaseName = builder.DefaultOutputFileBaseName
+
+	// Custom args.
+	customArgs := &generators.CustomArgs{}
+	arguments.CustomArgs = customArgs
+
+	arguments.AddFlags(pflag.CommandLine)
+	customArgs.AddFlags(pflag.CommandLine)
+	cpuProfile := pflag.String("cpuprofile", "", "If set, write a CPU profile of the generation to this file.")
+	memProfile := pflag.String("memprofile", "", "If set, write a heap profile to this file once the generation is done.")
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+	pflag.Parse()
+
+	if err := generators.Validate(arguments); err != nil {
+		klog.Fatalf("Error: %v", err)
+	}
 
 	// Run it.
-	if err := arguments.Execute(
-		generators.NameSystems(),
-		generators.DefaultNameSystem(),
-		generators.Packages,
-	); err != nil {
+	stopProfiles, err := startProfiles(*cpuProfile, *memProfile)
+	if err != nil {
 		klog.Fatalf("Error: %v", err)
 	}
+	err = generators.Execute(arguments)
+	if err := stopProfiles(); err != nil {
+		klog.Errorf("Failed writing the profiles: %v", err)
+	}
+	if err != nil {
+		klog.Fatalf("Error: %v", err)
+	}
+	summary := customArgs.Summary()
+	if klog.V(1).Enabled() {
+		var table strings.Builder
+		if err := summary.WriteTable(&table); err == nil {
+			klog.Info(strings.TrimSuffix(table.String(), "\n"))
+		}
+	} else {
+		klog.Info(summary.String())
+	}
 	klog.V(2).Info("Completed successfully.")
 }
+
+// startProfiles starts the CPU profile written to cpuProfile, when set, and
+// returns the function stopping it and writing the heap profile to
+// memProfile, when set.
+func startProfiles(cpuProfile, memProfile string) (func() error, error) {
+	var cpu *os.File
+	if cpuProfile != "" {
+		var err error
+		if cpu, err = os.Create(cpuProfile); err != nil {
+			return nil, err
+		}
+		if err := pprof.StartCPUProfile(cpu); err != nil {
+			cpu.Close()
+			return nil, err
+		}
+	}
+	return func() error {
+		if cpu != nil {
+			pprof.StopCPUProfile()
+			if err := cpu.Close(); err != nil {
+				return err
+			}
+		}
+		if memProfile == "" {
+			return nil
+		}
+		f, err := os.Create(memProfile)
+		if err != nil {
+			return err
+		}
+		// The profile is of the live objects, up to date after a collection.
+		runtime.GC()
+		if err := pprof.WriteHeapProfile(f); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}, nil
+}
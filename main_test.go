@@ -0,0 +1,50 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStartProfiles(t *testing.T) {
+	dir := t.TempDir()
+	cpuProfile, memProfile := filepath.Join(dir, "cpu.pprof"), filepath.Join(dir, "mem.pprof")
+	stopProfiles, err := startProfiles(cpuProfile, memProfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stopProfiles(); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{cpuProfile, memProfile} {
+		if info, err := os.Stat(name); err != nil || info.Size() == 0 {
+			t.Errorf("%s was not written (%v)", filepath.Base(name), err)
+		}
+	}
+
+	stopProfiles, err = startProfiles("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stopProfiles(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := startProfiles(filepath.Join(dir, "missing", "cpu.pprof"), ""); err == nil {
+		t.Error("startProfiles() of a profile in a missing directory succeeded")
+	}
+}
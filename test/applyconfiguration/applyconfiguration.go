@@ -0,0 +1,96 @@
+// Copyright 2023 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package applyconfiguration declares the apply configurations of the types
+// of the test package, as applyconfiguration-gen would, for the kubernetes
+// case of the golden test to set the builders from. The metadata of
+// TestObject is the one of apimachinery, the module not requiring client-go.
+package applyconfiguration
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type TestAApplyConfiguration struct {
+	TestB *TestBApplyConfiguration `json:"TestB,omitempty"`
+}
+
+type TestBApplyConfiguration struct {
+	TestBKey *string `json:"TestBKey,omitempty"`
+}
+
+type TestBuildNameApplyConfiguration struct {
+	Build *string `json:"Build,omitempty"`
+}
+
+type TestCApplyConfiguration struct {
+	Key *int `json:"Key,omitempty"`
+}
+
+type TestDocItemApplyConfiguration struct {
+	Label *string `json:"Label,omitempty"`
+}
+
+type TestGApplyConfiguration struct {
+	KeyG *int `json:"KeyG,omitempty"`
+}
+
+type TestIgnoredEmbeddedApplyConfiguration struct {
+	Value  *string `json:"Value,omitempty"`
+	Secret *string `json:"Secret,omitempty"`
+}
+
+type TestMutualAApplyConfiguration struct {
+	Key  *string                         `json:"Key,omitempty"`
+	List []TestMutualBApplyConfiguration `json:"List,omitempty"`
+}
+
+type TestMutualBApplyConfiguration struct {
+	Key    *string                        `json:"Key,omitempty"`
+	Parent *TestMutualAApplyConfiguration `json:"Parent,omitempty"`
+}
+
+type TestMutualCApplyConfiguration struct {
+	Inner *TestMutualDApplyConfiguration `json:"Inner,omitempty"`
+}
+
+type TestMutualDApplyConfiguration struct {
+	Outer *TestMutualCApplyConfiguration `json:"Outer,omitempty"`
+}
+
+type TestNodeApplyConfiguration struct {
+	Name     *string                               `json:"Name,omitempty"`
+	Parent   *TestNodeApplyConfiguration           `json:"Parent,omitempty"`
+	Children []*TestNodeApplyConfiguration         `json:"Children,omitempty"`
+	Siblings []TestNodeApplyConfiguration          `json:"Siblings,omitempty"`
+	Index    map[string]TestNodeApplyConfiguration `json:"Index,omitempty"`
+}
+
+type TestObjectApplyConfiguration struct {
+	Kind       *string                  `json:"kind,omitempty"`
+	APIVersion *string                  `json:"apiVersion,omitempty"`
+	Metadata   *metav1.ObjectMeta       `json:"metadata,omitempty"`
+	Spec       *TestBApplyConfiguration `json:"spec,omitempty"`
+}
+
+type TestRequiredApplyConfiguration struct {
+	Key      *string `json:"Key,omitempty"`
+	Tas      *int    `json:"Tas,omitempty"`
+	Optional *string `json:"Optional,omitempty"`
+}
+
+type TestStartApplyConfiguration struct {
+	StateName *string                  `json:"stateName,omitempty"`
+	Schedule  *TestBApplyConfiguration `json:"schedule,omitempty"`
+}
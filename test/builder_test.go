@@ -0,0 +1,49 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import "testing"
+
+func TestPointerSetterNil(t *testing.T) {
+	builder := NewTestNodeBuilder()
+	builder.fromModel(TestNode{Name: "child", Parent: &TestNode{Name: "parent"}})
+	if node := builder.SetParent(nil).Build(); node.Parent != nil {
+		t.Errorf("SetParent(nil) after fromModel built the parent %q", node.Parent.Name)
+	}
+
+	builder = NewTestNodeBuilder()
+	builder.Parent().Name("parent")
+	builder.Build()
+	if node := builder.SetParent(nil).Build(); node.Parent != nil {
+		t.Errorf("SetParent(nil) after Build built the parent %q", node.Parent.Name)
+	}
+
+	node := builder.SetParent(&TestNode{Name: "other"}).Build()
+	if node.Parent == nil || node.Parent.Name != "other" {
+		t.Errorf("SetParent(&TestNode{Name: \"other\"}) built the parent %v", node.Parent)
+	}
+}
+
+func TestSliceSetterCopy(t *testing.T) {
+	tags := make([]string, 1, 2)
+	tags[0] = "a"
+	built := NewTestPrimitiveSlicesBuilder().Tags(tags).AppendTags("b").Build()
+	if got := tags[:2][1]; got != "" {
+		t.Errorf("AppendTags after Tags wrote %q to the array of the caller", got)
+	}
+	if len(built.Tags) != 2 || built.Tags[0] != "a" || built.Tags[1] != "b" {
+		t.Errorf("AppendTags after Tags built %q", built.Tags)
+	}
+}
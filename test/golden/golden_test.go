@@ -0,0 +1,638 @@
+// Copyright 2023 The builder-gen Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package golden runs the generator against the fixture packages and compares
+// the generated files with the golden files of testdata. Run
+//
+//	go test ./test/golden/ -update
+//
+// to write the golden files again after changing the generator. The generated
+// files are vetted and tested in place of those of the fixtures before being
+// compared or written, and the tests run again with pinnedToolchain when the
+// gengo parser does not support the current toolchain.
+package golden
+
+import (
+	"bytes"
+	"flag"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/gengo/args"
+	deepcopy "k8s.io/gengo/examples/deepcopy-gen/generators"
+
+	"github.com/galgotech/builder-gen/builder"
+	"github.com/galgotech/builder-gen/generators"
+)
+
+var update = flag.Bool("update", false, "write the golden files instead of comparing them")
+
+// module is the path of the module of the fixtures, under which the generated
+// files are written.
+const module = "github.com/galgotech/builder-gen"
+
+// pinnedToolchain is the Go toolchain the test runs with when the gengo
+// parser does not support the current one.
+const pinnedToolchain = "go1.22.12"
+
+// fixtures are the packages the generator runs against.
+var fixtures = []string{
+	module + "/test",
+	module + "/test/other",
+}
+
+// versionFixtures add the API versions of test/versions to the fixtures.
+var versionFixtures = append([]string{
+	module + "/test/versions/v1alpha1",
+	module + "/test/versions/v1",
+}, fixtures...)
+
+// cases are the flag combinations tested, each with its golden files in
+// testdata/<name>, run against the fixtures unless they list their inputs.
+// Their generated files are vetted and their tests run, with the modules of
+// testdata/deps.mod for those importing modules the module does not require.
+var cases = []struct {
+	name   string
+	opts   builder.Options
+	inputs []string
+	// deps compiles the generated files with testdata/deps.mod.
+	deps bool
+	// golden names the case whose golden files the generated ones are
+	// compared with, the flags leaving them unchanged.
+	golden string
+	// existing names the case whose golden files are written to the output
+	// base before generating.
+	existing string
+	// printed compares what the generator prints, the files of the output
+	// base relative to it, with the golden stdout, the output base being
+	// left as it was.
+	printed bool
+	// inPlace generates from a copy of the fixtures under the output base, as
+	// --closure needs, the copy being the module of the inputs.
+	inPlace bool
+}{
+	{name: "default", opts: builder.Options{}},
+	{name: "yaml", opts: builder.Options{YAMLPackage: "sigs.k8s.io/yaml", UnmarshalJSON: true}},
+	{name: "setter-prefix", opts: builder.Options{SetterPrefix: "With", ConstructorPrefix: "Make", JSONSetterNames: true}},
+	{name: "equal", opts: builder.Options{Equal: true, AllArgsConstructors: true}},
+	{name: "smoke-tests", opts: builder.Options{SmokeTests: true}},
+	{name: "immutable-build", opts: builder.Options{ImmutableBuild: true}},
+	{name: "deep-copy", opts: builder.Options{DeepCopy: true, ImmutableBuild: true}},
+	{name: "is-zero", opts: builder.Options{IsZero: true}},
+	{name: "getters", opts: builder.Options{Getters: true}},
+	{name: "accumulate-errors", opts: builder.Options{AccumulateErrors: true, NewCallErrors: "record"}},
+	{name: "conditional-setters", opts: builder.Options{ConditionalSetters: true, SetterPrefix: "Set"}},
+	{name: "has-methods", opts: builder.Options{HasMethods: true}},
+	{name: "copy-on-write", opts: builder.Options{CopyOnWrite: true, ConditionalSetters: true}},
+	{name: "struct-validator", opts: builder.Options{StructValidator: true}, deps: true},
+	{name: "flatten-embedded", opts: builder.Options{FlattenEmbedded: true, SmokeTests: true}},
+	{name: "build-tags", opts: builder.Options{BuildTags: []string{"buildergen_tagged"}}},
+	{name: "ordered-maps", opts: builder.Options{OrderedMaps: true}},
+	{name: "type-filters", opts: builder.Options{IncludeTypes: "^(Test|Address|Geo)", ExcludeTypes: "^TestMutual|^Geo$"}},
+	{name: "go-version", opts: builder.Options{GoVersion: "1.20", AccumulateErrors: true}},
+	{name: "skip-packages", opts: builder.Options{SkipPackages: []string{module + "/test/o*"}}},
+	{name: "kubernetes", opts: builder.Options{Kubernetes: true, Config: &generators.Config{ApplyConfigurations: map[string]string{
+		module + "/test": module + "/test/applyconfiguration",
+	}}}, deps: true},
+	{name: "config", opts: builder.Options{SmokeTests: true, Config: &generators.Config{Types: map[string]generators.TypeConfig{
+		module + "/test.TestStart": {Handler: "union", String: "StateName"},
+	}, External: map[string]generators.ExternalType{
+		"k8s.io/apimachinery/pkg/util/intstr.IntOrString": {Setter: "dual-setter"},
+		module + "/test/other.Address":                    {Builder: module + "/test/other.AddressBuilder"},
+	}}}},
+	{name: "shared-generators", opts: builder.Options{Generators: []generators.SharedGenerator{deepCopyGen()}}},
+	{name: "json-schema", opts: builder.Options{JSONSchema: true}},
+	{name: "convert-versions", opts: builder.Options{ConvertVersions: true}, inputs: versionFixtures},
+	{name: "opt-in", opts: builder.Options{OptIn: true}},
+	{name: "closure", opts: builder.Options{Closure: true}, inputs: []string{module + "/test"}, inPlace: true},
+	{name: "input-group", opts: builder.Options{InputGroups: []generators.InputGroup{{
+		InputDirs:          []string{module + "/test/other"},
+		OutputFileBaseName: "zz_generated.other",
+		GoHeaderFilePath:   "../../boilerplate/boilerplate.go.txt",
+		SetterPrefix:       "Set",
+	}}}, inputs: []string{module + "/test"}},
+	{name: "parallelism", opts: builder.Options{Parallelism: 1}, golden: "default"},
+	{name: "dry-run", opts: builder.Options{DryRun: true, HasMethods: true}, existing: "default", printed: true},
+	{name: "stdout", opts: builder.Options{Stdout: true, IncludeTypes: "^(Address|Geo)$"}, printed: true},
+}
+
+// deepCopyGen returns deepcopy-gen, run on the fixtures parsed for the
+// builders by the shared-generators case.
+func deepCopyGen() generators.SharedGenerator {
+	arguments := args.Default().WithoutDefaultFlagParsing()
+	arguments.OutputFileBaseName = "zz_generated.deepcopy"
+	arguments.GoHeaderFilePath = "../../boilerplate/no-boilerplate.go.txt"
+	arguments.CustomArgs = &deepcopy.CustomArgs{}
+	return generators.SharedGenerator{
+		Name:              "deepcopy-gen",
+		Arguments:         arguments,
+		NameSystems:       deepcopy.NameSystems(),
+		DefaultNameSystem: deepcopy.DefaultNameSystem(),
+		Packages:          deepcopy.Packages,
+	}
+}
+
+func TestGolden(t *testing.T) {
+	if !supportedToolchain(runtime.Version()) {
+		runPinned(t)
+		return
+	}
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			out := t.TempDir()
+			opts := c.opts
+			opts.InputDirs = fixtures
+			if c.inputs != nil {
+				opts.InputDirs = c.inputs
+			}
+			tree := filepath.Join(out, filepath.FromSlash(module))
+			var copied map[string][]byte
+			if c.inPlace {
+				copied = copyFixtures(t, root, tree)
+			}
+			var existing map[string][]byte
+			if c.existing != "" {
+				existing = readTree(t, filepath.Join("testdata", c.existing))
+				for name, data := range existing {
+					writeFile(t, filepath.Join(tree, filepath.FromSlash(name)), data)
+				}
+			}
+			opts.OutputBase = out
+			opts.GoHeaderFilePath = filepath.Join(root, "boilerplate", "no-boilerplate.go.txt")
+			opts.Generators = append([]generators.SharedGenerator{}, opts.Generators...)
+			for i, gen := range opts.Generators {
+				genArgs := *gen.Arguments
+				genArgs.OutputBase = out
+				opts.Generators[i].Arguments = &genArgs
+			}
+
+			run := func() error { return builder.Run(opts) }
+			if c.inPlace {
+				run = func() error { return runIn(tree, opts) }
+			}
+			var generated map[string][]byte
+			if c.printed {
+				printed := captureStdout(t, run)
+				generated = map[string][]byte{"stdout": []byte(strings.ReplaceAll(printed, out+string(filepath.Separator), ""))}
+				if written := readTree(t, tree); !equalTrees(written, existing) {
+					t.Errorf("the output base changed, holding %d files instead of %d", len(written), len(existing))
+				}
+			} else {
+				if err := run(); err != nil {
+					t.Fatalf("generating: %v", err)
+				}
+				generated = readTree(t, tree)
+				for name, data := range copied {
+					if bytes.Equal(generated[name], data) {
+						delete(generated, name)
+					}
+				}
+				deps := ""
+				if c.deps {
+					deps = filepath.Join("testdata", "deps")
+				}
+				checkTree(t, root, generated, deps, nil)
+				if len(c.opts.BuildTags) > 0 {
+					checkTree(t, root, generated, deps, c.opts.BuildTags)
+				}
+			}
+
+			dir := filepath.Join("testdata", c.name)
+			if c.golden != "" {
+				dir = filepath.Join("testdata", c.golden)
+			} else if *update {
+				if !t.Failed() {
+					writeTree(t, dir, generated)
+				}
+				return
+			}
+			golden := readTree(t, dir)
+			for name, want := range golden {
+				got, ok := generated[name]
+				if !ok {
+					t.Errorf("%s was not generated", name)
+				} else if !bytes.Equal(got, want) {
+					t.Errorf("%s differs from its golden file, run with -update if the change is expected:\n%s", name, firstDifference(got, want))
+				}
+			}
+			for name := range generated {
+				if _, ok := golden[name]; !ok {
+					t.Errorf("%s has no golden file, run with -update to add it", name)
+				}
+			}
+		})
+	}
+}
+
+// runIn runs the generator in the directory dir, the module of the inputs
+// being the one of dir.
+func runIn(dir string, opts builder.Options) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if err := os.Chdir(dir); err != nil {
+		return err
+	}
+	defer os.Chdir(wd)
+	return builder.Run(opts)
+}
+
+// captureStdout returns what run prints to the standard output, failing if it
+// fails.
+func captureStdout(t *testing.T, run func() error) string {
+	t.Helper()
+	file, err := os.CreateTemp(t.TempDir(), "stdout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	stdout := os.Stdout
+	os.Stdout = file
+	err = run()
+	os.Stdout = stdout
+	if err != nil {
+		t.Fatalf("generating: %v", err)
+	}
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// equalTrees reports whether the trees a and b hold the same files.
+func equalTrees(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, data := range a {
+		if other, ok := b[name]; !ok || !bytes.Equal(data, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// TestImportCycle checks that the generation fails without writing any file
+// when the builders of test/cycle/a would import test/cycle/b, which imports
+// it.
+func TestImportCycle(t *testing.T) {
+	if !supportedToolchain(runtime.Version()) {
+		runPinned(t)
+		return
+	}
+	out := t.TempDir()
+	err := builder.Run(builder.Options{
+		InputDirs:        []string{module + "/test/cycle/a", module + "/test/cycle/b"},
+		OutputBase:       out,
+		GoHeaderFilePath: "../../boilerplate/no-boilerplate.go.txt",
+		Config: &generators.Config{External: map[string]generators.ExternalType{
+			module + "/test/other.Address": {Builder: module + "/test/cycle/b.AddressBuilder"},
+		}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "import cycle") {
+		t.Fatalf("generating: got %v, want an import cycle error", err)
+	}
+	if written := readTree(t, out); len(written) > 0 {
+		names := make([]string, 0, len(written))
+		for name := range written {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		t.Errorf("the import cycle did not prevent writing %s", strings.Join(names, ", "))
+	}
+}
+
+// TestCacheFile checks that a run with --cache-file after a first one leaves
+// the files of the unchanged packages as they were without generating them,
+// and that changing the flags generates them again.
+func TestCacheFile(t *testing.T) {
+	if !supportedToolchain(runtime.Version()) {
+		runPinned(t)
+		return
+	}
+	out := t.TempDir()
+	tree := filepath.Join(out, filepath.FromSlash(module))
+	opts := builder.Options{
+		InputDirs:        fixtures,
+		OutputBase:       out,
+		GoHeaderFilePath: "../../boilerplate/no-boilerplate.go.txt",
+		CacheFile:        filepath.Join(t.TempDir(), "cache.json"),
+	}
+	if _, err := builder.RunSummary(opts); err != nil {
+		t.Fatalf("generating: %v", err)
+	}
+	written := readTree(t, tree)
+	if !equalTrees(written, readTree(t, filepath.Join("testdata", "default"))) {
+		t.Fatalf("the first run did not generate the golden files of the default case")
+	}
+	modTimes := map[string]time.Time{}
+	for name := range written {
+		info, err := os.Stat(filepath.Join(tree, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		modTimes[name] = info.ModTime()
+	}
+
+	summary, err := builder.RunSummary(opts)
+	if err != nil {
+		t.Fatalf("generating again: %v", err)
+	}
+	for _, pkg := range summary.Packages {
+		if !pkg.Cached {
+			t.Errorf("%s was generated again", pkg.Package)
+		}
+	}
+	if !equalTrees(readTree(t, tree), written) {
+		t.Errorf("the cached run changed the generated files")
+	}
+	for name, modTime := range modTimes {
+		if info, err := os.Stat(filepath.Join(tree, filepath.FromSlash(name))); err != nil || !info.ModTime().Equal(modTime) {
+			t.Errorf("the cached run wrote %s again", name)
+		}
+	}
+
+	opts.HasMethods = true
+	if summary, err = builder.RunSummary(opts); err != nil {
+		t.Fatalf("generating with --has-methods: %v", err)
+	}
+	for _, pkg := range summary.Packages {
+		if pkg.Cached {
+			t.Errorf("%s was not generated again with --has-methods", pkg.Package)
+		}
+	}
+	if !equalTrees(readTree(t, tree), readTree(t, filepath.Join("testdata", "has-methods"))) {
+		t.Errorf("the run with --has-methods did not generate the golden files of the has-methods case")
+	}
+}
+
+// TestStrict checks that --strict fails on the members of the fixtures the
+// builders can't handle.
+func TestStrict(t *testing.T) {
+	if !supportedToolchain(runtime.Version()) {
+		runPinned(t)
+		return
+	}
+	err := builder.Run(builder.Options{
+		InputDirs:        fixtures,
+		OutputBase:       t.TempDir(),
+		GoHeaderFilePath: "../../boilerplate/no-boilerplate.go.txt",
+		Strict:           true,
+	})
+	if err == nil {
+		t.Fatal("generating with --strict succeeded")
+	}
+	for _, want := range []string{"TestUnsupported.Callback: func members are not supported", "TestUnsupported.Signals: chan members are not supported"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("generating with --strict: got %v, want an error reporting %q", err, want)
+		}
+	}
+}
+
+// runPinned runs the test again with pinnedToolchain, failing when it fails.
+func runPinned(t *testing.T) {
+	if os.Getenv("GOTOOLCHAIN") == pinnedToolchain {
+		t.Fatalf("the gengo parser does not support the type aliases of %s, and GOTOOLCHAIN=%s did not replace it", runtime.Version(), pinnedToolchain)
+	}
+	// The fixtures and the golden files are read by the other run, read
+	// them here too for the test cache to see their changes.
+	readTree(t, "..")
+	run := flag.Lookup("test.run").Value.String()
+	if run == "" {
+		run = "^" + t.Name() + "$"
+	}
+	args := []string{"test", "-mod=readonly", "-count=1", "-run", run}
+	if testing.Verbose() {
+		args = append(args, "-v")
+	}
+	args = append(args, ".")
+	if *update {
+		args = append(args, "-args", "-update")
+	}
+	cmd := exec.Command("go", args...)
+	cmd.Env = append(os.Environ(), "GOTOOLCHAIN="+pinnedToolchain)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("the gengo parser does not support the type aliases of %s, the test failed with %s: %v\n%s", runtime.Version(), pinnedToolchain, err, output)
+	}
+	t.Logf("the gengo parser does not support the type aliases of %s, the test ran with %s:\n%s", runtime.Version(), pinnedToolchain, output)
+}
+
+// generatedComment is the comment of the generated Go files.
+var generatedComment = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+// checkTree runs go vet with tags on the packages the Go files of generated
+// are generated into, and their tests, in a copy of the module under root
+// holding them in place of the generated files of its fixtures. The go.mod and
+// go.sum of the copy are deps+".mod" and deps+".sum" when deps is not empty.
+func checkTree(t *testing.T, root string, generated map[string][]byte, deps string, tags []string) {
+	t.Helper()
+	tree := t.TempDir()
+	copyFixtures(t, root, tree)
+	if deps != "" {
+		for _, ext := range []string{".mod", ".sum"} {
+			data, err := os.ReadFile(deps + ext)
+			if err != nil {
+				t.Fatal(err)
+			}
+			writeFile(t, filepath.Join(tree, "go"+ext), data)
+		}
+	}
+	dirs := map[string]bool{}
+	for name, data := range generated {
+		if strings.HasSuffix(name, ".go") {
+			writeFile(t, filepath.Join(tree, filepath.FromSlash(name)), data)
+			dirs["./"+path.Dir(name)] = true
+		}
+	}
+	packages := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		packages = append(packages, dir)
+	}
+	sort.Strings(packages)
+
+	for _, command := range []string{"vet", "test"} {
+		args := []string{command, "-mod=readonly"}
+		if command == "test" {
+			args = append(args, "-count=1")
+		}
+		if len(tags) > 0 {
+			args = append(args, "-tags", strings.Join(tags, ","))
+		}
+		cmd := exec.Command("go", append(args, packages...)...)
+		cmd.Dir = tree
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("running go %s on the generated files with the tags %q: %v\n%s", command, tags, err, output)
+		}
+	}
+}
+
+// copyFixtures copies the go.mod and go.sum of the module under root, its
+// boilerplate files and the Go files of its fixtures, less their generated
+// files, to tree, returning
+// the copied files keyed by their slash-separated path relative to tree. The
+// tests of the fixtures, written against the default builders, are not
+// copied.
+func copyFixtures(t *testing.T, root, tree string) map[string][]byte {
+	t.Helper()
+	copied := map[string][]byte{}
+	for _, name := range []string{"go.mod", "go.sum"} {
+		data, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, filepath.Join(tree, name), data)
+		copied[name] = data
+	}
+	err := filepath.WalkDir(filepath.Join(root, "test"), func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(name, ".go") {
+			if err == nil && d.IsDir() && d.Name() == "golden" {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if strings.HasSuffix(name, "_test.go") {
+			return nil
+		}
+		data, err := os.ReadFile(name)
+		if err != nil || generatedComment.Match(data) {
+			return err
+		}
+		rel, err := filepath.Rel(root, name)
+		if err != nil {
+			return err
+		}
+		writeFile(t, filepath.Join(tree, rel), data)
+		copied[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("copying the fixtures: %v", err)
+	}
+	// The boilerplate tags of the fixtures name the boilerplate files of the
+	// module.
+	boilerplates, err := filepath.Glob(filepath.Join(root, "boilerplate", "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range boilerplates {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rel := path.Join("boilerplate", filepath.Base(name))
+		writeFile(t, filepath.Join(tree, filepath.FromSlash(rel)), data)
+		copied[rel] = data
+	}
+	return copied
+}
+
+// supportedToolchain reports whether the parser of gengo handles the types of
+// the Go version, older than go1.27 which reports the type aliases.
+func supportedToolchain(version string) bool {
+	if !strings.HasPrefix(version, "go1.") {
+		return false
+	}
+	minor := strings.TrimPrefix(version, "go1.")
+	if i := strings.IndexAny(minor, ".rcbeta"); i >= 0 {
+		minor = minor[:i]
+	}
+	n, err := strconv.Atoi(minor)
+	return err == nil && n < 27
+}
+
+// readTree returns the files under dir, keyed by their slash-separated path
+// relative to dir, the ".golden" extension removed.
+func readTree(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+	files := map[string][]byte{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[strings.TrimSuffix(filepath.ToSlash(rel), ".golden")] = data
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("reading %s: %v", dir, err)
+	}
+	return files
+}
+
+// writeTree replaces the golden files of dir with files.
+func writeTree(t *testing.T, dir string, files map[string][]byte) {
+	t.Helper()
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name)+".golden")
+		writeFile(t, path, data)
+	}
+}
+
+// writeFile writes data to the file path, creating its directory.
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// firstDifference describes the first line where got and want differ.
+func firstDifference(got, want []byte) string {
+	gotLines := strings.Split(string(got), "\n")
+	wantLines := strings.Split(string(want), "\n")
+	for i := 0; i < len(gotLines) || i < len(wantLines); i++ {
+		var g, w string
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w {
+			return "line " + strconv.Itoa(i+1) + ":\n\tgot:  " + g + "\n\twant: " + w
+		}
+	}
+	return ""
+}
@@ -0,0 +1,29 @@
+// Copyright 2023 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+// Hand-written builder functions and methods, builder-gen skips generating
+// them.
+
+func NewTestDBuilder() *TestDBuilder {
+	builder := &TestDBuilder{}
+	builder.model.KeyD = 1
+	return builder
+}
+
+func (b *TestEBuilder) KeyE(input int) *TestEBuilder {
+	b.model.KeyE = input * 2
+	return b
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package other
+
+// Address is a postal address.
+type Address struct {
+	// Street of the address.
+	Street string
+	Geo    *Geo
+}
+
+// Zone names a geographic zone.
+type Zone string
+
+// Geo is a geographic position.
+//
+// +builder-gen:setter-prefix=
+// +builder-gen:build-name=Locate
+type Geo struct {
+	Lat, Lng float64
+}
@@ -15,15 +15,22 @@
 package test
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/galgotech/builder-gen/test/other"
 )
 
 type TestBAlias = []*TestB
 type TestBAliasMap = map[string]*TestB
 type TestJsonAlias = json.RawMessage
 
+// +builder-gen=true
 type Test struct {
 	Key              string
 	Tas              int
@@ -39,6 +46,7 @@ type Test struct {
 	TestJsonAlias TestJsonAlias
 }
 
+// +builder-gen:true
 // +builder-gen:new-call=Test1Tag,Test2Tag
 type TestA struct {
 	TestB TestB
@@ -66,10 +74,12 @@ type TestC struct {
 	Key int
 }
 
+// +builder-gen=true
 type TestD struct {
 	KeyD int
 }
 
+// +builder-gen=true
 type TestE struct {
 	*TestD
 	KeyE  int
@@ -84,3 +94,605 @@ type TestF struct {
 type TestG struct {
 	KeyG int
 }
+
+type TestH struct {
+	// +builder-gen:embedded-ignore-method
+	TestE
+}
+
+// +builder-gen:embedded-ignore-method=TestE.TestD
+type TestI struct {
+	TestE
+}
+
+// +builder-gen:embedded-value=TestG
+type TestEmbeddedValue struct {
+	// +builder-gen:embedded-value
+	TestD
+	*TestG
+	Name string
+}
+
+type TestPromotedA struct {
+	Name string
+	Size int
+}
+
+type TestPromotedB struct {
+	Name  string
+	Color string
+}
+
+// TestPromoted embeds two structs with a Name member, its builder has no
+// Name setter like the model has no promoted Name field.
+type TestPromoted struct {
+	TestPromotedA
+	TestPromotedB
+}
+
+// TestMixin gets the setters of TestB through its Spec member.
+//
+// +builder-gen:mixin=TestB
+type TestMixin struct {
+	Spec     TestB
+	Replicas int
+}
+
+// +builder-gen:mixin=github.com/galgotech/builder-gen/test/other.Address
+type TestMixinForeign struct {
+	Name    string
+	Address other.Address
+}
+
+type TestFlattenBase struct {
+	Name   string
+	Tags   []string
+	Labels map[string]string
+	Owner  *string
+}
+
+type TestFlatten struct {
+	TestFlattenBase
+	Replicas int
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +builder-gen:gvk=test.builder-gen.io/v1,Kind=TestObject
+type TestObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TestB `json:"spec"`
+}
+
+func (in *TestObject) DeepCopyObject() runtime.Object {
+	out := *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	return &out
+}
+
+// TestMetadata holds its metadata by pointer, the setters of its fields but
+// Name, taken by the member, allocating it.
+type TestMetadata struct {
+	Metadata *metav1.ObjectMeta `json:"metadata,omitempty"`
+	Name     string
+}
+
+// TestDeepCopied also gets the deep-copy functions of deepcopy-gen, run with
+// the builders by the shared-generators golden case.
+//
+// +k8s:deepcopy-gen=true
+type TestDeepCopied struct {
+	Name   string
+	Tags   []string
+	Labels map[string]string
+	Count  *int
+}
+
+type TestNode struct {
+	Name     string
+	Parent   *TestNode
+	Children []*TestNode
+	Siblings []TestNode
+	Index    map[string]TestNode
+}
+
+type TestMutualA struct {
+	Key  string
+	List []TestMutualB
+}
+
+type TestMutualB struct {
+	Key    string
+	Parent *TestMutualA
+}
+
+type TestMutualC struct {
+	Inner TestMutualD
+}
+
+type TestMutualD struct {
+	Outer *TestMutualC
+}
+
+type TestConflict struct {
+	Build       string
+	BuildObject int
+	Model       TestB
+	B           *TestB
+	Input       []TestB
+}
+
+type TestConflictEmbedded struct {
+	TestConflict
+}
+
+type TestMeta = metav1.ObjectMeta
+type TestMetaList []TestMeta
+type TestMetaPtr *TestMeta
+
+type TestForeignAlias struct {
+	Meta        TestMeta
+	MetaPointer *TestMeta
+	Metas       []TestMeta
+	MetaList    TestMetaList
+	MetaPtr     TestMetaPtr
+	MetaMap     map[string]TestMeta
+	Ignored     TestC
+	IgnoredList []*TestC
+}
+
+type TestBSlice []*TestB
+type TestBSliceAlias = TestBSlice
+type TestZoneMap map[other.Zone]*TestB
+type TestZoneMapAlias = TestZoneMap
+type TestMetaListAlias = TestMetaList
+
+type TestAliasChain struct {
+	Slice   TestBSliceAlias
+	Zones   TestZoneMapAlias
+	ZoneMap map[other.Zone]*TestB
+	Metas   TestMetaListAlias
+}
+
+type TestSlicePointers struct {
+	Items        *[]TestB
+	ItemPointers *[]*TestB
+	ItemMap      *map[string]TestB
+	Names        *[]string
+}
+
+type TestMapSlices struct {
+	Labels          []map[string]string
+	Items           []map[string]TestB
+	ItemPointers    []map[string]*TestB
+	Zones           []TestZoneMap
+	ForeignMetadata []map[string]TestMeta
+}
+
+type TestRow []TestB
+
+type TestSliceSlices struct {
+	Grid     [][]TestB
+	Pointers [][]*TestB
+	Cubes    [][][]TestB
+	Rows     []TestRow
+	Foreign  [][]other.Address
+}
+
+type TestMapLists struct {
+	ByName map[string][]*TestB
+	Values map[string][]TestB
+	ByKind map[TestKind]TestRow
+	Nested map[string][][]TestB
+	Labels map[string][]string
+}
+
+type TestNamedLists struct {
+	Row      TestRow
+	Optional *TestRow
+}
+
+type TestKind string
+
+type TestMapKeys struct {
+	ByID    map[int32]TestB
+	ByKind  map[TestKind]*TestB
+	ByZone  map[other.Zone]TestB
+	Counts  map[TestKind]int
+	Enabled map[uint16]bool
+}
+
+type TestCoord struct {
+	X, Y int
+}
+
+type TestCell struct {
+	Value string
+}
+
+type TestGrid struct {
+	Cells   map[TestCoord]TestCell
+	Marks   map[TestCoord]bool
+	Regions map[other.Geo]*TestCell
+}
+
+type TestIgnoredMembers struct {
+	Key      string
+	Internal string `json:"internal" builder:"-"`
+	// +builder-gen:ignore=true
+	Cache  *TestB
+	Nested TestIgnoredEmbedded
+	TestIgnoredEmbedded
+}
+
+type TestIgnoredEmbedded struct {
+	Value  string
+	Secret string `builder:"-"`
+}
+
+type TestJSONNames struct {
+	DisplayName string            `json:"display_name"`
+	APIVersion  string            `json:"apiVersion,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Items       []TestB           `json:"items"`
+	Hidden      string            `json:"-"`
+	Plain       string
+	Built       string `json:"build"`
+}
+
+// TestDoc is a documented type, its comments are copied to the builder.
+//
+// +builder-gen:new-call=TestTag
+type TestDoc struct {
+	// Name is the display name.
+	Name string
+	// Price is the amount in $ cents.
+	//
+	// +optional
+	Price int
+	// Items are the nested documented builders.
+	Items []TestDocItem
+	// Item is the main item.
+	Item *TestDocItem
+	*TestD
+}
+
+func (t *TestDoc) TestTag() {
+
+}
+
+// TestDocItem is an item of TestDoc.
+type TestDocItem struct {
+	// Label identifies the item.
+	Label string
+}
+
+// TestUnsupported has members the builder reports instead of setting.
+type TestUnsupported struct {
+	Key       string
+	Any       interface{}
+	Fixed     [2]int
+	Callback  func()
+	Signals   chan int
+	Listeners [2]TestB
+}
+
+// TestClosure references structs of another package of the module, they get
+// builders with --closure.
+type TestClosure struct {
+	Home      other.Address
+	Work      *other.Address
+	Previous  []other.Address
+	Locations map[string]*other.Geo
+}
+
+// TestRequired can only be built with its key and tas.
+type TestRequired struct {
+	// +builder-gen:required
+	Key string
+	// +builder-gen:required
+	Tas      int
+	Optional string
+}
+
+// TestRequiredParent holds a type with required members, set as values.
+type TestRequiredParent struct {
+	Child    TestRequired
+	Children []*TestRequired
+}
+
+// TestLabels is a named slice of primitives.
+type TestLabels []string
+
+// TestPrimitiveSlices has slices of primitive values.
+type TestPrimitiveSlices struct {
+	Tags   []string
+	Ports  []int
+	Labels TestLabels
+	Data   []byte
+	// +builder-gen:encoding=base64
+	Blob []byte
+}
+
+// TestPrimitiveMaps has maps of primitive values.
+type TestPrimitiveMaps struct {
+	Annotations map[string]string
+	Weights     map[int]float64
+	Flags       TestFlags
+}
+
+// TestFlags is a named map of primitives.
+type TestFlags map[string]bool
+
+// TestAnonymous has members of anonymous struct types.
+type TestAnonymous struct {
+	Name string
+	Spec struct {
+		Replicas int
+		Image    string
+	}
+	Status *struct {
+		Ready bool
+	}
+	Containers []struct {
+		Name  string `json:"name"`
+		Ports struct {
+			HTTP int
+		}
+	}
+}
+
+// TestExtension has extension data members of interface types.
+type TestExtension struct {
+	Extra any
+	// Config holds a decoded JSON document.
+	//
+	// +builder-gen:json
+	Config   interface{}
+	Stringer fmt.Stringer
+}
+
+// TestState is the state of a TestMachine.
+type TestState interface {
+	StateName() string
+}
+
+// TestIdle is a TestState held by value.
+type TestIdle struct {
+	Name string
+}
+
+func (s TestIdle) StateName() string {
+	return s.Name
+}
+
+// TestEnd is a TestState held by pointer.
+type TestEnd struct {
+	Code int
+}
+
+func (s *TestEnd) StateName() string {
+	return "end"
+}
+
+// TestMachine has interface members set by the builders of their
+// implementations.
+type TestMachine struct {
+	// +builder-gen:implementations=TestIdle,TestEnd
+	State TestState
+	// +builder-gen:implementations=TestB
+	Next any
+}
+
+// TestValidated has members checked by BuildSafe.
+type TestValidated struct {
+	// +builder-gen:validate=nonempty
+	// +builder-gen:validate=regexp=^[a-z][a-z0-9-]*$
+	Name string
+	// +builder-gen:validate=min=1
+	// +builder-gen:validate=max=10
+	Replicas int
+	// +builder-gen:validate=max=3
+	Tags []string
+	// +builder-gen:validate=nonempty
+	// +builder-gen:validate=min=0.5
+	Ratio *float64
+	Notes string
+}
+
+// TestStructValidated carries the validate struct tags of
+// github.com/go-playground/validator.
+type TestStructValidated struct {
+	Email string `json:"email" validate:"required,email"`
+	Age   int    `json:"age" validate:"gte=0,lte=130"`
+}
+
+// TestNewCallError is initialized by a method which may fail.
+//
+// +builder-gen:new-call=Init
+type TestNewCallError struct {
+	ID string
+}
+
+func (t *TestNewCallError) Init() error {
+	t.ID = "default"
+	return nil
+}
+
+// TestNewFunc is created by its canonical constructor.
+//
+// +builder-gen:new-func=NewTestNewFunc
+type TestNewFunc struct {
+	Name    string
+	version int
+}
+
+func NewTestNewFunc() *TestNewFunc {
+	return &TestNewFunc{version: 1}
+}
+
+// TestNewFuncError is created by a constructor which may fail.
+//
+// +builder-gen:new-func=NewTestNewFuncError
+type TestNewFuncError struct {
+	Name string
+}
+
+func NewTestNewFuncError() (TestNewFuncError, error) {
+	return TestNewFuncError{Name: "default"}, nil
+}
+
+// TestBuildName has a member named Build, its builder returning the model
+// from ToModel.
+//
+// +builder-gen:build-name=ToModel
+type TestBuildName struct {
+	Build string
+}
+
+// TestBuildNameNested holds a TestBuildName, built by its ToModel.
+type TestBuildNameNested struct {
+	Build TestBuildName
+	Ptr   *TestBuildName
+	Steps []TestBuildName
+}
+
+// TestBuildHook is enriched by its hooks when built with a context.
+//
+// +builder-gen:build-hook=Enrich,Check
+type TestBuildHook struct {
+	ID   string
+	Name string
+}
+
+func (t *TestBuildHook) Enrich(ctx context.Context) error {
+	if t.Name == "" {
+		t.Name = t.ID
+	}
+	return nil
+}
+
+func (t TestBuildHook) Check(ctx context.Context) error {
+	if t.ID == "" {
+		return fmt.Errorf("missing ID")
+	}
+	return nil
+}
+
+// TestCap has its slices and maps allocated with the capacity of their tags.
+type TestCap struct {
+	// +builder-gen:cap=16
+	Items []TestB
+	// +builder-gen:cap=8
+	Index map[string]*TestB
+	// +builder-gen:cap=32
+	Tags []string
+	// +builder-gen:cap=4
+	Labels map[string]string
+}
+
+// TestOneof has groups of mutually exclusive members, each setter clearing
+// the other members of its group.
+//
+// +builder-gen:oneof=Event,Operations,Sleep
+// +builder-gen:oneof=Labels,Selector
+type TestOneof struct {
+	Name       string
+	Event      *TestB
+	Operations []TestB
+	Sleep      string
+	Labels     map[string]string
+	Selector   fmt.Stringer
+}
+
+// TestInitialisms has members named with initialisms, upper-cased in the
+// names of the setters.
+type TestInitialisms struct {
+	Id      string
+	UserId  string
+	HttpUrl string
+	Ids     []string
+}
+
+// TestKeywords has members lowering to Go keywords, the builder fields and
+// local variables holding them escaped.
+type TestKeywords struct {
+	Type    string
+	Func    string
+	Range   []TestB
+	Go      *TestB
+	Select  map[string]TestB
+	Default TestB
+	Map     map[string]string
+	Chan    int
+}
+
+// testMessageState stands for the protoimpl.MessageState of the messages of
+// protoc-gen-go.
+type testMessageState struct {
+	initialized bool
+}
+
+// TestMessage mimics a message generated by protoc-gen-go, its builder
+// leaving the internal members alone and setting each field of the Value
+// oneof.
+type TestMessage struct {
+	state            testMessageState
+	sizeCache        int32
+	unknownFields    []byte
+	XXX_unrecognized []byte
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Types that are assignable to Value:
+	//
+	//	*TestMessage_Number
+	//	*TestMessage_Text
+	//	*TestMessage_Child
+	Value isTestMessage_Value `protobuf_oneof:"value"`
+}
+
+type isTestMessage_Value interface {
+	isTestMessage_Value()
+}
+
+type TestMessage_Number struct {
+	Number float64 `protobuf:"fixed64,2,opt,name=number,proto3,oneof"`
+}
+
+type TestMessage_Text struct {
+	Text string `protobuf:"bytes,3,opt,name=text,proto3,oneof"`
+}
+
+type TestMessage_Child struct {
+	Child *TestB `protobuf:"bytes,4,opt,name=child,proto3,oneof"`
+}
+
+func (*TestMessage_Number) isTestMessage_Value() {}
+
+func (*TestMessage_Text) isTestMessage_Value() {}
+
+func (*TestMessage_Child) isTestMessage_Value() {}
+
+// TestStart is a "string or object" union like those of the Serverless
+// Workflow model, decoded from the name of a state or from an object.
+type TestStart struct {
+	StateName string `json:"stateName"`
+	Schedule  *TestB `json:"schedule,omitempty"`
+}
+
+func (s *TestStart) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*s = TestStart{StateName: name}
+		return nil
+	}
+	type start TestStart
+	return json.Unmarshal(data, (*start)(s))
+}
+
+type TestWorkflow struct {
+	ID    string     `json:"id"`
+	Start *TestStart `json:"start,omitempty"`
+	Init  TestStart  `json:"init"`
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 The Serverless Workflow Specification Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package v1alpha1 is the first API version of the types converted by the
+// builders to the other versions of test/versions.
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// Phase is the lifecycle phase of a widget.
+type Phase string
+
+// Zone names the zone of a widget part.
+type Zone string
+
+// Widget is declared by every version.
+type Widget struct {
+	metav1.ObjectMeta
+	Replicas   int32
+	Phase      Phase
+	Spec       WidgetSpec
+	Parts      []WidgetPart
+	Owner      *WidgetPart
+	Components map[Zone]*WidgetPart
+	Tags       map[string]string
+	// Deprecated is removed by v1.
+	Deprecated bool
+}
+
+// WidgetSpec is the desired state of a widget.
+type WidgetSpec struct {
+	Size  int
+	Color string
+}
+
+// WidgetPart is a part of a widget.
+type WidgetPart struct {
+	ID     string
+	Weight float64
+}
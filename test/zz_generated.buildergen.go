@@ -6,29 +6,82 @@
 package test
 
 import (
+	context "context"
+	base64 "encoding/base64"
 	json "encoding/json"
+	errors "errors"
+	fmt "fmt"
+	reflect "reflect"
+	regexp "regexp"
+	strings "strings"
 
+	other "github.com/galgotech/builder-gen/test/other"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
+	yaml "sigs.k8s.io/yaml"
 )
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+// builderErrors are the errors recorded by the builders of the package.
+type builderErrors []error
+
+func (errs builderErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the errors, for errors.Is and errors.As.
+func (errs builderErrors) Unwrap() []error {
+	return errs
+}
+
+// err returns nil without errors, the error when there is only one.
+func (errs builderErrors) err() error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return errs
+}
+
+// NewTestBuilder creates a builder for Test.
 func NewTestBuilder() *TestBuilder {
 	builder := &TestBuilder{}
 	builder.model = Test{}
 	builder.testa = NewTestABuilder()
 	builder.testblist = []*TestBBuilder{}
+	builder.testbmap = map[string]*TestBBuilder{}
 	builder.testblistpointer = []*TestBBuilder{}
 	builder.testbalias = []*TestBBuilder{}
+	builder.testbaliasmap = map[string]*TestBBuilder{}
 	return builder
 }
 
+// NewTestBuilderFromYAML creates a builder for Test from the YAML document data.
+func NewTestBuilderFromYAML(data []byte) (*TestBuilder, error) {
+	builder := NewTestBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
 type TestBuilder struct {
 	model            Test
 	testa            *TestABuilder
 	testb            *TestBBuilder
 	testblist        []*TestBBuilder
+	testbmap         map[string]*TestBBuilder
 	testblistpointer []*TestBBuilder
 	testbalias       []*TestBBuilder
+	testbaliasmap    map[string]*TestBBuilder
 }
 
 func (b *TestBuilder) Key(input string) *TestBuilder {
@@ -57,12 +110,42 @@ func (b *TestBuilder) TestB() *TestBBuilder {
 	return b.testb
 }
 
+// SetTestB sets TestB to a copy of the value input points to, nil
+// if input is nil.
+func (b *TestBuilder) SetTestB(input *TestB) *TestBuilder {
+	b.testb = nil
+	b.model.TestB = nil
+	if input != nil {
+		b.testb = NewTestBBuilder()
+		b.testb.fromModel(*input)
+	}
+	return b
+}
+
+// SetTestBListFrom replaces the builders of TestBList by builders of the
+// elements of items.
+func (b *TestBuilder) SetTestBListFrom(items []TestB) *TestBuilder {
+	b.testblist = make([]*TestBBuilder, 0, len(items))
+	for _, item := range items {
+		builder := NewTestBBuilder()
+		builder.fromModel(item)
+		b.testblist = append(b.testblist, builder)
+	}
+	return b
+}
+
 func (b *TestBuilder) AddTestBList() *TestBBuilder {
 	builder := NewTestBBuilder()
 	b.testblist = append(b.testblist, builder)
 	return builder
 }
 
+// AddTestBListWith calls build with the builder AddTestBList appends.
+func (b *TestBuilder) AddTestBListWith(build func(*TestBBuilder)) *TestBuilder {
+	build(b.AddTestBList())
+	return b
+}
+
 func (b *TestBuilder) RemoveTestBList(remove *TestBBuilder) {
 	for i, val := range b.testblist {
 		if val == remove {
@@ -72,7 +155,44 @@ func (b *TestBuilder) RemoveTestBList(remove *TestBBuilder) {
 	}
 }
 func (b *TestBuilder) TestBMap(input map[string]TestB) *TestBuilder {
-	b.model.TestBMap = input
+	b.testbmap = map[string]*TestBBuilder{}
+	for k, v := range input {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.testbmap[k] = builder
+	}
+	return b
+}
+
+func (b *TestBuilder) AddTestBMap(key string) *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.testbmap[key] = builder
+	return builder
+}
+
+// AddTestBMapWith calls build with the builder AddTestBMap sets for key.
+func (b *TestBuilder) AddTestBMapWith(key string, build func(*TestBBuilder)) *TestBuilder {
+	build(b.AddTestBMap(key))
+	return b
+}
+
+// RemoveTestBMap drops the builder of key from TestBMap.
+func (b *TestBuilder) RemoveTestBMap(key string) {
+	delete(b.testbmap, key)
+}
+
+// SetTestBListPointerFrom replaces the builders of TestBListPointer by builders of the
+// elements of items.
+func (b *TestBuilder) SetTestBListPointerFrom(items []*TestB) *TestBuilder {
+	b.testblistpointer = make([]*TestBBuilder, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*item)
+		b.testblistpointer = append(b.testblistpointer, builder)
+	}
 	return b
 }
 
@@ -82,6 +202,12 @@ func (b *TestBuilder) AddTestBListPointer() *TestBBuilder {
 	return builder
 }
 
+// AddTestBListPointerWith calls build with the builder AddTestBListPointer appends.
+func (b *TestBuilder) AddTestBListPointerWith(build func(*TestBBuilder)) *TestBuilder {
+	build(b.AddTestBListPointer())
+	return b
+}
+
 func (b *TestBuilder) RemoveTestBListPointer(remove *TestBBuilder) {
 	for i, val := range b.testblistpointer {
 		if val == remove {
@@ -90,12 +216,35 @@ func (b *TestBuilder) RemoveTestBListPointer(remove *TestBBuilder) {
 		}
 	}
 }
+
+// SetTestBAliasFrom replaces the builders of TestBAlias by builders of the
+// elements of items.
+func (b *TestBuilder) SetTestBAliasFrom(items []*TestB) *TestBuilder {
+	b.testbalias = make([]*TestBBuilder, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*item)
+		b.testbalias = append(b.testbalias, builder)
+	}
+	return b
+}
+
+// TestBListPointerPointer []**TestB
 func (b *TestBuilder) AddTestBAlias() *TestBBuilder {
 	builder := NewTestBBuilder()
 	b.testbalias = append(b.testbalias, builder)
 	return builder
 }
 
+// AddTestBAliasWith calls build with the builder AddTestBAlias appends.
+func (b *TestBuilder) AddTestBAliasWith(build func(*TestBBuilder)) *TestBuilder {
+	build(b.AddTestBAlias())
+	return b
+}
+
 func (b *TestBuilder) RemoveTestBAlias(remove *TestBBuilder) {
 	for i, val := range b.testbalias {
 		if val == remove {
@@ -105,15 +254,45 @@ func (b *TestBuilder) RemoveTestBAlias(remove *TestBBuilder) {
 	}
 }
 func (b *TestBuilder) TestBAliasMap(input map[string]*TestB) *TestBuilder {
-	b.model.TestBAliasMap = input
+	b.testbaliasmap = map[string]*TestBBuilder{}
+	for k, v := range input {
+		if v == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*v)
+		b.testbaliasmap[k] = builder
+	}
+	return b
+}
+
+func (b *TestBuilder) AddTestBAliasMap(key string) *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.testbaliasmap[key] = builder
+	return builder
+}
+
+// AddTestBAliasMapWith calls build with the builder AddTestBAliasMap sets for key.
+func (b *TestBuilder) AddTestBAliasMapWith(key string, build func(*TestBBuilder)) *TestBuilder {
+	build(b.AddTestBAliasMap(key))
 	return b
 }
 
-func (b *TestBuilder) TestJsonAlias(input json.RawMessage) *TestBuilder {
+// RemoveTestBAliasMap drops the builder of key from TestBAliasMap.
+func (b *TestBuilder) RemoveTestBAliasMap(key string) {
+	delete(b.testbaliasmap, key)
+}
+
+func (b *TestBuilder) TestJSONAlias(input json.RawMessage) *TestBuilder {
 	b.model.TestJsonAlias = input
 	return b
 }
 
+func (b *TestBuilder) SetTestJSONAliasString(input string) *TestBuilder {
+	b.model.TestJsonAlias = json.RawMessage(input)
+	return b
+}
+
 func (b *TestBuilder) Build() Test {
 	b.model.TestA = b.testa.Build()
 	if b.testb != nil {
@@ -124,6 +303,10 @@ func (b *TestBuilder) Build() Test {
 	for _, v := range b.testblist {
 		b.model.TestBList = append(b.model.TestBList, v.Build())
 	}
+	b.model.TestBMap = map[string]TestB{}
+	for k, v := range b.testbmap {
+		b.model.TestBMap[k] = v.Build()
+	}
 	b.model.TestBListPointer = []*TestB{}
 	for _, v := range b.testblistpointer {
 		vv := v.Build()
@@ -134,10 +317,166 @@ func (b *TestBuilder) Build() Test {
 		vv := v.Build()
 		b.model.TestBAlias = append(b.model.TestBAlias, &vv)
 	}
+	b.model.TestBAliasMap = map[string]*TestB{}
+	for k, v := range b.testbaliasmap {
+		vv := v.Build()
+		b.model.TestBAliasMap[k] = &vv
+	}
 	return b.model
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestBuilder) BuildPtr() *Test {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Key).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Key: %#v", b.model.Key))
+	}
+	if !reflect.ValueOf(&b.model.Tas).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Tas: %#v", b.model.Tas))
+	}
+	if !reflect.ValueOf(&b.model.TestPkgType).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("TestPkgType: %+v", b.model.TestPkgType))
+	}
+	if b.testa != nil {
+		fields = append(fields, "TestA: "+b.testa.String())
+	}
+	if b.testb != nil {
+		fields = append(fields, "TestB: "+b.testb.String())
+	}
+	if len(b.testblist) > 0 {
+		fields = append(fields, fmt.Sprintf("TestBList: %d builders", len(b.testblist)))
+	}
+	if len(b.testbmap) > 0 {
+		fields = append(fields, fmt.Sprintf("TestBMap: %d builders", len(b.testbmap)))
+	}
+	if len(b.testblistpointer) > 0 {
+		fields = append(fields, fmt.Sprintf("TestBListPointer: %d builders", len(b.testblistpointer)))
+	}
+	if len(b.testbalias) > 0 {
+		fields = append(fields, fmt.Sprintf("TestBAlias: %d builders", len(b.testbalias)))
+	}
+	if len(b.testbaliasmap) > 0 {
+		fields = append(fields, fmt.Sprintf("TestBAliasMap: %d builders", len(b.testbaliasmap)))
+	}
+	if !reflect.ValueOf(&b.model.TestJsonAlias).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("TestJsonAlias: %+v", b.model.TestJsonAlias))
+	}
+	return "TestBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestBuilder) GoString() string {
+	if b == nil {
+		return "(*TestBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestBuilder{model: %#v, testa: %#v, testb: %#v, testblist: %#v, testbmap: %#v, testblistpointer: %#v, testbalias: %#v, testbaliasmap: %#v}", b.model, b.testa, b.testb, b.testblist, b.testbmap, b.testblistpointer, b.testbalias, b.testbaliasmap)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestBuilder) Clone() *TestBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.testa = b.testa.Clone()
+	clone.testb = b.testb.Clone()
+	if b.testblist != nil {
+		clone.testblist = make([]*TestBBuilder, len(b.testblist))
+		for k, v := range b.testblist {
+			clone.testblist[k] = v.Clone()
+		}
+	}
+	if b.testbmap != nil {
+		clone.testbmap = make(map[string]*TestBBuilder, len(b.testbmap))
+		for k, v := range b.testbmap {
+			clone.testbmap[k] = v.Clone()
+		}
+	}
+	if b.testblistpointer != nil {
+		clone.testblistpointer = make([]*TestBBuilder, len(b.testblistpointer))
+		for k, v := range b.testblistpointer {
+			clone.testblistpointer[k] = v.Clone()
+		}
+	}
+	if b.testbalias != nil {
+		clone.testbalias = make([]*TestBBuilder, len(b.testbalias))
+		for k, v := range b.testbalias {
+			clone.testbalias[k] = v.Clone()
+		}
+	}
+	if b.testbaliasmap != nil {
+		clone.testbaliasmap = make(map[string]*TestBBuilder, len(b.testbaliasmap))
+		for k, v := range b.testbaliasmap {
+			clone.testbaliasmap[k] = v.Clone()
+		}
+	}
+	if b.model.TestJsonAlias != nil {
+		clone.model.TestJsonAlias = make(json.RawMessage, len(b.model.TestJsonAlias))
+		copy(clone.model.TestJsonAlias, b.model.TestJsonAlias)
+	}
+	return &clone
+}
+
+func (b *TestBuilder) fromModel(model Test) {
+	b.model = model
+	b.testa.fromModel(model.TestA)
+	b.testb = nil
+	if model.TestB != nil {
+		b.testb = NewTestBBuilder()
+		b.testb.fromModel(*model.TestB)
+	}
+	b.testblist = []*TestBBuilder{}
+	for _, v := range model.TestBList {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.testblist = append(b.testblist, builder)
+	}
+	b.testbmap = map[string]*TestBBuilder{}
+	for k, v := range model.TestBMap {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.testbmap[k] = builder
+	}
+	b.testblistpointer = []*TestBBuilder{}
+	for _, v := range model.TestBListPointer {
+		if v == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*v)
+		b.testblistpointer = append(b.testblistpointer, builder)
+	}
+	b.testbalias = []*TestBBuilder{}
+	for _, v := range model.TestBAlias {
+		if v == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*v)
+		b.testbalias = append(b.testbalias, builder)
+	}
+	b.testbaliasmap = map[string]*TestBBuilder{}
+	for k, v := range model.TestBAliasMap {
+		if v == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*v)
+		b.testbaliasmap[k] = builder
+	}
+}
+
+// NewTestABuilder creates a builder for TestA.
 func NewTestABuilder() *TestABuilder {
 	builder := &TestABuilder{}
 	builder.model = TestA{}
@@ -147,6 +486,17 @@ func NewTestABuilder() *TestABuilder {
 	return builder
 }
 
+// NewTestABuilderFromYAML creates a builder for TestA from the YAML document data.
+func NewTestABuilderFromYAML(data []byte) (*TestABuilder, error) {
+	builder := NewTestABuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
 type TestABuilder struct {
 	model TestA
 	testb *TestBBuilder
@@ -161,135 +511,9458 @@ func (b *TestABuilder) Build() TestA {
 	return b.model
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func NewTestBBuilder() *TestBBuilder {
-	builder := &TestBBuilder{}
-	builder.model = TestB{}
-	builder.model.TestTag()
-	return builder
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestABuilder) BuildPtr() *TestA {
+	model := b.Build()
+	return &model
 }
 
-type TestBBuilder struct {
-	model TestB
+// String summarizes the members set on the builder, for debugging.
+func (b *TestABuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if b.testb != nil {
+		fields = append(fields, "TestB: "+b.testb.String())
+	}
+	return "TestABuilder{" + strings.Join(fields, ", ") + "}"
 }
 
-func (b *TestBBuilder) TestBKey(input string) *TestBBuilder {
-	b.model.TestBKey = input
-	return b
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestABuilder) GoString() string {
+	if b == nil {
+		return "(*TestABuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestABuilder{model: %#v, testb: %#v}", b.model, b.testb)
 }
 
-func (b *TestBBuilder) Build() TestB {
-	return b.model
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestABuilder) Clone() *TestABuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.testb = b.testb.Clone()
+	return &clone
+}
+
+func (b *TestABuilder) fromModel(model TestA) {
+	b.model = model
+	b.testb.fromModel(model.TestB)
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func NewTestDBuilder() *TestDBuilder {
-	builder := &TestDBuilder{}
-	builder.model = TestD{}
+// NewTestAliasChainBuilder creates a builder for TestAliasChain.
+func NewTestAliasChainBuilder() *TestAliasChainBuilder {
+	builder := &TestAliasChainBuilder{}
+	builder.model = TestAliasChain{}
+	builder.zones = map[other.Zone]*TestBBuilder{}
+	builder.zonemap = map[other.Zone]*TestBBuilder{}
 	return builder
 }
 
-type TestDBuilder struct {
-	model TestD
+// NewTestAliasChainBuilderFromYAML creates a builder for TestAliasChain from the YAML document data.
+func NewTestAliasChainBuilderFromYAML(data []byte) (*TestAliasChainBuilder, error) {
+	builder := NewTestAliasChainBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
 }
 
-func (b *TestDBuilder) KeyD(input int) *TestDBuilder {
-	b.model.KeyD = input
+type TestAliasChainBuilder struct {
+	model   TestAliasChain
+	slice   *TestBSliceBuilder
+	zones   map[other.Zone]*TestBBuilder
+	zonemap map[other.Zone]*TestBBuilder
+}
+
+func (b *TestAliasChainBuilder) Slice() *TestBSliceBuilder {
+	if b.slice == nil {
+		b.slice = NewTestBSliceBuilder()
+	}
+	return b.slice
+}
+
+// SetSlice replaces the list builder of Slice by one of the
+// elements of input.
+func (b *TestAliasChainBuilder) SetSlice(input TestBSlice) *TestAliasChainBuilder {
+	b.slice = NewTestBSliceBuilder()
+	b.slice.fromModel(input)
 	return b
 }
 
-func (b *TestDBuilder) Build() TestD {
-	return b.model
+func (b *TestAliasChainBuilder) Zones(input TestZoneMap) *TestAliasChainBuilder {
+	b.zones = map[other.Zone]*TestBBuilder{}
+	for k, v := range input {
+		if v == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*v)
+		b.zones[k] = builder
+	}
+	return b
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func NewTestEBuilder() *TestEBuilder {
-	builder := &TestEBuilder{}
-	builder.model = TestE{}
+func (b *TestAliasChainBuilder) AddZones(key other.Zone) *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.zones[key] = builder
 	return builder
 }
 
-type TestEBuilder struct {
-	model TestE
-	*TestDBuilder
-	testg *TestGBuilder
+// AddZonesWith calls build with the builder AddZones sets for key.
+func (b *TestAliasChainBuilder) AddZonesWith(key other.Zone, build func(*TestBBuilder)) *TestAliasChainBuilder {
+	build(b.AddZones(key))
+	return b
 }
 
-func (b *TestEBuilder) TestD() *TestDBuilder {
-	if b.TestDBuilder == nil {
-		b.TestDBuilder = NewTestDBuilder()
+// RemoveZones drops the builder of key from Zones.
+func (b *TestAliasChainBuilder) RemoveZones(key other.Zone) {
+	delete(b.zones, key)
+}
+
+func (b *TestAliasChainBuilder) ZoneMap(input map[other.Zone]*TestB) *TestAliasChainBuilder {
+	b.zonemap = map[other.Zone]*TestBBuilder{}
+	for k, v := range input {
+		if v == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*v)
+		b.zonemap[k] = builder
 	}
-	return b.TestDBuilder
+	return b
 }
 
-func (b *TestEBuilder) KeyD(input int) *TestEBuilder {
-	b.TestDBuilder.KeyD(input)
+func (b *TestAliasChainBuilder) AddZoneMap(key other.Zone) *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.zonemap[key] = builder
+	return builder
+}
+
+// AddZoneMapWith calls build with the builder AddZoneMap sets for key.
+func (b *TestAliasChainBuilder) AddZoneMapWith(key other.Zone, build func(*TestBBuilder)) *TestAliasChainBuilder {
+	build(b.AddZoneMap(key))
 	return b
 }
 
-func (b *TestEBuilder) KeyE(input int) *TestEBuilder {
-	b.model.KeyE = input
+// RemoveZoneMap drops the builder of key from ZoneMap.
+func (b *TestAliasChainBuilder) RemoveZoneMap(key other.Zone) {
+	delete(b.zonemap, key)
+}
+
+func (b *TestAliasChainBuilder) Metas(input TestMetaList) *TestAliasChainBuilder {
+	b.model.Metas = input
 	return b
 }
 
-func (b *TestEBuilder) TestG() *TestGBuilder {
-	if b.testg == nil {
-		b.testg = NewTestGBuilder()
+func (b *TestAliasChainBuilder) Build() TestAliasChain {
+	if b.slice != nil {
+		b.model.Slice = b.slice.Build()
 	}
-	return b.testg
+	b.model.Zones = map[other.Zone]*TestB{}
+	for k, v := range b.zones {
+		vv := v.Build()
+		b.model.Zones[k] = &vv
+	}
+	b.model.ZoneMap = map[other.Zone]*TestB{}
+	for k, v := range b.zonemap {
+		vv := v.Build()
+		b.model.ZoneMap[k] = &vv
+	}
+	return b.model
 }
 
-func (b *TestEBuilder) Build() TestE {
-	if b.TestDBuilder != nil {
-		testd := b.TestDBuilder.Build()
-		b.model.TestD = &testd
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestAliasChainBuilder) BuildPtr() *TestAliasChain {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestAliasChainBuilder) String() string {
+	if b == nil {
+		return "<nil>"
 	}
-	if b.testg != nil {
-		testg := b.testg.Build()
-		b.model.TestG = &testg
+	var fields []string
+	if b.slice != nil {
+		fields = append(fields, fmt.Sprintf("Slice: %d builders", len(b.slice.items)))
 	}
-	return b.model
+	if len(b.zones) > 0 {
+		fields = append(fields, fmt.Sprintf("Zones: %d builders", len(b.zones)))
+	}
+	if len(b.zonemap) > 0 {
+		fields = append(fields, fmt.Sprintf("ZoneMap: %d builders", len(b.zonemap)))
+	}
+	if !reflect.ValueOf(&b.model.Metas).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Metas: %+v", b.model.Metas))
+	}
+	return "TestAliasChainBuilder{" + strings.Join(fields, ", ") + "}"
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func NewTestFBuilder() *TestFBuilder {
-	builder := &TestFBuilder{}
-	builder.model = TestF{}
-	builder.TestEBuilder = *NewTestEBuilder()
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestAliasChainBuilder) GoString() string {
+	if b == nil {
+		return "(*TestAliasChainBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestAliasChainBuilder{model: %#v, slice: %#v, zones: %#v, zonemap: %#v}", b.model, b.slice, b.zones, b.zonemap)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestAliasChainBuilder) Clone() *TestAliasChainBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.slice != nil {
+		clone.slice = b.slice.Clone()
+	}
+	if b.zones != nil {
+		clone.zones = make(map[other.Zone]*TestBBuilder, len(b.zones))
+		for k, v := range b.zones {
+			clone.zones[k] = v.Clone()
+		}
+	}
+	if b.zonemap != nil {
+		clone.zonemap = make(map[other.Zone]*TestBBuilder, len(b.zonemap))
+		for k, v := range b.zonemap {
+			clone.zonemap[k] = v.Clone()
+		}
+	}
+	if b.model.Metas != nil {
+		clone.model.Metas = make(TestMetaList, len(b.model.Metas))
+		copy(clone.model.Metas, b.model.Metas)
+	}
+	return &clone
+}
+
+func (b *TestAliasChainBuilder) fromModel(model TestAliasChain) {
+	b.model = model
+	b.slice = nil
+	if model.Slice != nil {
+		b.slice = NewTestBSliceBuilder()
+		b.slice.fromModel(model.Slice)
+	}
+	b.zones = map[other.Zone]*TestBBuilder{}
+	for k, v := range model.Zones {
+		if v == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*v)
+		b.zones[k] = builder
+	}
+	b.zonemap = map[other.Zone]*TestBBuilder{}
+	for k, v := range model.ZoneMap {
+		if v == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*v)
+		b.zonemap[k] = builder
+	}
+}
+
+// NewTestBSliceBuilder creates a list builder for TestBSlice.
+func NewTestBSliceBuilder() *TestBSliceBuilder {
+	return &TestBSliceBuilder{}
+}
+
+// TestBSliceBuilder builds the TestBSlice lists of the members holding
+// slices of them, with a builder per element.
+type TestBSliceBuilder struct {
+	items []*TestBBuilder
+}
+
+// Add appends a new builder to the list and returns it.
+func (b *TestBSliceBuilder) Add() *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.items = append(b.items, builder)
 	return builder
 }
 
-type TestFBuilder struct {
-	model TestF
-	TestEBuilder
+// Build returns the list built by the builders added, in their order.
+func (b *TestBSliceBuilder) Build() TestBSlice {
+	list := make(TestBSlice, 0, len(b.items))
+	for _, v := range b.items {
+		vv := v.Build()
+		list = append(list, &vv)
+	}
+	return list
 }
 
-func (b *TestFBuilder) KeyE(input int) *TestFBuilder {
-	b.TestEBuilder.KeyE(input)
-	return b
+// GoString lists the builders of the list, for %#v.
+func (b *TestBSliceBuilder) GoString() string {
+	return fmt.Sprintf("&TestBSliceBuilder{items: %#v}", b.items)
 }
 
-func (b *TestFBuilder) Build() TestF {
-	b.model.TestE = b.TestEBuilder.Build()
-	return b.model
+// Clone returns a copy of the list builder and of its builders.
+func (b *TestBSliceBuilder) Clone() *TestBSliceBuilder {
+	clone := &TestBSliceBuilder{items: make([]*TestBBuilder, len(b.items))}
+	for i, v := range b.items {
+		clone.items[i] = v.Clone()
+	}
+	return clone
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func NewTestGBuilder() *TestGBuilder {
-	builder := &TestGBuilder{}
-	builder.model = TestG{}
+func (b *TestBSliceBuilder) fromModel(model TestBSlice) {
+	b.items = make([]*TestBBuilder, 0, len(model))
+	for _, v := range model {
+		if v == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*v)
+		b.items = append(b.items, builder)
+	}
+}
+
+// NewTestAnonymousBuilder creates a builder for TestAnonymous.
+//
+// TestAnonymous has members of anonymous struct types.
+func NewTestAnonymousBuilder() *TestAnonymousBuilder {
+	builder := &TestAnonymousBuilder{}
+	builder.model = TestAnonymous{}
+	builder.spec = NewTestAnonymousSpecBuilder()
+	builder.containers = []*TestAnonymousContainersBuilder{}
 	return builder
 }
 
-type TestGBuilder struct {
-	model TestG
+// NewTestAnonymousBuilderFromYAML creates a builder for TestAnonymous from the YAML document data.
+func NewTestAnonymousBuilderFromYAML(data []byte) (*TestAnonymousBuilder, error) {
+	builder := NewTestAnonymousBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
 }
 
-func (b *TestGBuilder) KeyG(input int) *TestGBuilder {
-	b.model.KeyG = input
+type TestAnonymousBuilder struct {
+	model      TestAnonymous
+	spec       *TestAnonymousSpecBuilder
+	status     *TestAnonymousStatusBuilder
+	containers []*TestAnonymousContainersBuilder
+}
+
+func (b *TestAnonymousBuilder) Name(input string) *TestAnonymousBuilder {
+	b.model.Name = input
 	return b
 }
 
-func (b *TestGBuilder) Build() TestG {
-	return b.model
+func (b *TestAnonymousBuilder) Spec() *TestAnonymousSpecBuilder {
+	return b.spec
+}
+
+func (b *TestAnonymousBuilder) Status() *TestAnonymousStatusBuilder {
+	if b.status == nil {
+		b.status = NewTestAnonymousStatusBuilder()
+	}
+	return b.status
+}
+
+// SetStatus sets Status to a copy of the value input points to, nil
+// if input is nil.
+func (b *TestAnonymousBuilder) SetStatus(input *TestAnonymousStatus) *TestAnonymousBuilder {
+	b.status = nil
+	b.model.Status = nil
+	if input != nil {
+		b.status = NewTestAnonymousStatusBuilder()
+		b.status.fromModel(*input)
+	}
+	return b
+}
+
+// SetContainersFrom replaces the builders of Containers by builders of the
+// elements of items.
+func (b *TestAnonymousBuilder) SetContainersFrom(items []TestAnonymousContainers) *TestAnonymousBuilder {
+	b.containers = make([]*TestAnonymousContainersBuilder, 0, len(items))
+	for _, item := range items {
+		builder := NewTestAnonymousContainersBuilder()
+		builder.fromModel(item)
+		b.containers = append(b.containers, builder)
+	}
+	return b
+}
+
+func (b *TestAnonymousBuilder) AddContainers() *TestAnonymousContainersBuilder {
+	builder := NewTestAnonymousContainersBuilder()
+	b.containers = append(b.containers, builder)
+	return builder
+}
+
+// AddContainersWith calls build with the builder AddContainers appends.
+func (b *TestAnonymousBuilder) AddContainersWith(build func(*TestAnonymousContainersBuilder)) *TestAnonymousBuilder {
+	build(b.AddContainers())
+	return b
+}
+
+func (b *TestAnonymousBuilder) RemoveContainers(remove *TestAnonymousContainersBuilder) {
+	for i, val := range b.containers {
+		if val == remove {
+			b.containers[i] = b.containers[len(b.containers)-1]
+			b.containers = b.containers[:len(b.containers)-1]
+		}
+	}
+}
+func (b *TestAnonymousBuilder) Build() TestAnonymous {
+	b.model.Spec = b.spec.Build()
+	if b.status != nil {
+		status := b.status.Build()
+		b.model.Status = &status
+	}
+	b.model.Containers = []TestAnonymousContainers{}
+	for _, v := range b.containers {
+		b.model.Containers = append(b.model.Containers, v.Build())
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestAnonymousBuilder) BuildPtr() *TestAnonymous {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestAnonymousBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	if b.spec != nil {
+		fields = append(fields, "Spec: "+b.spec.String())
+	}
+	if b.status != nil {
+		fields = append(fields, "Status: "+b.status.String())
+	}
+	if len(b.containers) > 0 {
+		fields = append(fields, fmt.Sprintf("Containers: %d builders", len(b.containers)))
+	}
+	return "TestAnonymousBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestAnonymousBuilder) GoString() string {
+	if b == nil {
+		return "(*TestAnonymousBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestAnonymousBuilder{model: %#v, spec: %#v, status: %#v, containers: %#v}", b.model, b.spec, b.status, b.containers)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestAnonymousBuilder) Clone() *TestAnonymousBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.spec = b.spec.Clone()
+	clone.status = b.status.Clone()
+	if b.containers != nil {
+		clone.containers = make([]*TestAnonymousContainersBuilder, len(b.containers))
+		for k, v := range b.containers {
+			clone.containers[k] = v.Clone()
+		}
+	}
+	return &clone
+}
+
+func (b *TestAnonymousBuilder) fromModel(model TestAnonymous) {
+	b.model = model
+	b.spec.fromModel(model.Spec)
+	b.status = nil
+	if model.Status != nil {
+		b.status = NewTestAnonymousStatusBuilder()
+		b.status.fromModel(*model.Status)
+	}
+	b.containers = []*TestAnonymousContainersBuilder{}
+	for _, v := range model.Containers {
+		builder := NewTestAnonymousContainersBuilder()
+		builder.fromModel(v)
+		b.containers = append(b.containers, builder)
+	}
+}
+
+// TestAnonymousSpec is the anonymous struct of TestAnonymous.Spec.
+type TestAnonymousSpec = struct {
+	Replicas int
+	Image    string
+}
+
+// NewTestAnonymousSpecBuilder creates a builder for TestAnonymousSpec.
+func NewTestAnonymousSpecBuilder() *TestAnonymousSpecBuilder {
+	builder := &TestAnonymousSpecBuilder{}
+	builder.model = TestAnonymousSpec{}
+	return builder
+}
+
+// NewTestAnonymousSpecBuilderFromYAML creates a builder for TestAnonymousSpec from the YAML document data.
+func NewTestAnonymousSpecBuilderFromYAML(data []byte) (*TestAnonymousSpecBuilder, error) {
+	builder := NewTestAnonymousSpecBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestAnonymousSpecBuilder struct {
+	model TestAnonymousSpec
+}
+
+func (b *TestAnonymousSpecBuilder) Replicas(input int) *TestAnonymousSpecBuilder {
+	b.model.Replicas = input
+	return b
+}
+
+func (b *TestAnonymousSpecBuilder) Image(input string) *TestAnonymousSpecBuilder {
+	b.model.Image = input
+	return b
+}
+
+func (b *TestAnonymousSpecBuilder) Build() TestAnonymousSpec {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestAnonymousSpecBuilder) BuildPtr() *TestAnonymousSpec {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestAnonymousSpecBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Replicas).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Replicas: %#v", b.model.Replicas))
+	}
+	if !reflect.ValueOf(&b.model.Image).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Image: %#v", b.model.Image))
+	}
+	return "TestAnonymousSpecBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestAnonymousSpecBuilder) GoString() string {
+	if b == nil {
+		return "(*TestAnonymousSpecBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestAnonymousSpecBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestAnonymousSpecBuilder) Clone() *TestAnonymousSpecBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestAnonymousSpecBuilder) fromModel(model TestAnonymousSpec) {
+	b.model = model
+}
+
+// TestAnonymousStatus is the anonymous struct of TestAnonymous.Status.
+type TestAnonymousStatus = struct {
+	Ready bool
+}
+
+// NewTestAnonymousStatusBuilder creates a builder for TestAnonymousStatus.
+func NewTestAnonymousStatusBuilder() *TestAnonymousStatusBuilder {
+	builder := &TestAnonymousStatusBuilder{}
+	builder.model = TestAnonymousStatus{}
+	return builder
+}
+
+// NewTestAnonymousStatusBuilderFromYAML creates a builder for TestAnonymousStatus from the YAML document data.
+func NewTestAnonymousStatusBuilderFromYAML(data []byte) (*TestAnonymousStatusBuilder, error) {
+	builder := NewTestAnonymousStatusBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestAnonymousStatusBuilder struct {
+	model TestAnonymousStatus
+}
+
+func (b *TestAnonymousStatusBuilder) Ready(input bool) *TestAnonymousStatusBuilder {
+	b.model.Ready = input
+	return b
+}
+
+func (b *TestAnonymousStatusBuilder) Build() TestAnonymousStatus {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestAnonymousStatusBuilder) BuildPtr() *TestAnonymousStatus {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestAnonymousStatusBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Ready).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Ready: %#v", b.model.Ready))
+	}
+	return "TestAnonymousStatusBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestAnonymousStatusBuilder) GoString() string {
+	if b == nil {
+		return "(*TestAnonymousStatusBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestAnonymousStatusBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestAnonymousStatusBuilder) Clone() *TestAnonymousStatusBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestAnonymousStatusBuilder) fromModel(model TestAnonymousStatus) {
+	b.model = model
+}
+
+// TestAnonymousContainers is the anonymous struct of TestAnonymous.Containers.
+type TestAnonymousContainers = struct {
+	Name  string `json:"name"`
+	Ports struct {
+		HTTP int
+	}
+}
+
+// NewTestAnonymousContainersBuilder creates a builder for TestAnonymousContainers.
+func NewTestAnonymousContainersBuilder() *TestAnonymousContainersBuilder {
+	builder := &TestAnonymousContainersBuilder{}
+	builder.model = TestAnonymousContainers{}
+	builder.ports = NewTestAnonymousContainersPortsBuilder()
+	return builder
+}
+
+// NewTestAnonymousContainersBuilderFromYAML creates a builder for TestAnonymousContainers from the YAML document data.
+func NewTestAnonymousContainersBuilderFromYAML(data []byte) (*TestAnonymousContainersBuilder, error) {
+	builder := NewTestAnonymousContainersBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestAnonymousContainersBuilder struct {
+	model TestAnonymousContainers
+	ports *TestAnonymousContainersPortsBuilder
+}
+
+func (b *TestAnonymousContainersBuilder) Name(input string) *TestAnonymousContainersBuilder {
+	b.model.Name = input
+	return b
+}
+
+func (b *TestAnonymousContainersBuilder) Ports() *TestAnonymousContainersPortsBuilder {
+	return b.ports
+}
+
+func (b *TestAnonymousContainersBuilder) Build() TestAnonymousContainers {
+	b.model.Ports = b.ports.Build()
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestAnonymousContainersBuilder) BuildPtr() *TestAnonymousContainers {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestAnonymousContainersBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	if b.ports != nil {
+		fields = append(fields, "Ports: "+b.ports.String())
+	}
+	return "TestAnonymousContainersBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestAnonymousContainersBuilder) GoString() string {
+	if b == nil {
+		return "(*TestAnonymousContainersBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestAnonymousContainersBuilder{model: %#v, ports: %#v}", b.model, b.ports)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestAnonymousContainersBuilder) Clone() *TestAnonymousContainersBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.ports = b.ports.Clone()
+	return &clone
+}
+
+func (b *TestAnonymousContainersBuilder) fromModel(model TestAnonymousContainers) {
+	b.model = model
+	b.ports.fromModel(model.Ports)
+}
+
+// TestAnonymousContainersPorts is the anonymous struct of TestAnonymousContainers.Ports.
+type TestAnonymousContainersPorts = struct {
+	HTTP int
+}
+
+// NewTestAnonymousContainersPortsBuilder creates a builder for TestAnonymousContainersPorts.
+func NewTestAnonymousContainersPortsBuilder() *TestAnonymousContainersPortsBuilder {
+	builder := &TestAnonymousContainersPortsBuilder{}
+	builder.model = TestAnonymousContainersPorts{}
+	return builder
+}
+
+// NewTestAnonymousContainersPortsBuilderFromYAML creates a builder for TestAnonymousContainersPorts from the YAML document data.
+func NewTestAnonymousContainersPortsBuilderFromYAML(data []byte) (*TestAnonymousContainersPortsBuilder, error) {
+	builder := NewTestAnonymousContainersPortsBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestAnonymousContainersPortsBuilder struct {
+	model TestAnonymousContainersPorts
+}
+
+func (b *TestAnonymousContainersPortsBuilder) HTTP(input int) *TestAnonymousContainersPortsBuilder {
+	b.model.HTTP = input
+	return b
+}
+
+func (b *TestAnonymousContainersPortsBuilder) Build() TestAnonymousContainersPorts {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestAnonymousContainersPortsBuilder) BuildPtr() *TestAnonymousContainersPorts {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestAnonymousContainersPortsBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.HTTP).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("HTTP: %#v", b.model.HTTP))
+	}
+	return "TestAnonymousContainersPortsBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestAnonymousContainersPortsBuilder) GoString() string {
+	if b == nil {
+		return "(*TestAnonymousContainersPortsBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestAnonymousContainersPortsBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestAnonymousContainersPortsBuilder) Clone() *TestAnonymousContainersPortsBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestAnonymousContainersPortsBuilder) fromModel(model TestAnonymousContainersPorts) {
+	b.model = model
+}
+
+// NewTestBBuilder creates a builder for TestB.
+func NewTestBBuilder() *TestBBuilder {
+	builder := &TestBBuilder{}
+	builder.model = TestB{}
+	builder.model.TestTag()
+	return builder
+}
+
+// NewTestBBuilderFromYAML creates a builder for TestB from the YAML document data.
+func NewTestBBuilderFromYAML(data []byte) (*TestBBuilder, error) {
+	builder := NewTestBBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestBBuilder struct {
+	model TestB
+}
+
+func (b *TestBBuilder) TestBKey(input string) *TestBBuilder {
+	b.model.TestBKey = input
+	return b
+}
+
+func (b *TestBBuilder) Build() TestB {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestBBuilder) BuildPtr() *TestB {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestBBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.TestBKey).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("TestBKey: %#v", b.model.TestBKey))
+	}
+	return "TestBBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestBBuilder) GoString() string {
+	if b == nil {
+		return "(*TestBBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestBBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestBBuilder) Clone() *TestBBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestBBuilder) fromModel(model TestB) {
+	b.model = model
+}
+
+// NewTestBuildHookBuilder creates a builder for TestBuildHook.
+//
+// TestBuildHook is enriched by its hooks when built with a context.
+func NewTestBuildHookBuilder() *TestBuildHookBuilder {
+	builder := &TestBuildHookBuilder{}
+	builder.model = TestBuildHook{}
+	return builder
+}
+
+// NewTestBuildHookBuilderFromYAML creates a builder for TestBuildHook from the YAML document data.
+func NewTestBuildHookBuilderFromYAML(data []byte) (*TestBuildHookBuilder, error) {
+	builder := NewTestBuildHookBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestBuildHookBuilder struct {
+	model TestBuildHook
+}
+
+func (b *TestBuildHookBuilder) ID(input string) *TestBuildHookBuilder {
+	b.model.ID = input
+	return b
+}
+
+func (b *TestBuildHookBuilder) Name(input string) *TestBuildHookBuilder {
+	b.model.Name = input
+	return b
+}
+
+func (b *TestBuildHookBuilder) Build() TestBuildHook {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestBuildHookBuilder) BuildPtr() *TestBuildHook {
+	model := b.Build()
+	return &model
+}
+
+// BuildContext builds the model and calls its hooks with ctx, returning
+// the first error.
+func (b *TestBuildHookBuilder) BuildContext(ctx context.Context) (TestBuildHook, error) {
+	model := b.Build()
+	if err := ctx.Err(); err != nil {
+		return model, err
+	}
+	if err := model.Enrich(ctx); err != nil {
+		return model, fmt.Errorf("Enrich: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return model, err
+	}
+	if err := model.Check(ctx); err != nil {
+		return model, fmt.Errorf("Check: %w", err)
+	}
+	return model, nil
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestBuildHookBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.ID).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("ID: %#v", b.model.ID))
+	}
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	return "TestBuildHookBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestBuildHookBuilder) GoString() string {
+	if b == nil {
+		return "(*TestBuildHookBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestBuildHookBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestBuildHookBuilder) Clone() *TestBuildHookBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestBuildHookBuilder) fromModel(model TestBuildHook) {
+	b.model = model
+}
+
+// NewTestBuildNameBuilder creates a builder for TestBuildName.
+//
+// TestBuildName has a member named Build, its builder returning the model
+// from ToModel.
+func NewTestBuildNameBuilder() *TestBuildNameBuilder {
+	builder := &TestBuildNameBuilder{}
+	builder.model = TestBuildName{}
+	return builder
+}
+
+// NewTestBuildNameBuilderFromYAML creates a builder for TestBuildName from the YAML document data.
+func NewTestBuildNameBuilderFromYAML(data []byte) (*TestBuildNameBuilder, error) {
+	builder := NewTestBuildNameBuilder()
+	model := builder.ToModel()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestBuildNameBuilder struct {
+	model TestBuildName
+}
+
+func (b *TestBuildNameBuilder) Build(input string) *TestBuildNameBuilder {
+	b.model.Build = input
+	return b
+}
+
+func (b *TestBuildNameBuilder) ToModel() TestBuildName {
+	return b.model
+}
+
+// ToModelPtr returns a pointer to the model built by ToModel.
+func (b *TestBuildNameBuilder) ToModelPtr() *TestBuildName {
+	model := b.ToModel()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestBuildNameBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Build).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Build: %#v", b.model.Build))
+	}
+	return "TestBuildNameBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestBuildNameBuilder) GoString() string {
+	if b == nil {
+		return "(*TestBuildNameBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestBuildNameBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestBuildNameBuilder) Clone() *TestBuildNameBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestBuildNameBuilder) fromModel(model TestBuildName) {
+	b.model = model
+}
+
+// NewTestBuildNameNestedBuilder creates a builder for TestBuildNameNested.
+//
+// TestBuildNameNested holds a TestBuildName, built by its ToModel.
+func NewTestBuildNameNestedBuilder() *TestBuildNameNestedBuilder {
+	builder := &TestBuildNameNestedBuilder{}
+	builder.model = TestBuildNameNested{}
+	builder.build_ = NewTestBuildNameBuilder()
+	builder.steps = []*TestBuildNameBuilder{}
+	return builder
+}
+
+// NewTestBuildNameNestedBuilderFromYAML creates a builder for TestBuildNameNested from the YAML document data.
+func NewTestBuildNameNestedBuilderFromYAML(data []byte) (*TestBuildNameNestedBuilder, error) {
+	builder := NewTestBuildNameNestedBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestBuildNameNestedBuilder struct {
+	model  TestBuildNameNested
+	build_ *TestBuildNameBuilder
+	ptr    *TestBuildNameBuilder
+	steps  []*TestBuildNameBuilder
+}
+
+func (b *TestBuildNameNestedBuilder) SetBuild() *TestBuildNameBuilder {
+	return b.build_
+}
+
+func (b *TestBuildNameNestedBuilder) Ptr() *TestBuildNameBuilder {
+	if b.ptr == nil {
+		b.ptr = NewTestBuildNameBuilder()
+	}
+	return b.ptr
+}
+
+// SetPtr sets Ptr to a copy of the value input points to, nil
+// if input is nil.
+func (b *TestBuildNameNestedBuilder) SetPtr(input *TestBuildName) *TestBuildNameNestedBuilder {
+	b.ptr = nil
+	b.model.Ptr = nil
+	if input != nil {
+		b.ptr = NewTestBuildNameBuilder()
+		b.ptr.fromModel(*input)
+	}
+	return b
+}
+
+// SetStepsFrom replaces the builders of Steps by builders of the
+// elements of items.
+func (b *TestBuildNameNestedBuilder) SetStepsFrom(items []TestBuildName) *TestBuildNameNestedBuilder {
+	b.steps = make([]*TestBuildNameBuilder, 0, len(items))
+	for _, item := range items {
+		builder := NewTestBuildNameBuilder()
+		builder.fromModel(item)
+		b.steps = append(b.steps, builder)
+	}
+	return b
+}
+
+func (b *TestBuildNameNestedBuilder) AddSteps() *TestBuildNameBuilder {
+	builder := NewTestBuildNameBuilder()
+	b.steps = append(b.steps, builder)
+	return builder
+}
+
+// AddStepsWith calls build with the builder AddSteps appends.
+func (b *TestBuildNameNestedBuilder) AddStepsWith(build func(*TestBuildNameBuilder)) *TestBuildNameNestedBuilder {
+	build(b.AddSteps())
+	return b
+}
+
+func (b *TestBuildNameNestedBuilder) RemoveSteps(remove *TestBuildNameBuilder) {
+	for i, val := range b.steps {
+		if val == remove {
+			b.steps[i] = b.steps[len(b.steps)-1]
+			b.steps = b.steps[:len(b.steps)-1]
+		}
+	}
+}
+func (b *TestBuildNameNestedBuilder) Build() TestBuildNameNested {
+	b.model.Build = b.build_.ToModel()
+	if b.ptr != nil {
+		ptr := b.ptr.ToModel()
+		b.model.Ptr = &ptr
+	}
+	b.model.Steps = []TestBuildName{}
+	for _, v := range b.steps {
+		b.model.Steps = append(b.model.Steps, v.ToModel())
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestBuildNameNestedBuilder) BuildPtr() *TestBuildNameNested {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestBuildNameNestedBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if b.build_ != nil {
+		fields = append(fields, "Build: "+b.build_.String())
+	}
+	if b.ptr != nil {
+		fields = append(fields, "Ptr: "+b.ptr.String())
+	}
+	if len(b.steps) > 0 {
+		fields = append(fields, fmt.Sprintf("Steps: %d builders", len(b.steps)))
+	}
+	return "TestBuildNameNestedBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestBuildNameNestedBuilder) GoString() string {
+	if b == nil {
+		return "(*TestBuildNameNestedBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestBuildNameNestedBuilder{model: %#v, build_: %#v, ptr: %#v, steps: %#v}", b.model, b.build_, b.ptr, b.steps)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestBuildNameNestedBuilder) Clone() *TestBuildNameNestedBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.build_ = b.build_.Clone()
+	clone.ptr = b.ptr.Clone()
+	if b.steps != nil {
+		clone.steps = make([]*TestBuildNameBuilder, len(b.steps))
+		for k, v := range b.steps {
+			clone.steps[k] = v.Clone()
+		}
+	}
+	return &clone
+}
+
+func (b *TestBuildNameNestedBuilder) fromModel(model TestBuildNameNested) {
+	b.model = model
+	b.build_.fromModel(model.Build)
+	b.ptr = nil
+	if model.Ptr != nil {
+		b.ptr = NewTestBuildNameBuilder()
+		b.ptr.fromModel(*model.Ptr)
+	}
+	b.steps = []*TestBuildNameBuilder{}
+	for _, v := range model.Steps {
+		builder := NewTestBuildNameBuilder()
+		builder.fromModel(v)
+		b.steps = append(b.steps, builder)
+	}
+}
+
+// NewTestCapBuilder creates a builder for TestCap.
+//
+// TestCap has its slices and maps allocated with the capacity of their tags.
+func NewTestCapBuilder() *TestCapBuilder {
+	builder := &TestCapBuilder{}
+	builder.model = TestCap{}
+	builder.items = make([]*TestBBuilder, 0, 16)
+	builder.index = make(map[string]*TestBBuilder, 8)
+	return builder
+}
+
+// NewTestCapBuilderFromYAML creates a builder for TestCap from the YAML document data.
+func NewTestCapBuilderFromYAML(data []byte) (*TestCapBuilder, error) {
+	builder := NewTestCapBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestCapBuilder struct {
+	model TestCap
+	items []*TestBBuilder
+	index map[string]*TestBBuilder
+}
+
+// SetItemsFrom replaces the builders of Items by builders of the
+// elements of items.
+func (b *TestCapBuilder) SetItemsFrom(items []TestB) *TestCapBuilder {
+	b.items = make([]*TestBBuilder, 0, len(items))
+	for _, item := range items {
+		builder := NewTestBBuilder()
+		builder.fromModel(item)
+		b.items = append(b.items, builder)
+	}
+	return b
+}
+
+func (b *TestCapBuilder) AddItems() *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.items = append(b.items, builder)
+	return builder
+}
+
+// AddItemsWith calls build with the builder AddItems appends.
+func (b *TestCapBuilder) AddItemsWith(build func(*TestBBuilder)) *TestCapBuilder {
+	build(b.AddItems())
+	return b
+}
+
+func (b *TestCapBuilder) RemoveItems(remove *TestBBuilder) {
+	for i, val := range b.items {
+		if val == remove {
+			b.items[i] = b.items[len(b.items)-1]
+			b.items = b.items[:len(b.items)-1]
+		}
+	}
+}
+func (b *TestCapBuilder) Index(input map[string]*TestB) *TestCapBuilder {
+	b.index = map[string]*TestBBuilder{}
+	for k, v := range input {
+		if v == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*v)
+		b.index[k] = builder
+	}
+	return b
+}
+
+func (b *TestCapBuilder) AddIndex(key string) *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.index[key] = builder
+	return builder
+}
+
+// AddIndexWith calls build with the builder AddIndex sets for key.
+func (b *TestCapBuilder) AddIndexWith(key string, build func(*TestBBuilder)) *TestCapBuilder {
+	build(b.AddIndex(key))
+	return b
+}
+
+// RemoveIndex drops the builder of key from Index.
+func (b *TestCapBuilder) RemoveIndex(key string) {
+	delete(b.index, key)
+}
+
+func (b *TestCapBuilder) Tags(input []string) *TestCapBuilder {
+	if input != nil {
+		input = append(make([]string, 0, len(input)), input...)
+	}
+	b.model.Tags = input
+	return b
+}
+
+func (b *TestCapBuilder) AddTags(items ...string) *TestCapBuilder {
+	if b.model.Tags == nil {
+		b.model.Tags = make([]string, 0, 32)
+	}
+	b.model.Tags = append(b.model.Tags, items...)
+	return b
+}
+
+func (b *TestCapBuilder) AppendTags(item string) *TestCapBuilder {
+	if b.model.Tags == nil {
+		b.model.Tags = make([]string, 0, 32)
+	}
+	b.model.Tags = append(b.model.Tags, item)
+	return b
+}
+
+func (b *TestCapBuilder) Labels(input map[string]string) *TestCapBuilder {
+	b.model.Labels = input
+	return b
+}
+
+func (b *TestCapBuilder) SetLabelsEntry(key string, value string) *TestCapBuilder {
+	if b.model.Labels == nil {
+		b.model.Labels = make(map[string]string, 4)
+	}
+	b.model.Labels[key] = value
+	return b
+}
+
+func (b *TestCapBuilder) Build() TestCap {
+	b.model.Items = make([]TestB, 0, 16)
+	for _, v := range b.items {
+		b.model.Items = append(b.model.Items, v.Build())
+	}
+	b.model.Index = make(map[string]*TestB, 8)
+	for k, v := range b.index {
+		vv := v.Build()
+		b.model.Index[k] = &vv
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestCapBuilder) BuildPtr() *TestCap {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestCapBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if len(b.items) > 0 {
+		fields = append(fields, fmt.Sprintf("Items: %d builders", len(b.items)))
+	}
+	if len(b.index) > 0 {
+		fields = append(fields, fmt.Sprintf("Index: %d builders", len(b.index)))
+	}
+	if !reflect.ValueOf(&b.model.Tags).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Tags: %+v", b.model.Tags))
+	}
+	if !reflect.ValueOf(&b.model.Labels).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Labels: %+v", b.model.Labels))
+	}
+	return "TestCapBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestCapBuilder) GoString() string {
+	if b == nil {
+		return "(*TestCapBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestCapBuilder{model: %#v, items: %#v, index: %#v}", b.model, b.items, b.index)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestCapBuilder) Clone() *TestCapBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.items != nil {
+		clone.items = make([]*TestBBuilder, len(b.items))
+		for k, v := range b.items {
+			clone.items[k] = v.Clone()
+		}
+	}
+	if b.index != nil {
+		clone.index = make(map[string]*TestBBuilder, len(b.index))
+		for k, v := range b.index {
+			clone.index[k] = v.Clone()
+		}
+	}
+	if b.model.Tags != nil {
+		clone.model.Tags = make([]string, len(b.model.Tags))
+		copy(clone.model.Tags, b.model.Tags)
+	}
+	if b.model.Labels != nil {
+		clone.model.Labels = make(map[string]string, len(b.model.Labels))
+		for k, v := range b.model.Labels {
+			clone.model.Labels[k] = v
+		}
+	}
+	return &clone
+}
+
+func (b *TestCapBuilder) fromModel(model TestCap) {
+	b.model = model
+	b.items = []*TestBBuilder{}
+	for _, v := range model.Items {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.items = append(b.items, builder)
+	}
+	b.index = map[string]*TestBBuilder{}
+	for k, v := range model.Index {
+		if v == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*v)
+		b.index[k] = builder
+	}
+}
+
+// NewTestCellBuilder creates a builder for TestCell.
+func NewTestCellBuilder() *TestCellBuilder {
+	builder := &TestCellBuilder{}
+	builder.model = TestCell{}
+	return builder
+}
+
+// NewTestCellBuilderFromYAML creates a builder for TestCell from the YAML document data.
+func NewTestCellBuilderFromYAML(data []byte) (*TestCellBuilder, error) {
+	builder := NewTestCellBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestCellBuilder struct {
+	model TestCell
+}
+
+func (b *TestCellBuilder) Value(input string) *TestCellBuilder {
+	b.model.Value = input
+	return b
+}
+
+func (b *TestCellBuilder) Build() TestCell {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestCellBuilder) BuildPtr() *TestCell {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestCellBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Value).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Value: %#v", b.model.Value))
+	}
+	return "TestCellBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestCellBuilder) GoString() string {
+	if b == nil {
+		return "(*TestCellBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestCellBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestCellBuilder) Clone() *TestCellBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestCellBuilder) fromModel(model TestCell) {
+	b.model = model
+}
+
+// NewTestClosureBuilder creates a builder for TestClosure.
+//
+// TestClosure references structs of another package of the module, they get
+// builders with --closure.
+func NewTestClosureBuilder() *TestClosureBuilder {
+	builder := &TestClosureBuilder{}
+	builder.model = TestClosure{}
+	return builder
+}
+
+// NewTestClosureBuilderFromYAML creates a builder for TestClosure from the YAML document data.
+func NewTestClosureBuilderFromYAML(data []byte) (*TestClosureBuilder, error) {
+	builder := NewTestClosureBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestClosureBuilder struct {
+	model TestClosure
+}
+
+func (b *TestClosureBuilder) Home(input other.Address) *TestClosureBuilder {
+	b.model.Home = input
+	return b
+}
+
+func (b *TestClosureBuilder) Work(input *other.Address) *TestClosureBuilder {
+	b.model.Work = input
+	return b
+}
+
+func (b *TestClosureBuilder) Previous(input []other.Address) *TestClosureBuilder {
+	b.model.Previous = input
+	return b
+}
+
+func (b *TestClosureBuilder) Locations(input map[string]*other.Geo) *TestClosureBuilder {
+	b.model.Locations = input
+	return b
+}
+
+func (b *TestClosureBuilder) Build() TestClosure {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestClosureBuilder) BuildPtr() *TestClosure {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestClosureBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Home).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Home: %+v", b.model.Home))
+	}
+	if !reflect.ValueOf(&b.model.Work).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Work: %+v", b.model.Work))
+	}
+	if !reflect.ValueOf(&b.model.Previous).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Previous: %+v", b.model.Previous))
+	}
+	if !reflect.ValueOf(&b.model.Locations).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Locations: %+v", b.model.Locations))
+	}
+	return "TestClosureBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestClosureBuilder) GoString() string {
+	if b == nil {
+		return "(*TestClosureBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestClosureBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestClosureBuilder) Clone() *TestClosureBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.model.Previous != nil {
+		clone.model.Previous = make([]other.Address, len(b.model.Previous))
+		copy(clone.model.Previous, b.model.Previous)
+	}
+	if b.model.Locations != nil {
+		clone.model.Locations = make(map[string]*other.Geo, len(b.model.Locations))
+		for k, v := range b.model.Locations {
+			clone.model.Locations[k] = v
+		}
+	}
+	return &clone
+}
+
+func (b *TestClosureBuilder) fromModel(model TestClosure) {
+	b.model = model
+}
+
+// NewTestConflictBuilder creates a builder for TestConflict.
+func NewTestConflictBuilder() *TestConflictBuilder {
+	builder := &TestConflictBuilder{}
+	builder.model = TestConflict{}
+	builder.model_ = NewTestBBuilder()
+	builder.input_ = []*TestBBuilder{}
+	return builder
+}
+
+// NewTestConflictBuilderFromYAML creates a builder for TestConflict from the YAML document data.
+func NewTestConflictBuilderFromYAML(data []byte) (*TestConflictBuilder, error) {
+	builder := NewTestConflictBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestConflictBuilder struct {
+	model  TestConflict
+	model_ *TestBBuilder
+	b_     *TestBBuilder
+	input_ []*TestBBuilder
+}
+
+func (b *TestConflictBuilder) SetBuild(input string) *TestConflictBuilder {
+	b.model.Build = input
+	return b
+}
+
+func (b *TestConflictBuilder) SetBuildObject(input int) *TestConflictBuilder {
+	b.model.BuildObject = input
+	return b
+}
+
+func (b *TestConflictBuilder) Model() *TestBBuilder {
+	return b.model_
+}
+
+func (b *TestConflictBuilder) B() *TestBBuilder {
+	if b.b_ == nil {
+		b.b_ = NewTestBBuilder()
+	}
+	return b.b_
+}
+
+// SetB sets B to a copy of the value input points to, nil
+// if input is nil.
+func (b *TestConflictBuilder) SetB(input *TestB) *TestConflictBuilder {
+	b.b_ = nil
+	b.model.B = nil
+	if input != nil {
+		b.b_ = NewTestBBuilder()
+		b.b_.fromModel(*input)
+	}
+	return b
+}
+
+// SetInputFrom replaces the builders of Input by builders of the
+// elements of items.
+func (b *TestConflictBuilder) SetInputFrom(items []TestB) *TestConflictBuilder {
+	b.input_ = make([]*TestBBuilder, 0, len(items))
+	for _, item := range items {
+		builder := NewTestBBuilder()
+		builder.fromModel(item)
+		b.input_ = append(b.input_, builder)
+	}
+	return b
+}
+
+func (b *TestConflictBuilder) AddInput() *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.input_ = append(b.input_, builder)
+	return builder
+}
+
+// AddInputWith calls build with the builder AddInput appends.
+func (b *TestConflictBuilder) AddInputWith(build func(*TestBBuilder)) *TestConflictBuilder {
+	build(b.AddInput())
+	return b
+}
+
+func (b *TestConflictBuilder) RemoveInput(remove *TestBBuilder) {
+	for i, val := range b.input_ {
+		if val == remove {
+			b.input_[i] = b.input_[len(b.input_)-1]
+			b.input_ = b.input_[:len(b.input_)-1]
+		}
+	}
+}
+func (b *TestConflictBuilder) Build() TestConflict {
+	b.model.Model = b.model_.Build()
+	if b.b_ != nil {
+		b_ := b.b_.Build()
+		b.model.B = &b_
+	}
+	b.model.Input = []TestB{}
+	for _, v := range b.input_ {
+		b.model.Input = append(b.model.Input, v.Build())
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestConflictBuilder) BuildPtr() *TestConflict {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestConflictBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Build).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Build: %#v", b.model.Build))
+	}
+	if !reflect.ValueOf(&b.model.BuildObject).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("BuildObject: %#v", b.model.BuildObject))
+	}
+	if b.model_ != nil {
+		fields = append(fields, "Model: "+b.model_.String())
+	}
+	if b.b_ != nil {
+		fields = append(fields, "B: "+b.b_.String())
+	}
+	if len(b.input_) > 0 {
+		fields = append(fields, fmt.Sprintf("Input: %d builders", len(b.input_)))
+	}
+	return "TestConflictBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestConflictBuilder) GoString() string {
+	if b == nil {
+		return "(*TestConflictBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestConflictBuilder{model: %#v, model_: %#v, b_: %#v, input_: %#v}", b.model, b.model_, b.b_, b.input_)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestConflictBuilder) Clone() *TestConflictBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.model_ = b.model_.Clone()
+	clone.b_ = b.b_.Clone()
+	if b.input_ != nil {
+		clone.input_ = make([]*TestBBuilder, len(b.input_))
+		for k, v := range b.input_ {
+			clone.input_[k] = v.Clone()
+		}
+	}
+	return &clone
+}
+
+func (b *TestConflictBuilder) fromModel(model TestConflict) {
+	b.model = model
+	b.model_.fromModel(model.Model)
+	b.b_ = nil
+	if model.B != nil {
+		b.b_ = NewTestBBuilder()
+		b.b_.fromModel(*model.B)
+	}
+	b.input_ = []*TestBBuilder{}
+	for _, v := range model.Input {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.input_ = append(b.input_, builder)
+	}
+}
+
+// NewTestConflictEmbeddedBuilder creates a builder for TestConflictEmbedded.
+func NewTestConflictEmbeddedBuilder() *TestConflictEmbeddedBuilder {
+	builder := &TestConflictEmbeddedBuilder{}
+	builder.model = TestConflictEmbedded{}
+	builder.TestConflictBuilder = *NewTestConflictBuilder()
+	return builder
+}
+
+// NewTestConflictEmbeddedBuilderFromYAML creates a builder for TestConflictEmbedded from the YAML document data.
+func NewTestConflictEmbeddedBuilderFromYAML(data []byte) (*TestConflictEmbeddedBuilder, error) {
+	builder := NewTestConflictEmbeddedBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestConflictEmbeddedBuilder struct {
+	model TestConflictEmbedded
+	TestConflictBuilder
+}
+
+func (b *TestConflictEmbeddedBuilder) TestConflict() *TestConflictBuilder {
+	return &b.TestConflictBuilder
+}
+
+func (b *TestConflictEmbeddedBuilder) SetBuild(input string) *TestConflictEmbeddedBuilder {
+	b.TestConflictBuilder.SetBuild(input)
+	return b
+}
+
+func (b *TestConflictEmbeddedBuilder) SetBuildObject(input int) *TestConflictEmbeddedBuilder {
+	b.TestConflictBuilder.SetBuildObject(input)
+	return b
+}
+
+func (b *TestConflictEmbeddedBuilder) Build() TestConflictEmbedded {
+	b.model.TestConflict = b.TestConflictBuilder.Build()
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestConflictEmbeddedBuilder) BuildPtr() *TestConflictEmbedded {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestConflictEmbeddedBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	fields = append(fields, "TestConflict: "+b.TestConflictBuilder.String())
+	return "TestConflictEmbeddedBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestConflictEmbeddedBuilder) GoString() string {
+	if b == nil {
+		return "(*TestConflictEmbeddedBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestConflictEmbeddedBuilder{model: %#v, TestConflictBuilder: %#v}", b.model, &b.TestConflictBuilder)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestConflictEmbeddedBuilder) Clone() *TestConflictEmbeddedBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.TestConflictBuilder = *b.TestConflictBuilder.Clone()
+	return &clone
+}
+
+func (b *TestConflictEmbeddedBuilder) fromModel(model TestConflictEmbedded) {
+	b.model = model
+	b.TestConflictBuilder.fromModel(model.TestConflict)
+}
+
+// NewTestCoordBuilder creates a builder for TestCoord.
+func NewTestCoordBuilder() *TestCoordBuilder {
+	builder := &TestCoordBuilder{}
+	builder.model = TestCoord{}
+	return builder
+}
+
+// NewTestCoordBuilderFromYAML creates a builder for TestCoord from the YAML document data.
+func NewTestCoordBuilderFromYAML(data []byte) (*TestCoordBuilder, error) {
+	builder := NewTestCoordBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestCoordBuilder struct {
+	model TestCoord
+}
+
+func (b *TestCoordBuilder) X(input int) *TestCoordBuilder {
+	b.model.X = input
+	return b
+}
+
+func (b *TestCoordBuilder) Y(input int) *TestCoordBuilder {
+	b.model.Y = input
+	return b
+}
+
+func (b *TestCoordBuilder) Build() TestCoord {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestCoordBuilder) BuildPtr() *TestCoord {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestCoordBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.X).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("X: %#v", b.model.X))
+	}
+	if !reflect.ValueOf(&b.model.Y).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Y: %#v", b.model.Y))
+	}
+	return "TestCoordBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestCoordBuilder) GoString() string {
+	if b == nil {
+		return "(*TestCoordBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestCoordBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestCoordBuilder) Clone() *TestCoordBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestCoordBuilder) fromModel(model TestCoord) {
+	b.model = model
+}
+
+// NewTestDBuilderFromYAML creates a builder for TestD from the YAML document data.
+func NewTestDBuilderFromYAML(data []byte) (*TestDBuilder, error) {
+	builder := NewTestDBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestDBuilder struct {
+	model TestD
+}
+
+func (b *TestDBuilder) KeyD(input int) *TestDBuilder {
+	b.model.KeyD = input
+	return b
+}
+
+func (b *TestDBuilder) Build() TestD {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestDBuilder) BuildPtr() *TestD {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestDBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.KeyD).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("KeyD: %#v", b.model.KeyD))
+	}
+	return "TestDBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestDBuilder) GoString() string {
+	if b == nil {
+		return "(*TestDBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestDBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestDBuilder) Clone() *TestDBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestDBuilder) fromModel(model TestD) {
+	b.model = model
+}
+
+// NewTestDeepCopiedBuilder creates a builder for TestDeepCopied.
+//
+// TestDeepCopied also gets the deep-copy functions of deepcopy-gen, run with
+// the builders by the shared-generators golden case.
+func NewTestDeepCopiedBuilder() *TestDeepCopiedBuilder {
+	builder := &TestDeepCopiedBuilder{}
+	builder.model = TestDeepCopied{}
+	return builder
+}
+
+// NewTestDeepCopiedBuilderFromYAML creates a builder for TestDeepCopied from the YAML document data.
+func NewTestDeepCopiedBuilderFromYAML(data []byte) (*TestDeepCopiedBuilder, error) {
+	builder := NewTestDeepCopiedBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestDeepCopiedBuilder struct {
+	model TestDeepCopied
+}
+
+func (b *TestDeepCopiedBuilder) Name(input string) *TestDeepCopiedBuilder {
+	b.model.Name = input
+	return b
+}
+
+func (b *TestDeepCopiedBuilder) Tags(input []string) *TestDeepCopiedBuilder {
+	if input != nil {
+		input = append(make([]string, 0, len(input)), input...)
+	}
+	b.model.Tags = input
+	return b
+}
+
+func (b *TestDeepCopiedBuilder) AddTags(items ...string) *TestDeepCopiedBuilder {
+	b.model.Tags = append(b.model.Tags, items...)
+	return b
+}
+
+func (b *TestDeepCopiedBuilder) AppendTags(item string) *TestDeepCopiedBuilder {
+	b.model.Tags = append(b.model.Tags, item)
+	return b
+}
+
+func (b *TestDeepCopiedBuilder) Labels(input map[string]string) *TestDeepCopiedBuilder {
+	b.model.Labels = input
+	return b
+}
+
+func (b *TestDeepCopiedBuilder) SetLabelsEntry(key string, value string) *TestDeepCopiedBuilder {
+	if b.model.Labels == nil {
+		b.model.Labels = map[string]string{}
+	}
+	b.model.Labels[key] = value
+	return b
+}
+
+func (b *TestDeepCopiedBuilder) Count(input *int) *TestDeepCopiedBuilder {
+	b.model.Count = input
+	return b
+}
+
+func (b *TestDeepCopiedBuilder) Build() TestDeepCopied {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestDeepCopiedBuilder) BuildPtr() *TestDeepCopied {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestDeepCopiedBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	if !reflect.ValueOf(&b.model.Tags).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Tags: %+v", b.model.Tags))
+	}
+	if !reflect.ValueOf(&b.model.Labels).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Labels: %+v", b.model.Labels))
+	}
+	if !reflect.ValueOf(&b.model.Count).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Count: %+v", b.model.Count))
+	}
+	return "TestDeepCopiedBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestDeepCopiedBuilder) GoString() string {
+	if b == nil {
+		return "(*TestDeepCopiedBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestDeepCopiedBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestDeepCopiedBuilder) Clone() *TestDeepCopiedBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.model.Tags != nil {
+		clone.model.Tags = make([]string, len(b.model.Tags))
+		copy(clone.model.Tags, b.model.Tags)
+	}
+	if b.model.Labels != nil {
+		clone.model.Labels = make(map[string]string, len(b.model.Labels))
+		for k, v := range b.model.Labels {
+			clone.model.Labels[k] = v
+		}
+	}
+	return &clone
+}
+
+func (b *TestDeepCopiedBuilder) fromModel(model TestDeepCopied) {
+	b.model = model
+}
+
+// NewTestDocBuilder creates a builder for TestDoc.
+//
+// TestDoc is a documented type, its comments are copied to the builder.
+func NewTestDocBuilder() *TestDocBuilder {
+	builder := &TestDocBuilder{}
+	builder.model = TestDoc{}
+	builder.model.TestTag()
+	builder.items = []*TestDocItemBuilder{}
+	return builder
+}
+
+// NewTestDocBuilderFromYAML creates a builder for TestDoc from the YAML document data.
+func NewTestDocBuilderFromYAML(data []byte) (*TestDocBuilder, error) {
+	builder := NewTestDocBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestDocBuilder struct {
+	model TestDoc
+	items []*TestDocItemBuilder
+	item  *TestDocItemBuilder
+	*TestDBuilder
+}
+
+// Name is the display name.
+func (b *TestDocBuilder) Name(input string) *TestDocBuilder {
+	b.model.Name = input
+	return b
+}
+
+// Price is the amount in $ cents.
+func (b *TestDocBuilder) Price(input int) *TestDocBuilder {
+	b.model.Price = input
+	return b
+}
+
+// SetItemsFrom replaces the builders of Items by builders of the
+// elements of items.
+func (b *TestDocBuilder) SetItemsFrom(items []TestDocItem) *TestDocBuilder {
+	b.items = make([]*TestDocItemBuilder, 0, len(items))
+	for _, item := range items {
+		builder := NewTestDocItemBuilder()
+		builder.fromModel(item)
+		b.items = append(b.items, builder)
+	}
+	return b
+}
+
+// Items are the nested documented builders.
+func (b *TestDocBuilder) AddItems() *TestDocItemBuilder {
+	builder := NewTestDocItemBuilder()
+	b.items = append(b.items, builder)
+	return builder
+}
+
+// AddItemsWith calls build with the builder AddItems appends.
+func (b *TestDocBuilder) AddItemsWith(build func(*TestDocItemBuilder)) *TestDocBuilder {
+	build(b.AddItems())
+	return b
+}
+
+func (b *TestDocBuilder) RemoveItems(remove *TestDocItemBuilder) {
+	for i, val := range b.items {
+		if val == remove {
+			b.items[i] = b.items[len(b.items)-1]
+			b.items = b.items[:len(b.items)-1]
+		}
+	}
+}
+
+// Item is the main item.
+func (b *TestDocBuilder) Item() *TestDocItemBuilder {
+	if b.item == nil {
+		b.item = NewTestDocItemBuilder()
+	}
+	return b.item
+}
+
+// SetItem sets Item to a copy of the value input points to, nil
+// if input is nil.
+func (b *TestDocBuilder) SetItem(input *TestDocItem) *TestDocBuilder {
+	b.item = nil
+	b.model.Item = nil
+	if input != nil {
+		b.item = NewTestDocItemBuilder()
+		b.item.fromModel(*input)
+	}
+	return b
+}
+
+func (b *TestDocBuilder) TestD() *TestDBuilder {
+	if b.TestDBuilder == nil {
+		b.TestDBuilder = NewTestDBuilder()
+	}
+	return b.TestDBuilder
+}
+
+// SetTestD sets TestD to a copy of the value input points to, nil
+// if input is nil.
+func (b *TestDocBuilder) SetTestD(input *TestD) *TestDocBuilder {
+	b.TestDBuilder = nil
+	b.model.TestD = nil
+	if input != nil {
+		b.TestDBuilder = NewTestDBuilder()
+		b.TestDBuilder.fromModel(*input)
+	}
+	return b
+}
+
+func (b *TestDocBuilder) KeyD(input int) *TestDocBuilder {
+	if b.TestDBuilder == nil {
+		b.TestDBuilder = NewTestDBuilder()
+	}
+	b.TestDBuilder.KeyD(input)
+	return b
+}
+
+func (b *TestDocBuilder) Build() TestDoc {
+	b.model.Items = []TestDocItem{}
+	for _, v := range b.items {
+		b.model.Items = append(b.model.Items, v.Build())
+	}
+	if b.item != nil {
+		item := b.item.Build()
+		b.model.Item = &item
+	}
+	if b.TestDBuilder != nil {
+		testd := b.TestDBuilder.Build()
+		b.model.TestD = &testd
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestDocBuilder) BuildPtr() *TestDoc {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestDocBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	if !reflect.ValueOf(&b.model.Price).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Price: %#v", b.model.Price))
+	}
+	if len(b.items) > 0 {
+		fields = append(fields, fmt.Sprintf("Items: %d builders", len(b.items)))
+	}
+	if b.item != nil {
+		fields = append(fields, "Item: "+b.item.String())
+	}
+	if b.TestDBuilder != nil {
+		fields = append(fields, "TestD: "+b.TestDBuilder.String())
+	}
+	return "TestDocBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestDocBuilder) GoString() string {
+	if b == nil {
+		return "(*TestDocBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestDocBuilder{model: %#v, items: %#v, item: %#v, TestDBuilder: %#v}", b.model, b.items, b.item, b.TestDBuilder)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestDocBuilder) Clone() *TestDocBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.items != nil {
+		clone.items = make([]*TestDocItemBuilder, len(b.items))
+		for k, v := range b.items {
+			clone.items[k] = v.Clone()
+		}
+	}
+	clone.item = b.item.Clone()
+	clone.TestDBuilder = b.TestDBuilder.Clone()
+	return &clone
+}
+
+func (b *TestDocBuilder) fromModel(model TestDoc) {
+	b.model = model
+	b.items = []*TestDocItemBuilder{}
+	for _, v := range model.Items {
+		builder := NewTestDocItemBuilder()
+		builder.fromModel(v)
+		b.items = append(b.items, builder)
+	}
+	b.item = nil
+	if model.Item != nil {
+		b.item = NewTestDocItemBuilder()
+		b.item.fromModel(*model.Item)
+	}
+	b.TestDBuilder = nil
+	if model.TestD != nil {
+		b.TestDBuilder = NewTestDBuilder()
+		b.TestDBuilder.fromModel(*model.TestD)
+	}
+}
+
+// NewTestDocItemBuilder creates a builder for TestDocItem.
+//
+// TestDocItem is an item of TestDoc.
+func NewTestDocItemBuilder() *TestDocItemBuilder {
+	builder := &TestDocItemBuilder{}
+	builder.model = TestDocItem{}
+	return builder
+}
+
+// NewTestDocItemBuilderFromYAML creates a builder for TestDocItem from the YAML document data.
+func NewTestDocItemBuilderFromYAML(data []byte) (*TestDocItemBuilder, error) {
+	builder := NewTestDocItemBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestDocItemBuilder struct {
+	model TestDocItem
+}
+
+// Label identifies the item.
+func (b *TestDocItemBuilder) Label(input string) *TestDocItemBuilder {
+	b.model.Label = input
+	return b
+}
+
+func (b *TestDocItemBuilder) Build() TestDocItem {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestDocItemBuilder) BuildPtr() *TestDocItem {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestDocItemBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Label).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Label: %#v", b.model.Label))
+	}
+	return "TestDocItemBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestDocItemBuilder) GoString() string {
+	if b == nil {
+		return "(*TestDocItemBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestDocItemBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestDocItemBuilder) Clone() *TestDocItemBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestDocItemBuilder) fromModel(model TestDocItem) {
+	b.model = model
+}
+
+// NewTestEBuilder creates a builder for TestE.
+func NewTestEBuilder() *TestEBuilder {
+	builder := &TestEBuilder{}
+	builder.model = TestE{}
+	return builder
+}
+
+// NewTestEBuilderFromYAML creates a builder for TestE from the YAML document data.
+func NewTestEBuilderFromYAML(data []byte) (*TestEBuilder, error) {
+	builder := NewTestEBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestEBuilder struct {
+	model TestE
+	*TestDBuilder
+	testg *TestGBuilder
+}
+
+func (b *TestEBuilder) TestD() *TestDBuilder {
+	if b.TestDBuilder == nil {
+		b.TestDBuilder = NewTestDBuilder()
+	}
+	return b.TestDBuilder
+}
+
+// SetTestD sets TestD to a copy of the value input points to, nil
+// if input is nil.
+func (b *TestEBuilder) SetTestD(input *TestD) *TestEBuilder {
+	b.TestDBuilder = nil
+	b.model.TestD = nil
+	if input != nil {
+		b.TestDBuilder = NewTestDBuilder()
+		b.TestDBuilder.fromModel(*input)
+	}
+	return b
+}
+
+func (b *TestEBuilder) KeyD(input int) *TestEBuilder {
+	if b.TestDBuilder == nil {
+		b.TestDBuilder = NewTestDBuilder()
+	}
+	b.TestDBuilder.KeyD(input)
+	return b
+}
+
+func (b *TestEBuilder) TestG() *TestGBuilder {
+	if b.testg == nil {
+		b.testg = NewTestGBuilder()
+	}
+	return b.testg
+}
+
+// SetTestG sets TestG to a copy of the value input points to, nil
+// if input is nil.
+func (b *TestEBuilder) SetTestG(input *TestG) *TestEBuilder {
+	b.testg = nil
+	b.model.TestG = nil
+	if input != nil {
+		b.testg = NewTestGBuilder()
+		b.testg.fromModel(*input)
+	}
+	return b
+}
+
+func (b *TestEBuilder) Build() TestE {
+	if b.TestDBuilder != nil {
+		testd := b.TestDBuilder.Build()
+		b.model.TestD = &testd
+	}
+	if b.testg != nil {
+		testg := b.testg.Build()
+		b.model.TestG = &testg
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestEBuilder) BuildPtr() *TestE {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestEBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if b.TestDBuilder != nil {
+		fields = append(fields, "TestD: "+b.TestDBuilder.String())
+	}
+	if !reflect.ValueOf(&b.model.KeyE).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("KeyE: %#v", b.model.KeyE))
+	}
+	if b.testg != nil {
+		fields = append(fields, "TestG: "+b.testg.String())
+	}
+	return "TestEBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestEBuilder) GoString() string {
+	if b == nil {
+		return "(*TestEBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestEBuilder{model: %#v, TestDBuilder: %#v, testg: %#v}", b.model, b.TestDBuilder, b.testg)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestEBuilder) Clone() *TestEBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.TestDBuilder = b.TestDBuilder.Clone()
+	clone.testg = b.testg.Clone()
+	return &clone
+}
+
+func (b *TestEBuilder) fromModel(model TestE) {
+	b.model = model
+	b.TestDBuilder = nil
+	if model.TestD != nil {
+		b.TestDBuilder = NewTestDBuilder()
+		b.TestDBuilder.fromModel(*model.TestD)
+	}
+	b.testg = nil
+	if model.TestG != nil {
+		b.testg = NewTestGBuilder()
+		b.testg.fromModel(*model.TestG)
+	}
+}
+
+// NewTestEmbeddedValueBuilder creates a builder for TestEmbeddedValue.
+func NewTestEmbeddedValueBuilder() *TestEmbeddedValueBuilder {
+	builder := &TestEmbeddedValueBuilder{}
+	builder.model = TestEmbeddedValue{}
+	return builder
+}
+
+// NewTestEmbeddedValueBuilderFromYAML creates a builder for TestEmbeddedValue from the YAML document data.
+func NewTestEmbeddedValueBuilderFromYAML(data []byte) (*TestEmbeddedValueBuilder, error) {
+	builder := NewTestEmbeddedValueBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestEmbeddedValueBuilder struct {
+	model TestEmbeddedValue
+}
+
+func (b *TestEmbeddedValueBuilder) TestD(input TestD) *TestEmbeddedValueBuilder {
+	b.model.TestD = input
+	return b
+}
+
+func (b *TestEmbeddedValueBuilder) TestG(input *TestG) *TestEmbeddedValueBuilder {
+	b.model.TestG = input
+	return b
+}
+
+func (b *TestEmbeddedValueBuilder) Name(input string) *TestEmbeddedValueBuilder {
+	b.model.Name = input
+	return b
+}
+
+func (b *TestEmbeddedValueBuilder) Build() TestEmbeddedValue {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestEmbeddedValueBuilder) BuildPtr() *TestEmbeddedValue {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestEmbeddedValueBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.TestD).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("TestD: %+v", b.model.TestD))
+	}
+	if !reflect.ValueOf(&b.model.TestG).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("TestG: %+v", b.model.TestG))
+	}
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	return "TestEmbeddedValueBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestEmbeddedValueBuilder) GoString() string {
+	if b == nil {
+		return "(*TestEmbeddedValueBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestEmbeddedValueBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestEmbeddedValueBuilder) Clone() *TestEmbeddedValueBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestEmbeddedValueBuilder) fromModel(model TestEmbeddedValue) {
+	b.model = model
+}
+
+// NewTestEndBuilder creates a builder for TestEnd.
+//
+// TestEnd is a TestState held by pointer.
+func NewTestEndBuilder() *TestEndBuilder {
+	builder := &TestEndBuilder{}
+	builder.model = TestEnd{}
+	return builder
+}
+
+// NewTestEndBuilderFromYAML creates a builder for TestEnd from the YAML document data.
+func NewTestEndBuilderFromYAML(data []byte) (*TestEndBuilder, error) {
+	builder := NewTestEndBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestEndBuilder struct {
+	model TestEnd
+}
+
+func (b *TestEndBuilder) Code(input int) *TestEndBuilder {
+	b.model.Code = input
+	return b
+}
+
+func (b *TestEndBuilder) Build() TestEnd {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestEndBuilder) BuildPtr() *TestEnd {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestEndBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Code).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Code: %#v", b.model.Code))
+	}
+	return "TestEndBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestEndBuilder) GoString() string {
+	if b == nil {
+		return "(*TestEndBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestEndBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestEndBuilder) Clone() *TestEndBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestEndBuilder) fromModel(model TestEnd) {
+	b.model = model
+}
+
+// NewTestExtensionBuilder creates a builder for TestExtension.
+//
+// TestExtension has extension data members of interface types.
+func NewTestExtensionBuilder() *TestExtensionBuilder {
+	builder := &TestExtensionBuilder{}
+	builder.model = TestExtension{}
+	return builder
+}
+
+// NewTestExtensionBuilderFromYAML creates a builder for TestExtension from the YAML document data.
+func NewTestExtensionBuilderFromYAML(data []byte) (*TestExtensionBuilder, error) {
+	builder := NewTestExtensionBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestExtensionBuilder struct {
+	model TestExtension
+}
+
+func (b *TestExtensionBuilder) Extra(input interface{}) *TestExtensionBuilder {
+	b.model.Extra = input
+	return b
+}
+
+// Config holds a decoded JSON document.
+func (b *TestExtensionBuilder) Config(input interface{}) *TestExtensionBuilder {
+	b.model.Config = input
+	return b
+}
+
+// SetConfigJSON sets Config to the decoded JSON document data.
+func (b *TestExtensionBuilder) SetConfigJSON(data []byte) error {
+	var input interface{}
+	if err := json.Unmarshal(data, &input); err != nil {
+		return err
+	}
+	b.model.Config = input
+	return nil
+}
+
+func (b *TestExtensionBuilder) Stringer(input fmt.Stringer) *TestExtensionBuilder {
+	b.model.Stringer = input
+	return b
+}
+
+func (b *TestExtensionBuilder) Build() TestExtension {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestExtensionBuilder) BuildPtr() *TestExtension {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestExtensionBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Extra).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Extra: %+v", b.model.Extra))
+	}
+	if !reflect.ValueOf(&b.model.Config).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Config: %+v", b.model.Config))
+	}
+	if !reflect.ValueOf(&b.model.Stringer).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Stringer: %+v", b.model.Stringer))
+	}
+	return "TestExtensionBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestExtensionBuilder) GoString() string {
+	if b == nil {
+		return "(*TestExtensionBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestExtensionBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestExtensionBuilder) Clone() *TestExtensionBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestExtensionBuilder) fromModel(model TestExtension) {
+	b.model = model
+}
+
+// NewTestFBuilder creates a builder for TestF.
+func NewTestFBuilder() *TestFBuilder {
+	builder := &TestFBuilder{}
+	builder.model = TestF{}
+	builder.TestEBuilder = *NewTestEBuilder()
+	return builder
+}
+
+// NewTestFBuilderFromYAML creates a builder for TestF from the YAML document data.
+func NewTestFBuilderFromYAML(data []byte) (*TestFBuilder, error) {
+	builder := NewTestFBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestFBuilder struct {
+	model TestF
+	TestEBuilder
+}
+
+func (b *TestFBuilder) KeyE(input int) *TestFBuilder {
+	b.TestEBuilder.KeyE(input)
+	return b
+}
+
+func (b *TestFBuilder) KeyD(input int) *TestFBuilder {
+	if b.TestEBuilder.TestDBuilder == nil {
+		b.TestEBuilder.TestDBuilder = NewTestDBuilder()
+	}
+	b.TestEBuilder.TestDBuilder.KeyD(input)
+	return b
+}
+
+func (b *TestFBuilder) Build() TestF {
+	b.model.TestE = b.TestEBuilder.Build()
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestFBuilder) BuildPtr() *TestF {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestFBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	fields = append(fields, "TestE: "+b.TestEBuilder.String())
+	return "TestFBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestFBuilder) GoString() string {
+	if b == nil {
+		return "(*TestFBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestFBuilder{model: %#v, TestEBuilder: %#v}", b.model, &b.TestEBuilder)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestFBuilder) Clone() *TestFBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.TestEBuilder = *b.TestEBuilder.Clone()
+	return &clone
+}
+
+func (b *TestFBuilder) fromModel(model TestF) {
+	b.model = model
+	b.TestEBuilder.fromModel(model.TestE)
+}
+
+// NewTestFlagsBuilder creates a builder for TestFlags.
+//
+// TestFlags is a named map of primitives.
+func NewTestFlagsBuilder() *TestFlagsBuilder {
+	builder := &TestFlagsBuilder{}
+	builder.model = TestFlags{}
+	return builder
+}
+
+// NewTestFlagsBuilderFromYAML creates a builder for TestFlags from the YAML document data.
+func NewTestFlagsBuilderFromYAML(data []byte) (*TestFlagsBuilder, error) {
+	builder := NewTestFlagsBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestFlagsBuilder struct {
+	model TestFlags
+}
+
+func (b *TestFlagsBuilder) Build() TestFlags {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestFlagsBuilder) BuildPtr() *TestFlags {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestFlagsBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	return "TestFlagsBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestFlagsBuilder) GoString() string {
+	if b == nil {
+		return "(*TestFlagsBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestFlagsBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestFlagsBuilder) Clone() *TestFlagsBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestFlagsBuilder) fromModel(model TestFlags) {
+	b.model = model
+}
+
+// NewTestFlattenBuilder creates a builder for TestFlatten.
+func NewTestFlattenBuilder() *TestFlattenBuilder {
+	builder := &TestFlattenBuilder{}
+	builder.model = TestFlatten{}
+	builder.TestFlattenBaseBuilder = *NewTestFlattenBaseBuilder()
+	return builder
+}
+
+// NewTestFlattenBuilderFromYAML creates a builder for TestFlatten from the YAML document data.
+func NewTestFlattenBuilderFromYAML(data []byte) (*TestFlattenBuilder, error) {
+	builder := NewTestFlattenBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestFlattenBuilder struct {
+	model TestFlatten
+	TestFlattenBaseBuilder
+}
+
+func (b *TestFlattenBuilder) TestFlattenBase() *TestFlattenBaseBuilder {
+	return &b.TestFlattenBaseBuilder
+}
+
+func (b *TestFlattenBuilder) Name(input string) *TestFlattenBuilder {
+	b.TestFlattenBaseBuilder.Name(input)
+	return b
+}
+
+func (b *TestFlattenBuilder) Replicas(input int) *TestFlattenBuilder {
+	b.model.Replicas = input
+	return b
+}
+
+func (b *TestFlattenBuilder) Build() TestFlatten {
+	b.model.TestFlattenBase = b.TestFlattenBaseBuilder.Build()
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestFlattenBuilder) BuildPtr() *TestFlatten {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestFlattenBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	fields = append(fields, "TestFlattenBase: "+b.TestFlattenBaseBuilder.String())
+	if !reflect.ValueOf(&b.model.Replicas).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Replicas: %#v", b.model.Replicas))
+	}
+	return "TestFlattenBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestFlattenBuilder) GoString() string {
+	if b == nil {
+		return "(*TestFlattenBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestFlattenBuilder{model: %#v, TestFlattenBaseBuilder: %#v}", b.model, &b.TestFlattenBaseBuilder)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestFlattenBuilder) Clone() *TestFlattenBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.TestFlattenBaseBuilder = *b.TestFlattenBaseBuilder.Clone()
+	return &clone
+}
+
+func (b *TestFlattenBuilder) fromModel(model TestFlatten) {
+	b.model = model
+	b.TestFlattenBaseBuilder.fromModel(model.TestFlattenBase)
+}
+
+// NewTestFlattenBaseBuilder creates a builder for TestFlattenBase.
+func NewTestFlattenBaseBuilder() *TestFlattenBaseBuilder {
+	builder := &TestFlattenBaseBuilder{}
+	builder.model = TestFlattenBase{}
+	return builder
+}
+
+// NewTestFlattenBaseBuilderFromYAML creates a builder for TestFlattenBase from the YAML document data.
+func NewTestFlattenBaseBuilderFromYAML(data []byte) (*TestFlattenBaseBuilder, error) {
+	builder := NewTestFlattenBaseBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestFlattenBaseBuilder struct {
+	model TestFlattenBase
+}
+
+func (b *TestFlattenBaseBuilder) Name(input string) *TestFlattenBaseBuilder {
+	b.model.Name = input
+	return b
+}
+
+func (b *TestFlattenBaseBuilder) Tags(input []string) *TestFlattenBaseBuilder {
+	if input != nil {
+		input = append(make([]string, 0, len(input)), input...)
+	}
+	b.model.Tags = input
+	return b
+}
+
+func (b *TestFlattenBaseBuilder) AddTags(items ...string) *TestFlattenBaseBuilder {
+	b.model.Tags = append(b.model.Tags, items...)
+	return b
+}
+
+func (b *TestFlattenBaseBuilder) AppendTags(item string) *TestFlattenBaseBuilder {
+	b.model.Tags = append(b.model.Tags, item)
+	return b
+}
+
+func (b *TestFlattenBaseBuilder) Labels(input map[string]string) *TestFlattenBaseBuilder {
+	b.model.Labels = input
+	return b
+}
+
+func (b *TestFlattenBaseBuilder) SetLabelsEntry(key string, value string) *TestFlattenBaseBuilder {
+	if b.model.Labels == nil {
+		b.model.Labels = map[string]string{}
+	}
+	b.model.Labels[key] = value
+	return b
+}
+
+func (b *TestFlattenBaseBuilder) Owner(input *string) *TestFlattenBaseBuilder {
+	b.model.Owner = input
+	return b
+}
+
+func (b *TestFlattenBaseBuilder) Build() TestFlattenBase {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestFlattenBaseBuilder) BuildPtr() *TestFlattenBase {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestFlattenBaseBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	if !reflect.ValueOf(&b.model.Tags).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Tags: %+v", b.model.Tags))
+	}
+	if !reflect.ValueOf(&b.model.Labels).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Labels: %+v", b.model.Labels))
+	}
+	if !reflect.ValueOf(&b.model.Owner).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Owner: %+v", b.model.Owner))
+	}
+	return "TestFlattenBaseBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestFlattenBaseBuilder) GoString() string {
+	if b == nil {
+		return "(*TestFlattenBaseBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestFlattenBaseBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestFlattenBaseBuilder) Clone() *TestFlattenBaseBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.model.Tags != nil {
+		clone.model.Tags = make([]string, len(b.model.Tags))
+		copy(clone.model.Tags, b.model.Tags)
+	}
+	if b.model.Labels != nil {
+		clone.model.Labels = make(map[string]string, len(b.model.Labels))
+		for k, v := range b.model.Labels {
+			clone.model.Labels[k] = v
+		}
+	}
+	return &clone
+}
+
+func (b *TestFlattenBaseBuilder) fromModel(model TestFlattenBase) {
+	b.model = model
+}
+
+// NewTestForeignAliasBuilder creates a builder for TestForeignAlias.
+func NewTestForeignAliasBuilder() *TestForeignAliasBuilder {
+	builder := &TestForeignAliasBuilder{}
+	builder.model = TestForeignAlias{}
+	return builder
+}
+
+// NewTestForeignAliasBuilderFromYAML creates a builder for TestForeignAlias from the YAML document data.
+func NewTestForeignAliasBuilderFromYAML(data []byte) (*TestForeignAliasBuilder, error) {
+	builder := NewTestForeignAliasBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestForeignAliasBuilder struct {
+	model TestForeignAlias
+}
+
+func (b *TestForeignAliasBuilder) Meta(input v1.ObjectMeta) *TestForeignAliasBuilder {
+	b.model.Meta = input
+	return b
+}
+
+func (b *TestForeignAliasBuilder) MetaPointer(input *v1.ObjectMeta) *TestForeignAliasBuilder {
+	b.model.MetaPointer = input
+	return b
+}
+
+func (b *TestForeignAliasBuilder) Metas(input []v1.ObjectMeta) *TestForeignAliasBuilder {
+	b.model.Metas = input
+	return b
+}
+
+func (b *TestForeignAliasBuilder) MetaList(input TestMetaList) *TestForeignAliasBuilder {
+	b.model.MetaList = input
+	return b
+}
+
+func (b *TestForeignAliasBuilder) MetaPtr(input TestMetaPtr) *TestForeignAliasBuilder {
+	b.model.MetaPtr = input
+	return b
+}
+
+func (b *TestForeignAliasBuilder) MetaMap(input map[string]v1.ObjectMeta) *TestForeignAliasBuilder {
+	b.model.MetaMap = input
+	return b
+}
+
+func (b *TestForeignAliasBuilder) Ignored(input TestC) *TestForeignAliasBuilder {
+	b.model.Ignored = input
+	return b
+}
+
+func (b *TestForeignAliasBuilder) IgnoredList(input []*TestC) *TestForeignAliasBuilder {
+	b.model.IgnoredList = input
+	return b
+}
+
+func (b *TestForeignAliasBuilder) Build() TestForeignAlias {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestForeignAliasBuilder) BuildPtr() *TestForeignAlias {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestForeignAliasBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Meta).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Meta: %+v", b.model.Meta))
+	}
+	if !reflect.ValueOf(&b.model.MetaPointer).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("MetaPointer: %+v", b.model.MetaPointer))
+	}
+	if !reflect.ValueOf(&b.model.Metas).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Metas: %+v", b.model.Metas))
+	}
+	if !reflect.ValueOf(&b.model.MetaList).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("MetaList: %+v", b.model.MetaList))
+	}
+	if !reflect.ValueOf(&b.model.MetaPtr).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("MetaPtr: %+v", b.model.MetaPtr))
+	}
+	if !reflect.ValueOf(&b.model.MetaMap).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("MetaMap: %+v", b.model.MetaMap))
+	}
+	if !reflect.ValueOf(&b.model.Ignored).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Ignored: %+v", b.model.Ignored))
+	}
+	if !reflect.ValueOf(&b.model.IgnoredList).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("IgnoredList: %+v", b.model.IgnoredList))
+	}
+	return "TestForeignAliasBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestForeignAliasBuilder) GoString() string {
+	if b == nil {
+		return "(*TestForeignAliasBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestForeignAliasBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestForeignAliasBuilder) Clone() *TestForeignAliasBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.model.Metas != nil {
+		clone.model.Metas = make([]v1.ObjectMeta, len(b.model.Metas))
+		copy(clone.model.Metas, b.model.Metas)
+	}
+	if b.model.MetaList != nil {
+		clone.model.MetaList = make(TestMetaList, len(b.model.MetaList))
+		copy(clone.model.MetaList, b.model.MetaList)
+	}
+	if b.model.MetaMap != nil {
+		clone.model.MetaMap = make(map[string]v1.ObjectMeta, len(b.model.MetaMap))
+		for k, v := range b.model.MetaMap {
+			clone.model.MetaMap[k] = v
+		}
+	}
+	if b.model.IgnoredList != nil {
+		clone.model.IgnoredList = make([]*TestC, len(b.model.IgnoredList))
+		copy(clone.model.IgnoredList, b.model.IgnoredList)
+	}
+	return &clone
+}
+
+func (b *TestForeignAliasBuilder) fromModel(model TestForeignAlias) {
+	b.model = model
+}
+
+// NewTestGBuilder creates a builder for TestG.
+func NewTestGBuilder() *TestGBuilder {
+	builder := &TestGBuilder{}
+	builder.model = TestG{}
+	return builder
+}
+
+// NewTestGBuilderFromYAML creates a builder for TestG from the YAML document data.
+func NewTestGBuilderFromYAML(data []byte) (*TestGBuilder, error) {
+	builder := NewTestGBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestGBuilder struct {
+	model TestG
+}
+
+func (b *TestGBuilder) KeyG(input int) *TestGBuilder {
+	b.model.KeyG = input
+	return b
+}
+
+func (b *TestGBuilder) Build() TestG {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestGBuilder) BuildPtr() *TestG {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestGBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.KeyG).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("KeyG: %#v", b.model.KeyG))
+	}
+	return "TestGBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestGBuilder) GoString() string {
+	if b == nil {
+		return "(*TestGBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestGBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestGBuilder) Clone() *TestGBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestGBuilder) fromModel(model TestG) {
+	b.model = model
+}
+
+// NewTestGridBuilder creates a builder for TestGrid.
+func NewTestGridBuilder() *TestGridBuilder {
+	builder := &TestGridBuilder{}
+	builder.model = TestGrid{}
+	builder.cells = map[TestCoord]*TestCellBuilder{}
+	builder.regions = map[other.Geo]*TestCellBuilder{}
+	return builder
+}
+
+// NewTestGridBuilderFromYAML creates a builder for TestGrid from the YAML document data.
+func NewTestGridBuilderFromYAML(data []byte) (*TestGridBuilder, error) {
+	builder := NewTestGridBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestGridBuilder struct {
+	model   TestGrid
+	cells   map[TestCoord]*TestCellBuilder
+	regions map[other.Geo]*TestCellBuilder
+}
+
+func (b *TestGridBuilder) Cells(input map[TestCoord]TestCell) *TestGridBuilder {
+	b.cells = map[TestCoord]*TestCellBuilder{}
+	for k, v := range input {
+		builder := NewTestCellBuilder()
+		builder.fromModel(v)
+		b.cells[k] = builder
+	}
+	return b
+}
+
+func (b *TestGridBuilder) AddCells(key TestCoord) *TestCellBuilder {
+	builder := NewTestCellBuilder()
+	b.cells[key] = builder
+	return builder
+}
+
+// AddCellsWith calls build with the builder AddCells sets for key.
+func (b *TestGridBuilder) AddCellsWith(key TestCoord, build func(*TestCellBuilder)) *TestGridBuilder {
+	build(b.AddCells(key))
+	return b
+}
+
+// RemoveCells drops the builder of key from Cells.
+func (b *TestGridBuilder) RemoveCells(key TestCoord) {
+	delete(b.cells, key)
+}
+
+func (b *TestGridBuilder) Marks(input map[TestCoord]bool) *TestGridBuilder {
+	b.model.Marks = input
+	return b
+}
+
+func (b *TestGridBuilder) SetMarksEntry(key TestCoord, value bool) *TestGridBuilder {
+	if b.model.Marks == nil {
+		b.model.Marks = map[TestCoord]bool{}
+	}
+	b.model.Marks[key] = value
+	return b
+}
+
+func (b *TestGridBuilder) Regions(input map[other.Geo]*TestCell) *TestGridBuilder {
+	b.regions = map[other.Geo]*TestCellBuilder{}
+	for k, v := range input {
+		if v == nil {
+			continue
+		}
+		builder := NewTestCellBuilder()
+		builder.fromModel(*v)
+		b.regions[k] = builder
+	}
+	return b
+}
+
+func (b *TestGridBuilder) AddRegions(key other.Geo) *TestCellBuilder {
+	builder := NewTestCellBuilder()
+	b.regions[key] = builder
+	return builder
+}
+
+// AddRegionsWith calls build with the builder AddRegions sets for key.
+func (b *TestGridBuilder) AddRegionsWith(key other.Geo, build func(*TestCellBuilder)) *TestGridBuilder {
+	build(b.AddRegions(key))
+	return b
+}
+
+// RemoveRegions drops the builder of key from Regions.
+func (b *TestGridBuilder) RemoveRegions(key other.Geo) {
+	delete(b.regions, key)
+}
+
+func (b *TestGridBuilder) Build() TestGrid {
+	b.model.Cells = map[TestCoord]TestCell{}
+	for k, v := range b.cells {
+		b.model.Cells[k] = v.Build()
+	}
+	b.model.Regions = map[other.Geo]*TestCell{}
+	for k, v := range b.regions {
+		vv := v.Build()
+		b.model.Regions[k] = &vv
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestGridBuilder) BuildPtr() *TestGrid {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestGridBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if len(b.cells) > 0 {
+		fields = append(fields, fmt.Sprintf("Cells: %d builders", len(b.cells)))
+	}
+	if !reflect.ValueOf(&b.model.Marks).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Marks: %+v", b.model.Marks))
+	}
+	if len(b.regions) > 0 {
+		fields = append(fields, fmt.Sprintf("Regions: %d builders", len(b.regions)))
+	}
+	return "TestGridBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestGridBuilder) GoString() string {
+	if b == nil {
+		return "(*TestGridBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestGridBuilder{model: %#v, cells: %#v, regions: %#v}", b.model, b.cells, b.regions)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestGridBuilder) Clone() *TestGridBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.cells != nil {
+		clone.cells = make(map[TestCoord]*TestCellBuilder, len(b.cells))
+		for k, v := range b.cells {
+			clone.cells[k] = v.Clone()
+		}
+	}
+	if b.model.Marks != nil {
+		clone.model.Marks = make(map[TestCoord]bool, len(b.model.Marks))
+		for k, v := range b.model.Marks {
+			clone.model.Marks[k] = v
+		}
+	}
+	if b.regions != nil {
+		clone.regions = make(map[other.Geo]*TestCellBuilder, len(b.regions))
+		for k, v := range b.regions {
+			clone.regions[k] = v.Clone()
+		}
+	}
+	return &clone
+}
+
+func (b *TestGridBuilder) fromModel(model TestGrid) {
+	b.model = model
+	b.cells = map[TestCoord]*TestCellBuilder{}
+	for k, v := range model.Cells {
+		builder := NewTestCellBuilder()
+		builder.fromModel(v)
+		b.cells[k] = builder
+	}
+	b.regions = map[other.Geo]*TestCellBuilder{}
+	for k, v := range model.Regions {
+		if v == nil {
+			continue
+		}
+		builder := NewTestCellBuilder()
+		builder.fromModel(*v)
+		b.regions[k] = builder
+	}
+}
+
+// NewTestHBuilder creates a builder for TestH.
+func NewTestHBuilder() *TestHBuilder {
+	builder := &TestHBuilder{}
+	builder.model = TestH{}
+	builder.TestEBuilder = *NewTestEBuilder()
+	return builder
+}
+
+// NewTestHBuilderFromYAML creates a builder for TestH from the YAML document data.
+func NewTestHBuilderFromYAML(data []byte) (*TestHBuilder, error) {
+	builder := NewTestHBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestHBuilder struct {
+	model TestH
+	TestEBuilder
+}
+
+func (b *TestHBuilder) KeyE(input int) *TestHBuilder {
+	b.TestEBuilder.KeyE(input)
+	return b
+}
+
+func (b *TestHBuilder) KeyD(input int) *TestHBuilder {
+	if b.TestEBuilder.TestDBuilder == nil {
+		b.TestEBuilder.TestDBuilder = NewTestDBuilder()
+	}
+	b.TestEBuilder.TestDBuilder.KeyD(input)
+	return b
+}
+
+func (b *TestHBuilder) Build() TestH {
+	b.model.TestE = b.TestEBuilder.Build()
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestHBuilder) BuildPtr() *TestH {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestHBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	fields = append(fields, "TestE: "+b.TestEBuilder.String())
+	return "TestHBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestHBuilder) GoString() string {
+	if b == nil {
+		return "(*TestHBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestHBuilder{model: %#v, TestEBuilder: %#v}", b.model, &b.TestEBuilder)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestHBuilder) Clone() *TestHBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.TestEBuilder = *b.TestEBuilder.Clone()
+	return &clone
+}
+
+func (b *TestHBuilder) fromModel(model TestH) {
+	b.model = model
+	b.TestEBuilder.fromModel(model.TestE)
+}
+
+// NewTestIBuilder creates a builder for TestI.
+func NewTestIBuilder() *TestIBuilder {
+	builder := &TestIBuilder{}
+	builder.model = TestI{}
+	builder.TestEBuilder = *NewTestEBuilder()
+	return builder
+}
+
+// NewTestIBuilderFromYAML creates a builder for TestI from the YAML document data.
+func NewTestIBuilderFromYAML(data []byte) (*TestIBuilder, error) {
+	builder := NewTestIBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestIBuilder struct {
+	model TestI
+	TestEBuilder
+}
+
+func (b *TestIBuilder) TestE() *TestEBuilder {
+	return &b.TestEBuilder
+}
+
+func (b *TestIBuilder) KeyE(input int) *TestIBuilder {
+	b.TestEBuilder.KeyE(input)
+	return b
+}
+
+func (b *TestIBuilder) Build() TestI {
+	b.model.TestE = b.TestEBuilder.Build()
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestIBuilder) BuildPtr() *TestI {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestIBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	fields = append(fields, "TestE: "+b.TestEBuilder.String())
+	return "TestIBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestIBuilder) GoString() string {
+	if b == nil {
+		return "(*TestIBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestIBuilder{model: %#v, TestEBuilder: %#v}", b.model, &b.TestEBuilder)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestIBuilder) Clone() *TestIBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.TestEBuilder = *b.TestEBuilder.Clone()
+	return &clone
+}
+
+func (b *TestIBuilder) fromModel(model TestI) {
+	b.model = model
+	b.TestEBuilder.fromModel(model.TestE)
+}
+
+// NewTestIdleBuilder creates a builder for TestIdle.
+//
+// TestIdle is a TestState held by value.
+func NewTestIdleBuilder() *TestIdleBuilder {
+	builder := &TestIdleBuilder{}
+	builder.model = TestIdle{}
+	return builder
+}
+
+// NewTestIdleBuilderFromYAML creates a builder for TestIdle from the YAML document data.
+func NewTestIdleBuilderFromYAML(data []byte) (*TestIdleBuilder, error) {
+	builder := NewTestIdleBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestIdleBuilder struct {
+	model TestIdle
+}
+
+func (b *TestIdleBuilder) Name(input string) *TestIdleBuilder {
+	b.model.Name = input
+	return b
+}
+
+func (b *TestIdleBuilder) Build() TestIdle {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestIdleBuilder) BuildPtr() *TestIdle {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestIdleBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	return "TestIdleBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestIdleBuilder) GoString() string {
+	if b == nil {
+		return "(*TestIdleBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestIdleBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestIdleBuilder) Clone() *TestIdleBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestIdleBuilder) fromModel(model TestIdle) {
+	b.model = model
+}
+
+// NewTestIgnoredEmbeddedBuilder creates a builder for TestIgnoredEmbedded.
+func NewTestIgnoredEmbeddedBuilder() *TestIgnoredEmbeddedBuilder {
+	builder := &TestIgnoredEmbeddedBuilder{}
+	builder.model = TestIgnoredEmbedded{}
+	return builder
+}
+
+// NewTestIgnoredEmbeddedBuilderFromYAML creates a builder for TestIgnoredEmbedded from the YAML document data.
+func NewTestIgnoredEmbeddedBuilderFromYAML(data []byte) (*TestIgnoredEmbeddedBuilder, error) {
+	builder := NewTestIgnoredEmbeddedBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestIgnoredEmbeddedBuilder struct {
+	model TestIgnoredEmbedded
+}
+
+func (b *TestIgnoredEmbeddedBuilder) Value(input string) *TestIgnoredEmbeddedBuilder {
+	b.model.Value = input
+	return b
+}
+
+func (b *TestIgnoredEmbeddedBuilder) Build() TestIgnoredEmbedded {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestIgnoredEmbeddedBuilder) BuildPtr() *TestIgnoredEmbedded {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestIgnoredEmbeddedBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Value).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Value: %#v", b.model.Value))
+	}
+	return "TestIgnoredEmbeddedBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestIgnoredEmbeddedBuilder) GoString() string {
+	if b == nil {
+		return "(*TestIgnoredEmbeddedBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestIgnoredEmbeddedBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestIgnoredEmbeddedBuilder) Clone() *TestIgnoredEmbeddedBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestIgnoredEmbeddedBuilder) fromModel(model TestIgnoredEmbedded) {
+	b.model = model
+}
+
+// NewTestIgnoredMembersBuilder creates a builder for TestIgnoredMembers.
+func NewTestIgnoredMembersBuilder() *TestIgnoredMembersBuilder {
+	builder := &TestIgnoredMembersBuilder{}
+	builder.model = TestIgnoredMembers{}
+	builder.nested = NewTestIgnoredEmbeddedBuilder()
+	builder.TestIgnoredEmbeddedBuilder = *NewTestIgnoredEmbeddedBuilder()
+	return builder
+}
+
+// NewTestIgnoredMembersBuilderFromYAML creates a builder for TestIgnoredMembers from the YAML document data.
+func NewTestIgnoredMembersBuilderFromYAML(data []byte) (*TestIgnoredMembersBuilder, error) {
+	builder := NewTestIgnoredMembersBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestIgnoredMembersBuilder struct {
+	model  TestIgnoredMembers
+	nested *TestIgnoredEmbeddedBuilder
+	TestIgnoredEmbeddedBuilder
+}
+
+func (b *TestIgnoredMembersBuilder) Key(input string) *TestIgnoredMembersBuilder {
+	b.model.Key = input
+	return b
+}
+
+func (b *TestIgnoredMembersBuilder) Nested() *TestIgnoredEmbeddedBuilder {
+	return b.nested
+}
+
+func (b *TestIgnoredMembersBuilder) TestIgnoredEmbedded() *TestIgnoredEmbeddedBuilder {
+	return &b.TestIgnoredEmbeddedBuilder
+}
+
+func (b *TestIgnoredMembersBuilder) Value(input string) *TestIgnoredMembersBuilder {
+	b.TestIgnoredEmbeddedBuilder.Value(input)
+	return b
+}
+
+func (b *TestIgnoredMembersBuilder) Build() TestIgnoredMembers {
+	b.model.Nested = b.nested.Build()
+	b.model.TestIgnoredEmbedded = b.TestIgnoredEmbeddedBuilder.Build()
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestIgnoredMembersBuilder) BuildPtr() *TestIgnoredMembers {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestIgnoredMembersBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Key).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Key: %#v", b.model.Key))
+	}
+	if b.nested != nil {
+		fields = append(fields, "Nested: "+b.nested.String())
+	}
+	fields = append(fields, "TestIgnoredEmbedded: "+b.TestIgnoredEmbeddedBuilder.String())
+	return "TestIgnoredMembersBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestIgnoredMembersBuilder) GoString() string {
+	if b == nil {
+		return "(*TestIgnoredMembersBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestIgnoredMembersBuilder{model: %#v, nested: %#v, TestIgnoredEmbeddedBuilder: %#v}", b.model, b.nested, &b.TestIgnoredEmbeddedBuilder)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestIgnoredMembersBuilder) Clone() *TestIgnoredMembersBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.nested = b.nested.Clone()
+	clone.TestIgnoredEmbeddedBuilder = *b.TestIgnoredEmbeddedBuilder.Clone()
+	return &clone
+}
+
+func (b *TestIgnoredMembersBuilder) fromModel(model TestIgnoredMembers) {
+	b.model = model
+	b.nested.fromModel(model.Nested)
+	b.TestIgnoredEmbeddedBuilder.fromModel(model.TestIgnoredEmbedded)
+}
+
+// NewTestInitialismsBuilder creates a builder for TestInitialisms.
+//
+// TestInitialisms has members named with initialisms, upper-cased in the
+// names of the setters.
+func NewTestInitialismsBuilder() *TestInitialismsBuilder {
+	builder := &TestInitialismsBuilder{}
+	builder.model = TestInitialisms{}
+	return builder
+}
+
+// NewTestInitialismsBuilderFromYAML creates a builder for TestInitialisms from the YAML document data.
+func NewTestInitialismsBuilderFromYAML(data []byte) (*TestInitialismsBuilder, error) {
+	builder := NewTestInitialismsBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestInitialismsBuilder struct {
+	model TestInitialisms
+}
+
+func (b *TestInitialismsBuilder) ID(input string) *TestInitialismsBuilder {
+	b.model.Id = input
+	return b
+}
+
+func (b *TestInitialismsBuilder) UserID(input string) *TestInitialismsBuilder {
+	b.model.UserId = input
+	return b
+}
+
+func (b *TestInitialismsBuilder) HTTPURL(input string) *TestInitialismsBuilder {
+	b.model.HttpUrl = input
+	return b
+}
+
+func (b *TestInitialismsBuilder) Ids(input []string) *TestInitialismsBuilder {
+	if input != nil {
+		input = append(make([]string, 0, len(input)), input...)
+	}
+	b.model.Ids = input
+	return b
+}
+
+func (b *TestInitialismsBuilder) AddIds(items ...string) *TestInitialismsBuilder {
+	b.model.Ids = append(b.model.Ids, items...)
+	return b
+}
+
+func (b *TestInitialismsBuilder) AppendIds(item string) *TestInitialismsBuilder {
+	b.model.Ids = append(b.model.Ids, item)
+	return b
+}
+
+func (b *TestInitialismsBuilder) Build() TestInitialisms {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestInitialismsBuilder) BuildPtr() *TestInitialisms {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestInitialismsBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Id).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Id: %#v", b.model.Id))
+	}
+	if !reflect.ValueOf(&b.model.UserId).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("UserId: %#v", b.model.UserId))
+	}
+	if !reflect.ValueOf(&b.model.HttpUrl).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("HttpUrl: %#v", b.model.HttpUrl))
+	}
+	if !reflect.ValueOf(&b.model.Ids).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Ids: %+v", b.model.Ids))
+	}
+	return "TestInitialismsBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestInitialismsBuilder) GoString() string {
+	if b == nil {
+		return "(*TestInitialismsBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestInitialismsBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestInitialismsBuilder) Clone() *TestInitialismsBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.model.Ids != nil {
+		clone.model.Ids = make([]string, len(b.model.Ids))
+		copy(clone.model.Ids, b.model.Ids)
+	}
+	return &clone
+}
+
+func (b *TestInitialismsBuilder) fromModel(model TestInitialisms) {
+	b.model = model
+}
+
+// NewTestJSONNamesBuilder creates a builder for TestJSONNames.
+func NewTestJSONNamesBuilder() *TestJSONNamesBuilder {
+	builder := &TestJSONNamesBuilder{}
+	builder.model = TestJSONNames{}
+	builder.items = []*TestBBuilder{}
+	return builder
+}
+
+// NewTestJSONNamesBuilderFromYAML creates a builder for TestJSONNames from the YAML document data.
+func NewTestJSONNamesBuilderFromYAML(data []byte) (*TestJSONNamesBuilder, error) {
+	builder := NewTestJSONNamesBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestJSONNamesBuilder struct {
+	model TestJSONNames
+	items []*TestBBuilder
+}
+
+func (b *TestJSONNamesBuilder) DisplayName(input string) *TestJSONNamesBuilder {
+	b.model.DisplayName = input
+	return b
+}
+
+func (b *TestJSONNamesBuilder) APIVersion(input string) *TestJSONNamesBuilder {
+	b.model.APIVersion = input
+	return b
+}
+
+func (b *TestJSONNamesBuilder) Labels(input map[string]string) *TestJSONNamesBuilder {
+	b.model.Labels = input
+	return b
+}
+
+func (b *TestJSONNamesBuilder) SetLabelsEntry(key string, value string) *TestJSONNamesBuilder {
+	if b.model.Labels == nil {
+		b.model.Labels = map[string]string{}
+	}
+	b.model.Labels[key] = value
+	return b
+}
+
+// SetItemsFrom replaces the builders of Items by builders of the
+// elements of items.
+func (b *TestJSONNamesBuilder) SetItemsFrom(items []TestB) *TestJSONNamesBuilder {
+	b.items = make([]*TestBBuilder, 0, len(items))
+	for _, item := range items {
+		builder := NewTestBBuilder()
+		builder.fromModel(item)
+		b.items = append(b.items, builder)
+	}
+	return b
+}
+
+func (b *TestJSONNamesBuilder) AddItems() *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.items = append(b.items, builder)
+	return builder
+}
+
+// AddItemsWith calls build with the builder AddItems appends.
+func (b *TestJSONNamesBuilder) AddItemsWith(build func(*TestBBuilder)) *TestJSONNamesBuilder {
+	build(b.AddItems())
+	return b
+}
+
+func (b *TestJSONNamesBuilder) RemoveItems(remove *TestBBuilder) {
+	for i, val := range b.items {
+		if val == remove {
+			b.items[i] = b.items[len(b.items)-1]
+			b.items = b.items[:len(b.items)-1]
+		}
+	}
+}
+func (b *TestJSONNamesBuilder) Hidden(input string) *TestJSONNamesBuilder {
+	b.model.Hidden = input
+	return b
+}
+
+func (b *TestJSONNamesBuilder) Plain(input string) *TestJSONNamesBuilder {
+	b.model.Plain = input
+	return b
+}
+
+func (b *TestJSONNamesBuilder) Built(input string) *TestJSONNamesBuilder {
+	b.model.Built = input
+	return b
+}
+
+func (b *TestJSONNamesBuilder) Build() TestJSONNames {
+	b.model.Items = []TestB{}
+	for _, v := range b.items {
+		b.model.Items = append(b.model.Items, v.Build())
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestJSONNamesBuilder) BuildPtr() *TestJSONNames {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestJSONNamesBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.DisplayName).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("DisplayName: %#v", b.model.DisplayName))
+	}
+	if !reflect.ValueOf(&b.model.APIVersion).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("APIVersion: %#v", b.model.APIVersion))
+	}
+	if !reflect.ValueOf(&b.model.Labels).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Labels: %+v", b.model.Labels))
+	}
+	if len(b.items) > 0 {
+		fields = append(fields, fmt.Sprintf("Items: %d builders", len(b.items)))
+	}
+	if !reflect.ValueOf(&b.model.Hidden).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Hidden: %#v", b.model.Hidden))
+	}
+	if !reflect.ValueOf(&b.model.Plain).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Plain: %#v", b.model.Plain))
+	}
+	if !reflect.ValueOf(&b.model.Built).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Built: %#v", b.model.Built))
+	}
+	return "TestJSONNamesBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestJSONNamesBuilder) GoString() string {
+	if b == nil {
+		return "(*TestJSONNamesBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestJSONNamesBuilder{model: %#v, items: %#v}", b.model, b.items)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestJSONNamesBuilder) Clone() *TestJSONNamesBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.model.Labels != nil {
+		clone.model.Labels = make(map[string]string, len(b.model.Labels))
+		for k, v := range b.model.Labels {
+			clone.model.Labels[k] = v
+		}
+	}
+	if b.items != nil {
+		clone.items = make([]*TestBBuilder, len(b.items))
+		for k, v := range b.items {
+			clone.items[k] = v.Clone()
+		}
+	}
+	return &clone
+}
+
+func (b *TestJSONNamesBuilder) fromModel(model TestJSONNames) {
+	b.model = model
+	b.items = []*TestBBuilder{}
+	for _, v := range model.Items {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.items = append(b.items, builder)
+	}
+}
+
+// NewTestKeywordsBuilder creates a builder for TestKeywords.
+//
+// TestKeywords has members lowering to Go keywords, the builder fields and
+// local variables holding them escaped.
+func NewTestKeywordsBuilder() *TestKeywordsBuilder {
+	builder := &TestKeywordsBuilder{}
+	builder.model = TestKeywords{}
+	builder.range_ = []*TestBBuilder{}
+	builder.select_ = map[string]*TestBBuilder{}
+	builder.default_ = NewTestBBuilder()
+	return builder
+}
+
+// NewTestKeywordsBuilderFromYAML creates a builder for TestKeywords from the YAML document data.
+func NewTestKeywordsBuilderFromYAML(data []byte) (*TestKeywordsBuilder, error) {
+	builder := NewTestKeywordsBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestKeywordsBuilder struct {
+	model    TestKeywords
+	range_   []*TestBBuilder
+	go_      *TestBBuilder
+	select_  map[string]*TestBBuilder
+	default_ *TestBBuilder
+}
+
+func (b *TestKeywordsBuilder) Type(input string) *TestKeywordsBuilder {
+	b.model.Type = input
+	return b
+}
+
+func (b *TestKeywordsBuilder) Func(input string) *TestKeywordsBuilder {
+	b.model.Func = input
+	return b
+}
+
+// SetRangeFrom replaces the builders of Range by builders of the
+// elements of items.
+func (b *TestKeywordsBuilder) SetRangeFrom(items []TestB) *TestKeywordsBuilder {
+	b.range_ = make([]*TestBBuilder, 0, len(items))
+	for _, item := range items {
+		builder := NewTestBBuilder()
+		builder.fromModel(item)
+		b.range_ = append(b.range_, builder)
+	}
+	return b
+}
+
+func (b *TestKeywordsBuilder) AddRange() *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.range_ = append(b.range_, builder)
+	return builder
+}
+
+// AddRangeWith calls build with the builder AddRange appends.
+func (b *TestKeywordsBuilder) AddRangeWith(build func(*TestBBuilder)) *TestKeywordsBuilder {
+	build(b.AddRange())
+	return b
+}
+
+func (b *TestKeywordsBuilder) RemoveRange(remove *TestBBuilder) {
+	for i, val := range b.range_ {
+		if val == remove {
+			b.range_[i] = b.range_[len(b.range_)-1]
+			b.range_ = b.range_[:len(b.range_)-1]
+		}
+	}
+}
+func (b *TestKeywordsBuilder) Go() *TestBBuilder {
+	if b.go_ == nil {
+		b.go_ = NewTestBBuilder()
+	}
+	return b.go_
+}
+
+// SetGo sets Go to a copy of the value input points to, nil
+// if input is nil.
+func (b *TestKeywordsBuilder) SetGo(input *TestB) *TestKeywordsBuilder {
+	b.go_ = nil
+	b.model.Go = nil
+	if input != nil {
+		b.go_ = NewTestBBuilder()
+		b.go_.fromModel(*input)
+	}
+	return b
+}
+
+func (b *TestKeywordsBuilder) Select(input map[string]TestB) *TestKeywordsBuilder {
+	b.select_ = map[string]*TestBBuilder{}
+	for k, v := range input {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.select_[k] = builder
+	}
+	return b
+}
+
+func (b *TestKeywordsBuilder) AddSelect(key string) *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.select_[key] = builder
+	return builder
+}
+
+// AddSelectWith calls build with the builder AddSelect sets for key.
+func (b *TestKeywordsBuilder) AddSelectWith(key string, build func(*TestBBuilder)) *TestKeywordsBuilder {
+	build(b.AddSelect(key))
+	return b
+}
+
+// RemoveSelect drops the builder of key from Select.
+func (b *TestKeywordsBuilder) RemoveSelect(key string) {
+	delete(b.select_, key)
+}
+
+func (b *TestKeywordsBuilder) Default() *TestBBuilder {
+	return b.default_
+}
+
+func (b *TestKeywordsBuilder) Map(input map[string]string) *TestKeywordsBuilder {
+	b.model.Map = input
+	return b
+}
+
+func (b *TestKeywordsBuilder) SetMapEntry(key string, value string) *TestKeywordsBuilder {
+	if b.model.Map == nil {
+		b.model.Map = map[string]string{}
+	}
+	b.model.Map[key] = value
+	return b
+}
+
+func (b *TestKeywordsBuilder) Chan(input int) *TestKeywordsBuilder {
+	b.model.Chan = input
+	return b
+}
+
+func (b *TestKeywordsBuilder) Build() TestKeywords {
+	b.model.Range = []TestB{}
+	for _, v := range b.range_ {
+		b.model.Range = append(b.model.Range, v.Build())
+	}
+	if b.go_ != nil {
+		go_ := b.go_.Build()
+		b.model.Go = &go_
+	}
+	b.model.Select = map[string]TestB{}
+	for k, v := range b.select_ {
+		b.model.Select[k] = v.Build()
+	}
+	b.model.Default = b.default_.Build()
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestKeywordsBuilder) BuildPtr() *TestKeywords {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestKeywordsBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Type).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Type: %#v", b.model.Type))
+	}
+	if !reflect.ValueOf(&b.model.Func).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Func: %#v", b.model.Func))
+	}
+	if len(b.range_) > 0 {
+		fields = append(fields, fmt.Sprintf("Range: %d builders", len(b.range_)))
+	}
+	if b.go_ != nil {
+		fields = append(fields, "Go: "+b.go_.String())
+	}
+	if len(b.select_) > 0 {
+		fields = append(fields, fmt.Sprintf("Select: %d builders", len(b.select_)))
+	}
+	if b.default_ != nil {
+		fields = append(fields, "Default: "+b.default_.String())
+	}
+	if !reflect.ValueOf(&b.model.Map).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Map: %+v", b.model.Map))
+	}
+	if !reflect.ValueOf(&b.model.Chan).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Chan: %#v", b.model.Chan))
+	}
+	return "TestKeywordsBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestKeywordsBuilder) GoString() string {
+	if b == nil {
+		return "(*TestKeywordsBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestKeywordsBuilder{model: %#v, range_: %#v, go_: %#v, select_: %#v, default_: %#v}", b.model, b.range_, b.go_, b.select_, b.default_)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestKeywordsBuilder) Clone() *TestKeywordsBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.range_ != nil {
+		clone.range_ = make([]*TestBBuilder, len(b.range_))
+		for k, v := range b.range_ {
+			clone.range_[k] = v.Clone()
+		}
+	}
+	clone.go_ = b.go_.Clone()
+	if b.select_ != nil {
+		clone.select_ = make(map[string]*TestBBuilder, len(b.select_))
+		for k, v := range b.select_ {
+			clone.select_[k] = v.Clone()
+		}
+	}
+	clone.default_ = b.default_.Clone()
+	if b.model.Map != nil {
+		clone.model.Map = make(map[string]string, len(b.model.Map))
+		for k, v := range b.model.Map {
+			clone.model.Map[k] = v
+		}
+	}
+	return &clone
+}
+
+func (b *TestKeywordsBuilder) fromModel(model TestKeywords) {
+	b.model = model
+	b.range_ = []*TestBBuilder{}
+	for _, v := range model.Range {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.range_ = append(b.range_, builder)
+	}
+	b.go_ = nil
+	if model.Go != nil {
+		b.go_ = NewTestBBuilder()
+		b.go_.fromModel(*model.Go)
+	}
+	b.select_ = map[string]*TestBBuilder{}
+	for k, v := range model.Select {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.select_[k] = builder
+	}
+	b.default_.fromModel(model.Default)
+}
+
+// NewTestLabelsBuilder creates a builder for TestLabels.
+//
+// TestLabels is a named slice of primitives.
+func NewTestLabelsBuilder() *TestLabelsBuilder {
+	builder := &TestLabelsBuilder{}
+	builder.model = TestLabels{}
+	return builder
+}
+
+// NewTestLabelsBuilderFromYAML creates a builder for TestLabels from the YAML document data.
+func NewTestLabelsBuilderFromYAML(data []byte) (*TestLabelsBuilder, error) {
+	builder := NewTestLabelsBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestLabelsBuilder struct {
+	model TestLabels
+}
+
+func (b *TestLabelsBuilder) Build() TestLabels {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestLabelsBuilder) BuildPtr() *TestLabels {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestLabelsBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	return "TestLabelsBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestLabelsBuilder) GoString() string {
+	if b == nil {
+		return "(*TestLabelsBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestLabelsBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestLabelsBuilder) Clone() *TestLabelsBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestLabelsBuilder) fromModel(model TestLabels) {
+	b.model = model
+}
+
+// NewTestMachineBuilder creates a builder for TestMachine.
+//
+// TestMachine has interface members set by the builders of their
+// implementations.
+func NewTestMachineBuilder() *TestMachineBuilder {
+	builder := &TestMachineBuilder{}
+	builder.model = TestMachine{}
+	return builder
+}
+
+// NewTestMachineBuilderFromYAML creates a builder for TestMachine from the YAML document data.
+func NewTestMachineBuilderFromYAML(data []byte) (*TestMachineBuilder, error) {
+	builder := NewTestMachineBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestMachineBuilder struct {
+	model         TestMachine
+	stateTestIdle *TestIdleBuilder
+	stateTestEnd  *TestEndBuilder
+	nextTestB     *TestBBuilder
+}
+
+func (b *TestMachineBuilder) State(input TestState) *TestMachineBuilder {
+	b.stateTestIdle = nil
+	b.stateTestEnd = nil
+	b.model.State = input
+	return b
+}
+
+// SetStateTestIdle sets State to a new TestIdle, returning its builder.
+func (b *TestMachineBuilder) SetStateTestIdle() *TestIdleBuilder {
+	b.stateTestEnd = nil
+	b.stateTestIdle = NewTestIdleBuilder()
+	return b.stateTestIdle
+}
+
+// SetStateTestEnd sets State to a new TestEnd, returning its builder.
+func (b *TestMachineBuilder) SetStateTestEnd() *TestEndBuilder {
+	b.stateTestIdle = nil
+	b.stateTestEnd = NewTestEndBuilder()
+	return b.stateTestEnd
+}
+
+func (b *TestMachineBuilder) Next(input interface{}) *TestMachineBuilder {
+	b.nextTestB = nil
+	b.model.Next = input
+	return b
+}
+
+// SetNextTestB sets Next to a new TestB, returning its builder.
+func (b *TestMachineBuilder) SetNextTestB() *TestBBuilder {
+	b.nextTestB = NewTestBBuilder()
+	return b.nextTestB
+}
+
+func (b *TestMachineBuilder) Build() TestMachine {
+	if b.stateTestIdle != nil {
+		b.model.State = b.stateTestIdle.Build()
+	}
+	if b.stateTestEnd != nil {
+		stateTestEnd := b.stateTestEnd.Build()
+		b.model.State = &stateTestEnd
+	}
+	if b.nextTestB != nil {
+		b.model.Next = b.nextTestB.Build()
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestMachineBuilder) BuildPtr() *TestMachine {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestMachineBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if b.stateTestIdle != nil {
+		fields = append(fields, "State: "+b.stateTestIdle.String())
+	} else if b.stateTestEnd != nil {
+		fields = append(fields, "State: "+b.stateTestEnd.String())
+	} else if !reflect.ValueOf(&b.model.State).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("State: %+v", b.model.State))
+	}
+	if b.nextTestB != nil {
+		fields = append(fields, "Next: "+b.nextTestB.String())
+	} else if !reflect.ValueOf(&b.model.Next).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Next: %+v", b.model.Next))
+	}
+	return "TestMachineBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestMachineBuilder) GoString() string {
+	if b == nil {
+		return "(*TestMachineBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestMachineBuilder{model: %#v, stateTestIdle: %#v, stateTestEnd: %#v, nextTestB: %#v}", b.model, b.stateTestIdle, b.stateTestEnd, b.nextTestB)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestMachineBuilder) Clone() *TestMachineBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.stateTestIdle = b.stateTestIdle.Clone()
+	clone.stateTestEnd = b.stateTestEnd.Clone()
+	clone.nextTestB = b.nextTestB.Clone()
+	return &clone
+}
+
+func (b *TestMachineBuilder) fromModel(model TestMachine) {
+	b.model = model
+	b.stateTestIdle = nil
+	b.stateTestEnd = nil
+	switch v := model.State.(type) {
+	case TestIdle:
+		b.stateTestIdle = NewTestIdleBuilder()
+		b.stateTestIdle.fromModel(v)
+	case *TestEnd:
+		if v != nil {
+			b.stateTestEnd = NewTestEndBuilder()
+			b.stateTestEnd.fromModel(*v)
+		}
+	}
+	b.nextTestB = nil
+	switch v := model.Next.(type) {
+	case TestB:
+		b.nextTestB = NewTestBBuilder()
+		b.nextTestB.fromModel(v)
+	}
+}
+
+// NewTestMapKeysBuilder creates a builder for TestMapKeys.
+func NewTestMapKeysBuilder() *TestMapKeysBuilder {
+	builder := &TestMapKeysBuilder{}
+	builder.model = TestMapKeys{}
+	builder.byid = map[int32]*TestBBuilder{}
+	builder.bykind = map[TestKind]*TestBBuilder{}
+	builder.byzone = map[other.Zone]*TestBBuilder{}
+	return builder
+}
+
+// NewTestMapKeysBuilderFromYAML creates a builder for TestMapKeys from the YAML document data.
+func NewTestMapKeysBuilderFromYAML(data []byte) (*TestMapKeysBuilder, error) {
+	builder := NewTestMapKeysBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestMapKeysBuilder struct {
+	model  TestMapKeys
+	byid   map[int32]*TestBBuilder
+	bykind map[TestKind]*TestBBuilder
+	byzone map[other.Zone]*TestBBuilder
+}
+
+func (b *TestMapKeysBuilder) ByID(input map[int32]TestB) *TestMapKeysBuilder {
+	b.byid = map[int32]*TestBBuilder{}
+	for k, v := range input {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.byid[k] = builder
+	}
+	return b
+}
+
+func (b *TestMapKeysBuilder) AddByID(key int32) *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.byid[key] = builder
+	return builder
+}
+
+// AddByIDWith calls build with the builder AddByID sets for key.
+func (b *TestMapKeysBuilder) AddByIDWith(key int32, build func(*TestBBuilder)) *TestMapKeysBuilder {
+	build(b.AddByID(key))
+	return b
+}
+
+// RemoveByID drops the builder of key from ByID.
+func (b *TestMapKeysBuilder) RemoveByID(key int32) {
+	delete(b.byid, key)
+}
+
+func (b *TestMapKeysBuilder) ByKind(input map[TestKind]*TestB) *TestMapKeysBuilder {
+	b.bykind = map[TestKind]*TestBBuilder{}
+	for k, v := range input {
+		if v == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*v)
+		b.bykind[k] = builder
+	}
+	return b
+}
+
+func (b *TestMapKeysBuilder) AddByKind(key TestKind) *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.bykind[key] = builder
+	return builder
+}
+
+// AddByKindWith calls build with the builder AddByKind sets for key.
+func (b *TestMapKeysBuilder) AddByKindWith(key TestKind, build func(*TestBBuilder)) *TestMapKeysBuilder {
+	build(b.AddByKind(key))
+	return b
+}
+
+// RemoveByKind drops the builder of key from ByKind.
+func (b *TestMapKeysBuilder) RemoveByKind(key TestKind) {
+	delete(b.bykind, key)
+}
+
+func (b *TestMapKeysBuilder) ByZone(input map[other.Zone]TestB) *TestMapKeysBuilder {
+	b.byzone = map[other.Zone]*TestBBuilder{}
+	for k, v := range input {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.byzone[k] = builder
+	}
+	return b
+}
+
+func (b *TestMapKeysBuilder) AddByZone(key other.Zone) *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.byzone[key] = builder
+	return builder
+}
+
+// AddByZoneWith calls build with the builder AddByZone sets for key.
+func (b *TestMapKeysBuilder) AddByZoneWith(key other.Zone, build func(*TestBBuilder)) *TestMapKeysBuilder {
+	build(b.AddByZone(key))
+	return b
+}
+
+// RemoveByZone drops the builder of key from ByZone.
+func (b *TestMapKeysBuilder) RemoveByZone(key other.Zone) {
+	delete(b.byzone, key)
+}
+
+func (b *TestMapKeysBuilder) Counts(input map[TestKind]int) *TestMapKeysBuilder {
+	b.model.Counts = input
+	return b
+}
+
+func (b *TestMapKeysBuilder) SetCountsEntry(key TestKind, value int) *TestMapKeysBuilder {
+	if b.model.Counts == nil {
+		b.model.Counts = map[TestKind]int{}
+	}
+	b.model.Counts[key] = value
+	return b
+}
+
+func (b *TestMapKeysBuilder) Enabled(input map[uint16]bool) *TestMapKeysBuilder {
+	b.model.Enabled = input
+	return b
+}
+
+func (b *TestMapKeysBuilder) SetEnabledEntry(key uint16, value bool) *TestMapKeysBuilder {
+	if b.model.Enabled == nil {
+		b.model.Enabled = map[uint16]bool{}
+	}
+	b.model.Enabled[key] = value
+	return b
+}
+
+func (b *TestMapKeysBuilder) Build() TestMapKeys {
+	b.model.ByID = map[int32]TestB{}
+	for k, v := range b.byid {
+		b.model.ByID[k] = v.Build()
+	}
+	b.model.ByKind = map[TestKind]*TestB{}
+	for k, v := range b.bykind {
+		vv := v.Build()
+		b.model.ByKind[k] = &vv
+	}
+	b.model.ByZone = map[other.Zone]TestB{}
+	for k, v := range b.byzone {
+		b.model.ByZone[k] = v.Build()
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestMapKeysBuilder) BuildPtr() *TestMapKeys {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestMapKeysBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if len(b.byid) > 0 {
+		fields = append(fields, fmt.Sprintf("ByID: %d builders", len(b.byid)))
+	}
+	if len(b.bykind) > 0 {
+		fields = append(fields, fmt.Sprintf("ByKind: %d builders", len(b.bykind)))
+	}
+	if len(b.byzone) > 0 {
+		fields = append(fields, fmt.Sprintf("ByZone: %d builders", len(b.byzone)))
+	}
+	if !reflect.ValueOf(&b.model.Counts).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Counts: %+v", b.model.Counts))
+	}
+	if !reflect.ValueOf(&b.model.Enabled).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Enabled: %+v", b.model.Enabled))
+	}
+	return "TestMapKeysBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestMapKeysBuilder) GoString() string {
+	if b == nil {
+		return "(*TestMapKeysBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestMapKeysBuilder{model: %#v, byid: %#v, bykind: %#v, byzone: %#v}", b.model, b.byid, b.bykind, b.byzone)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestMapKeysBuilder) Clone() *TestMapKeysBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.byid != nil {
+		clone.byid = make(map[int32]*TestBBuilder, len(b.byid))
+		for k, v := range b.byid {
+			clone.byid[k] = v.Clone()
+		}
+	}
+	if b.bykind != nil {
+		clone.bykind = make(map[TestKind]*TestBBuilder, len(b.bykind))
+		for k, v := range b.bykind {
+			clone.bykind[k] = v.Clone()
+		}
+	}
+	if b.byzone != nil {
+		clone.byzone = make(map[other.Zone]*TestBBuilder, len(b.byzone))
+		for k, v := range b.byzone {
+			clone.byzone[k] = v.Clone()
+		}
+	}
+	if b.model.Counts != nil {
+		clone.model.Counts = make(map[TestKind]int, len(b.model.Counts))
+		for k, v := range b.model.Counts {
+			clone.model.Counts[k] = v
+		}
+	}
+	if b.model.Enabled != nil {
+		clone.model.Enabled = make(map[uint16]bool, len(b.model.Enabled))
+		for k, v := range b.model.Enabled {
+			clone.model.Enabled[k] = v
+		}
+	}
+	return &clone
+}
+
+func (b *TestMapKeysBuilder) fromModel(model TestMapKeys) {
+	b.model = model
+	b.byid = map[int32]*TestBBuilder{}
+	for k, v := range model.ByID {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.byid[k] = builder
+	}
+	b.bykind = map[TestKind]*TestBBuilder{}
+	for k, v := range model.ByKind {
+		if v == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*v)
+		b.bykind[k] = builder
+	}
+	b.byzone = map[other.Zone]*TestBBuilder{}
+	for k, v := range model.ByZone {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.byzone[k] = builder
+	}
+}
+
+// NewTestMapListsBuilder creates a builder for TestMapLists.
+func NewTestMapListsBuilder() *TestMapListsBuilder {
+	builder := &TestMapListsBuilder{}
+	builder.model = TestMapLists{}
+	return builder
+}
+
+// NewTestMapListsBuilderFromYAML creates a builder for TestMapLists from the YAML document data.
+func NewTestMapListsBuilderFromYAML(data []byte) (*TestMapListsBuilder, error) {
+	builder := NewTestMapListsBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestMapListsBuilder struct {
+	model  TestMapLists
+	byname map[string]*TestBPointerListBuilder
+	values map[string]*TestBListBuilder
+	bykind map[TestKind]*TestRowBuilder
+	nested map[string]*TestBListListBuilder
+}
+
+func (b *TestMapListsBuilder) ByName(input map[string][]*TestB) *TestMapListsBuilder {
+	b.byname = make(map[string]*TestBPointerListBuilder, len(input))
+	for k, v := range input {
+		builder := &TestBPointerListBuilder{}
+		builder.fromModel(v)
+		b.byname[k] = builder
+	}
+	return b
+}
+
+// AddByName sets a new list builder, building the element of ByName for
+// key, and returns it.
+func (b *TestMapListsBuilder) AddByName(key string) *TestBPointerListBuilder {
+	if b.byname == nil {
+		b.byname = map[string]*TestBPointerListBuilder{}
+	}
+	builder := &TestBPointerListBuilder{}
+	b.byname[key] = builder
+	return builder
+}
+
+func (b *TestMapListsBuilder) Values(input map[string][]TestB) *TestMapListsBuilder {
+	b.values = make(map[string]*TestBListBuilder, len(input))
+	for k, v := range input {
+		builder := &TestBListBuilder{}
+		builder.fromModel(v)
+		b.values[k] = builder
+	}
+	return b
+}
+
+// AddValues sets a new list builder, building the element of Values for
+// key, and returns it.
+func (b *TestMapListsBuilder) AddValues(key string) *TestBListBuilder {
+	if b.values == nil {
+		b.values = map[string]*TestBListBuilder{}
+	}
+	builder := &TestBListBuilder{}
+	b.values[key] = builder
+	return builder
+}
+
+func (b *TestMapListsBuilder) ByKind(input map[TestKind]TestRow) *TestMapListsBuilder {
+	b.bykind = make(map[TestKind]*TestRowBuilder, len(input))
+	for k, v := range input {
+		builder := &TestRowBuilder{}
+		builder.fromModel(v)
+		b.bykind[k] = builder
+	}
+	return b
+}
+
+// AddByKind sets a new list builder, building the element of ByKind for
+// key, and returns it.
+func (b *TestMapListsBuilder) AddByKind(key TestKind) *TestRowBuilder {
+	if b.bykind == nil {
+		b.bykind = map[TestKind]*TestRowBuilder{}
+	}
+	builder := &TestRowBuilder{}
+	b.bykind[key] = builder
+	return builder
+}
+
+func (b *TestMapListsBuilder) Nested(input map[string][][]TestB) *TestMapListsBuilder {
+	b.nested = make(map[string]*TestBListListBuilder, len(input))
+	for k, v := range input {
+		builder := &TestBListListBuilder{}
+		builder.fromModel(v)
+		b.nested[k] = builder
+	}
+	return b
+}
+
+// AddNested sets a new list builder, building the element of Nested for
+// key, and returns it.
+func (b *TestMapListsBuilder) AddNested(key string) *TestBListListBuilder {
+	if b.nested == nil {
+		b.nested = map[string]*TestBListListBuilder{}
+	}
+	builder := &TestBListListBuilder{}
+	b.nested[key] = builder
+	return builder
+}
+
+func (b *TestMapListsBuilder) Labels(input map[string][]string) *TestMapListsBuilder {
+	b.model.Labels = input
+	return b
+}
+
+func (b *TestMapListsBuilder) Build() TestMapLists {
+	b.model.ByName = make(map[string][]*TestB, len(b.byname))
+	for k, v := range b.byname {
+		b.model.ByName[k] = v.Build()
+	}
+	b.model.Values = make(map[string][]TestB, len(b.values))
+	for k, v := range b.values {
+		b.model.Values[k] = v.Build()
+	}
+	b.model.ByKind = make(map[TestKind]TestRow, len(b.bykind))
+	for k, v := range b.bykind {
+		b.model.ByKind[k] = v.Build()
+	}
+	b.model.Nested = make(map[string][][]TestB, len(b.nested))
+	for k, v := range b.nested {
+		b.model.Nested[k] = v.Build()
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestMapListsBuilder) BuildPtr() *TestMapLists {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestMapListsBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if len(b.byname) > 0 {
+		fields = append(fields, fmt.Sprintf("ByName: %d lists of builders", len(b.byname)))
+	}
+	if len(b.values) > 0 {
+		fields = append(fields, fmt.Sprintf("Values: %d lists of builders", len(b.values)))
+	}
+	if len(b.bykind) > 0 {
+		fields = append(fields, fmt.Sprintf("ByKind: %d lists of builders", len(b.bykind)))
+	}
+	if len(b.nested) > 0 {
+		fields = append(fields, fmt.Sprintf("Nested: %d lists of builders", len(b.nested)))
+	}
+	if !reflect.ValueOf(&b.model.Labels).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Labels: %+v", b.model.Labels))
+	}
+	return "TestMapListsBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestMapListsBuilder) GoString() string {
+	if b == nil {
+		return "(*TestMapListsBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestMapListsBuilder{model: %#v, byname: %#v, values: %#v, bykind: %#v, nested: %#v}", b.model, b.byname, b.values, b.bykind, b.nested)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestMapListsBuilder) Clone() *TestMapListsBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.byname != nil {
+		clone.byname = make(map[string]*TestBPointerListBuilder, len(b.byname))
+		for k, v := range b.byname {
+			clone.byname[k] = v.Clone()
+		}
+	}
+	if b.values != nil {
+		clone.values = make(map[string]*TestBListBuilder, len(b.values))
+		for k, v := range b.values {
+			clone.values[k] = v.Clone()
+		}
+	}
+	if b.bykind != nil {
+		clone.bykind = make(map[TestKind]*TestRowBuilder, len(b.bykind))
+		for k, v := range b.bykind {
+			clone.bykind[k] = v.Clone()
+		}
+	}
+	if b.nested != nil {
+		clone.nested = make(map[string]*TestBListListBuilder, len(b.nested))
+		for k, v := range b.nested {
+			clone.nested[k] = v.Clone()
+		}
+	}
+	if b.model.Labels != nil {
+		clone.model.Labels = make(map[string][]string, len(b.model.Labels))
+		for k, v := range b.model.Labels {
+			clone.model.Labels[k] = v
+		}
+	}
+	return &clone
+}
+
+func (b *TestMapListsBuilder) fromModel(model TestMapLists) {
+	b.model = model
+	b.byname = make(map[string]*TestBPointerListBuilder, len(model.ByName))
+	for k, v := range model.ByName {
+		builder := &TestBPointerListBuilder{}
+		builder.fromModel(v)
+		b.byname[k] = builder
+	}
+	b.values = make(map[string]*TestBListBuilder, len(model.Values))
+	for k, v := range model.Values {
+		builder := &TestBListBuilder{}
+		builder.fromModel(v)
+		b.values[k] = builder
+	}
+	b.bykind = make(map[TestKind]*TestRowBuilder, len(model.ByKind))
+	for k, v := range model.ByKind {
+		builder := &TestRowBuilder{}
+		builder.fromModel(v)
+		b.bykind[k] = builder
+	}
+	b.nested = make(map[string]*TestBListListBuilder, len(model.Nested))
+	for k, v := range model.Nested {
+		builder := &TestBListListBuilder{}
+		builder.fromModel(v)
+		b.nested[k] = builder
+	}
+}
+
+// TestBPointerListBuilder builds the []*TestB lists of the members holding
+// slices of them, with a builder per element.
+type TestBPointerListBuilder struct {
+	items []*TestBBuilder
+}
+
+// Add appends a new builder to the list and returns it.
+func (b *TestBPointerListBuilder) Add() *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.items = append(b.items, builder)
+	return builder
+}
+
+// Build returns the list built by the builders added, in their order.
+func (b *TestBPointerListBuilder) Build() []*TestB {
+	list := make([]*TestB, 0, len(b.items))
+	for _, v := range b.items {
+		vv := v.Build()
+		list = append(list, &vv)
+	}
+	return list
+}
+
+// GoString lists the builders of the list, for %#v.
+func (b *TestBPointerListBuilder) GoString() string {
+	return fmt.Sprintf("&TestBPointerListBuilder{items: %#v}", b.items)
+}
+
+// Clone returns a copy of the list builder and of its builders.
+func (b *TestBPointerListBuilder) Clone() *TestBPointerListBuilder {
+	clone := &TestBPointerListBuilder{items: make([]*TestBBuilder, len(b.items))}
+	for i, v := range b.items {
+		clone.items[i] = v.Clone()
+	}
+	return clone
+}
+
+func (b *TestBPointerListBuilder) fromModel(model []*TestB) {
+	b.items = make([]*TestBBuilder, 0, len(model))
+	for _, v := range model {
+		if v == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*v)
+		b.items = append(b.items, builder)
+	}
+}
+
+// TestBListBuilder builds the []TestB lists of the members holding
+// slices of them, with a builder per element.
+type TestBListBuilder struct {
+	items []*TestBBuilder
+}
+
+// Add appends a new builder to the list and returns it.
+func (b *TestBListBuilder) Add() *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.items = append(b.items, builder)
+	return builder
+}
+
+// Build returns the list built by the builders added, in their order.
+func (b *TestBListBuilder) Build() []TestB {
+	list := make([]TestB, 0, len(b.items))
+	for _, v := range b.items {
+		list = append(list, v.Build())
+	}
+	return list
+}
+
+// GoString lists the builders of the list, for %#v.
+func (b *TestBListBuilder) GoString() string {
+	return fmt.Sprintf("&TestBListBuilder{items: %#v}", b.items)
+}
+
+// Clone returns a copy of the list builder and of its builders.
+func (b *TestBListBuilder) Clone() *TestBListBuilder {
+	clone := &TestBListBuilder{items: make([]*TestBBuilder, len(b.items))}
+	for i, v := range b.items {
+		clone.items[i] = v.Clone()
+	}
+	return clone
+}
+
+func (b *TestBListBuilder) fromModel(model []TestB) {
+	b.items = make([]*TestBBuilder, 0, len(model))
+	for _, v := range model {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.items = append(b.items, builder)
+	}
+}
+
+// NewTestRowBuilder creates a list builder for TestRow.
+func NewTestRowBuilder() *TestRowBuilder {
+	return &TestRowBuilder{}
+}
+
+// TestRowBuilder builds the TestRow lists of the members holding
+// slices of them, with a builder per element.
+type TestRowBuilder struct {
+	items []*TestBBuilder
+}
+
+// Add appends a new builder to the list and returns it.
+func (b *TestRowBuilder) Add() *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.items = append(b.items, builder)
+	return builder
+}
+
+// Build returns the list built by the builders added, in their order.
+func (b *TestRowBuilder) Build() TestRow {
+	list := make(TestRow, 0, len(b.items))
+	for _, v := range b.items {
+		list = append(list, v.Build())
+	}
+	return list
+}
+
+// GoString lists the builders of the list, for %#v.
+func (b *TestRowBuilder) GoString() string {
+	return fmt.Sprintf("&TestRowBuilder{items: %#v}", b.items)
+}
+
+// Clone returns a copy of the list builder and of its builders.
+func (b *TestRowBuilder) Clone() *TestRowBuilder {
+	clone := &TestRowBuilder{items: make([]*TestBBuilder, len(b.items))}
+	for i, v := range b.items {
+		clone.items[i] = v.Clone()
+	}
+	return clone
+}
+
+func (b *TestRowBuilder) fromModel(model TestRow) {
+	b.items = make([]*TestBBuilder, 0, len(model))
+	for _, v := range model {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.items = append(b.items, builder)
+	}
+}
+
+// TestBListListBuilder builds the [][]TestB lists of the members holding
+// slices of them, with a builder per element.
+type TestBListListBuilder struct {
+	items []*TestBListBuilder
+}
+
+// Add appends a new builder to the list and returns it.
+func (b *TestBListListBuilder) Add() *TestBListBuilder {
+	builder := &TestBListBuilder{}
+	b.items = append(b.items, builder)
+	return builder
+}
+
+// Build returns the list built by the builders added, in their order.
+func (b *TestBListListBuilder) Build() [][]TestB {
+	list := make([][]TestB, 0, len(b.items))
+	for _, v := range b.items {
+		list = append(list, v.Build())
+	}
+	return list
+}
+
+// GoString lists the builders of the list, for %#v.
+func (b *TestBListListBuilder) GoString() string {
+	return fmt.Sprintf("&TestBListListBuilder{items: %#v}", b.items)
+}
+
+// Clone returns a copy of the list builder and of its builders.
+func (b *TestBListListBuilder) Clone() *TestBListListBuilder {
+	clone := &TestBListListBuilder{items: make([]*TestBListBuilder, len(b.items))}
+	for i, v := range b.items {
+		clone.items[i] = v.Clone()
+	}
+	return clone
+}
+
+func (b *TestBListListBuilder) fromModel(model [][]TestB) {
+	b.items = make([]*TestBListBuilder, 0, len(model))
+	for _, v := range model {
+		builder := &TestBListBuilder{}
+		builder.fromModel(v)
+		b.items = append(b.items, builder)
+	}
+}
+
+// NewTestMapSlicesBuilder creates a builder for TestMapSlices.
+func NewTestMapSlicesBuilder() *TestMapSlicesBuilder {
+	builder := &TestMapSlicesBuilder{}
+	builder.model = TestMapSlices{}
+	return builder
+}
+
+// NewTestMapSlicesBuilderFromYAML creates a builder for TestMapSlices from the YAML document data.
+func NewTestMapSlicesBuilderFromYAML(data []byte) (*TestMapSlicesBuilder, error) {
+	builder := NewTestMapSlicesBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestMapSlicesBuilder struct {
+	model        TestMapSlices
+	items        []map[string]*TestBBuilder
+	itempointers []map[string]*TestBBuilder
+	zones        []map[other.Zone]*TestBBuilder
+}
+
+func (b *TestMapSlicesBuilder) Labels(input []map[string]string) *TestMapSlicesBuilder {
+	if input != nil {
+		input = append(make([]map[string]string, 0, len(input)), input...)
+	}
+	b.model.Labels = input
+	return b
+}
+
+func (b *TestMapSlicesBuilder) AddLabels(items ...map[string]string) *TestMapSlicesBuilder {
+	b.model.Labels = append(b.model.Labels, items...)
+	return b
+}
+
+func (b *TestMapSlicesBuilder) AppendLabels(item map[string]string) *TestMapSlicesBuilder {
+	b.model.Labels = append(b.model.Labels, item)
+	return b
+}
+
+func (b *TestMapSlicesBuilder) Items(input []map[string]TestB) *TestMapSlicesBuilder {
+	b.items = make([]map[string]*TestBBuilder, 0, len(input))
+	for _, entries := range input {
+		builders := make(map[string]*TestBBuilder, len(entries))
+		for k, v := range entries {
+			builder := NewTestBBuilder()
+			builder.fromModel(v)
+			builders[k] = builder
+		}
+		b.items = append(b.items, builders)
+	}
+	return b
+}
+
+// AddItems appends a map holding a new builder per key, and returns the
+// builders in the order of the keys.
+func (b *TestMapSlicesBuilder) AddItems(keys ...string) []*TestBBuilder {
+	builders := make(map[string]*TestBBuilder, len(keys))
+	result := make([]*TestBBuilder, 0, len(keys))
+	for _, key := range keys {
+		builder := NewTestBBuilder()
+		builders[key] = builder
+		result = append(result, builder)
+	}
+	b.items = append(b.items, builders)
+	return result
+}
+
+func (b *TestMapSlicesBuilder) ItemPointers(input []map[string]*TestB) *TestMapSlicesBuilder {
+	b.itempointers = make([]map[string]*TestBBuilder, 0, len(input))
+	for _, entries := range input {
+		builders := make(map[string]*TestBBuilder, len(entries))
+		for k, v := range entries {
+			if v == nil {
+				continue
+			}
+			builder := NewTestBBuilder()
+			builder.fromModel(*v)
+			builders[k] = builder
+		}
+		b.itempointers = append(b.itempointers, builders)
+	}
+	return b
+}
+
+// AddItemPointers appends a map holding a new builder per key, and returns the
+// builders in the order of the keys.
+func (b *TestMapSlicesBuilder) AddItemPointers(keys ...string) []*TestBBuilder {
+	builders := make(map[string]*TestBBuilder, len(keys))
+	result := make([]*TestBBuilder, 0, len(keys))
+	for _, key := range keys {
+		builder := NewTestBBuilder()
+		builders[key] = builder
+		result = append(result, builder)
+	}
+	b.itempointers = append(b.itempointers, builders)
+	return result
+}
+
+func (b *TestMapSlicesBuilder) Zones(input []TestZoneMap) *TestMapSlicesBuilder {
+	b.zones = make([]map[other.Zone]*TestBBuilder, 0, len(input))
+	for _, entries := range input {
+		builders := make(map[other.Zone]*TestBBuilder, len(entries))
+		for k, v := range entries {
+			if v == nil {
+				continue
+			}
+			builder := NewTestBBuilder()
+			builder.fromModel(*v)
+			builders[k] = builder
+		}
+		b.zones = append(b.zones, builders)
+	}
+	return b
+}
+
+// AddZones appends a map holding a new builder per key, and returns the
+// builders in the order of the keys.
+func (b *TestMapSlicesBuilder) AddZones(keys ...other.Zone) []*TestBBuilder {
+	builders := make(map[other.Zone]*TestBBuilder, len(keys))
+	result := make([]*TestBBuilder, 0, len(keys))
+	for _, key := range keys {
+		builder := NewTestBBuilder()
+		builders[key] = builder
+		result = append(result, builder)
+	}
+	b.zones = append(b.zones, builders)
+	return result
+}
+
+func (b *TestMapSlicesBuilder) ForeignMetadata(input []map[string]v1.ObjectMeta) *TestMapSlicesBuilder {
+	b.model.ForeignMetadata = input
+	return b
+}
+
+func (b *TestMapSlicesBuilder) Build() TestMapSlices {
+	b.model.Items = make([]map[string]TestB, 0, len(b.items))
+	for _, builders := range b.items {
+		entries := make(map[string]TestB, len(builders))
+		for k, v := range builders {
+			entries[k] = v.Build()
+		}
+		b.model.Items = append(b.model.Items, entries)
+	}
+	b.model.ItemPointers = make([]map[string]*TestB, 0, len(b.itempointers))
+	for _, builders := range b.itempointers {
+		entries := make(map[string]*TestB, len(builders))
+		for k, v := range builders {
+			vv := v.Build()
+			entries[k] = &vv
+		}
+		b.model.ItemPointers = append(b.model.ItemPointers, entries)
+	}
+	b.model.Zones = make([]TestZoneMap, 0, len(b.zones))
+	for _, builders := range b.zones {
+		entries := make(TestZoneMap, len(builders))
+		for k, v := range builders {
+			vv := v.Build()
+			entries[k] = &vv
+		}
+		b.model.Zones = append(b.model.Zones, entries)
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestMapSlicesBuilder) BuildPtr() *TestMapSlices {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestMapSlicesBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Labels).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Labels: %+v", b.model.Labels))
+	}
+	if len(b.items) > 0 {
+		fields = append(fields, fmt.Sprintf("Items: %d maps of builders", len(b.items)))
+	}
+	if len(b.itempointers) > 0 {
+		fields = append(fields, fmt.Sprintf("ItemPointers: %d maps of builders", len(b.itempointers)))
+	}
+	if len(b.zones) > 0 {
+		fields = append(fields, fmt.Sprintf("Zones: %d maps of builders", len(b.zones)))
+	}
+	if !reflect.ValueOf(&b.model.ForeignMetadata).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("ForeignMetadata: %+v", b.model.ForeignMetadata))
+	}
+	return "TestMapSlicesBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestMapSlicesBuilder) GoString() string {
+	if b == nil {
+		return "(*TestMapSlicesBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestMapSlicesBuilder{model: %#v, items: %#v, itempointers: %#v, zones: %#v}", b.model, b.items, b.itempointers, b.zones)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestMapSlicesBuilder) Clone() *TestMapSlicesBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.model.Labels != nil {
+		clone.model.Labels = make([]map[string]string, len(b.model.Labels))
+		copy(clone.model.Labels, b.model.Labels)
+	}
+	if b.items != nil {
+		clone.items = make([]map[string]*TestBBuilder, len(b.items))
+		for i, builders := range b.items {
+			clone.items[i] = make(map[string]*TestBBuilder, len(builders))
+			for k, v := range builders {
+				clone.items[i][k] = v.Clone()
+			}
+		}
+	}
+	if b.itempointers != nil {
+		clone.itempointers = make([]map[string]*TestBBuilder, len(b.itempointers))
+		for i, builders := range b.itempointers {
+			clone.itempointers[i] = make(map[string]*TestBBuilder, len(builders))
+			for k, v := range builders {
+				clone.itempointers[i][k] = v.Clone()
+			}
+		}
+	}
+	if b.zones != nil {
+		clone.zones = make([]map[other.Zone]*TestBBuilder, len(b.zones))
+		for i, builders := range b.zones {
+			clone.zones[i] = make(map[other.Zone]*TestBBuilder, len(builders))
+			for k, v := range builders {
+				clone.zones[i][k] = v.Clone()
+			}
+		}
+	}
+	if b.model.ForeignMetadata != nil {
+		clone.model.ForeignMetadata = make([]map[string]v1.ObjectMeta, len(b.model.ForeignMetadata))
+		copy(clone.model.ForeignMetadata, b.model.ForeignMetadata)
+	}
+	return &clone
+}
+
+func (b *TestMapSlicesBuilder) fromModel(model TestMapSlices) {
+	b.model = model
+	b.items = make([]map[string]*TestBBuilder, 0, len(model.Items))
+	for _, entries := range model.Items {
+		builders := make(map[string]*TestBBuilder, len(entries))
+		for k, v := range entries {
+			builder := NewTestBBuilder()
+			builder.fromModel(v)
+			builders[k] = builder
+		}
+		b.items = append(b.items, builders)
+	}
+	b.itempointers = make([]map[string]*TestBBuilder, 0, len(model.ItemPointers))
+	for _, entries := range model.ItemPointers {
+		builders := make(map[string]*TestBBuilder, len(entries))
+		for k, v := range entries {
+			if v == nil {
+				continue
+			}
+			builder := NewTestBBuilder()
+			builder.fromModel(*v)
+			builders[k] = builder
+		}
+		b.itempointers = append(b.itempointers, builders)
+	}
+	b.zones = make([]map[other.Zone]*TestBBuilder, 0, len(model.Zones))
+	for _, entries := range model.Zones {
+		builders := make(map[other.Zone]*TestBBuilder, len(entries))
+		for k, v := range entries {
+			if v == nil {
+				continue
+			}
+			builder := NewTestBBuilder()
+			builder.fromModel(*v)
+			builders[k] = builder
+		}
+		b.zones = append(b.zones, builders)
+	}
+}
+
+// NewTestMessageBuilder creates a builder for TestMessage.
+//
+// TestMessage mimics a message generated by protoc-gen-go, its builder
+// leaving the internal members alone and setting each field of the Value
+// oneof.
+func NewTestMessageBuilder() *TestMessageBuilder {
+	builder := &TestMessageBuilder{}
+	builder.model = TestMessage{}
+	return builder
+}
+
+// NewTestMessageBuilderFromYAML creates a builder for TestMessage from the YAML document data.
+func NewTestMessageBuilderFromYAML(data []byte) (*TestMessageBuilder, error) {
+	builder := NewTestMessageBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestMessageBuilder struct {
+	model TestMessage
+}
+
+func (b *TestMessageBuilder) Name(input string) *TestMessageBuilder {
+	b.model.Name = input
+	return b
+}
+
+// Types that are assignable to Value:
+//
+//	*TestMessage_Number
+//	*TestMessage_Text
+//	*TestMessage_Child
+func (b *TestMessageBuilder) Value(input isTestMessage_Value) *TestMessageBuilder {
+	b.model.Value = input
+	return b
+}
+
+// Child sets Value to the Child field of the oneof.
+func (b *TestMessageBuilder) Child(input *TestB) *TestMessageBuilder {
+	b.model.Value = &TestMessage_Child{Child: input}
+	return b
+}
+
+// Number sets Value to the Number field of the oneof.
+func (b *TestMessageBuilder) Number(input float64) *TestMessageBuilder {
+	b.model.Value = &TestMessage_Number{Number: input}
+	return b
+}
+
+// Text sets Value to the Text field of the oneof.
+func (b *TestMessageBuilder) Text(input string) *TestMessageBuilder {
+	b.model.Value = &TestMessage_Text{Text: input}
+	return b
+}
+
+func (b *TestMessageBuilder) Build() TestMessage {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestMessageBuilder) BuildPtr() *TestMessage {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestMessageBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	if !reflect.ValueOf(&b.model.Value).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Value: %+v", b.model.Value))
+	}
+	return "TestMessageBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestMessageBuilder) GoString() string {
+	if b == nil {
+		return "(*TestMessageBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestMessageBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestMessageBuilder) Clone() *TestMessageBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestMessageBuilder) fromModel(model TestMessage) {
+	b.model = model
+}
+
+// NewTestMetaListBuilder creates a builder for TestMetaList.
+func NewTestMetaListBuilder() *TestMetaListBuilder {
+	builder := &TestMetaListBuilder{}
+	builder.model = TestMetaList{}
+	return builder
+}
+
+// NewTestMetaListBuilderFromYAML creates a builder for TestMetaList from the YAML document data.
+func NewTestMetaListBuilderFromYAML(data []byte) (*TestMetaListBuilder, error) {
+	builder := NewTestMetaListBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestMetaListBuilder struct {
+	model TestMetaList
+}
+
+func (b *TestMetaListBuilder) Build() TestMetaList {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestMetaListBuilder) BuildPtr() *TestMetaList {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestMetaListBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	return "TestMetaListBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestMetaListBuilder) GoString() string {
+	if b == nil {
+		return "(*TestMetaListBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestMetaListBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestMetaListBuilder) Clone() *TestMetaListBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestMetaListBuilder) fromModel(model TestMetaList) {
+	b.model = model
+}
+
+// NewTestMetadataBuilder creates a builder for TestMetadata.
+//
+// TestMetadata holds its metadata by pointer, the setters of its fields but
+// Name, taken by the member, allocating it.
+func NewTestMetadataBuilder() *TestMetadataBuilder {
+	builder := &TestMetadataBuilder{}
+	builder.model = TestMetadata{}
+	return builder
+}
+
+// NewTestMetadataBuilderFromYAML creates a builder for TestMetadata from the YAML document data.
+func NewTestMetadataBuilderFromYAML(data []byte) (*TestMetadataBuilder, error) {
+	builder := NewTestMetadataBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestMetadataBuilder struct {
+	model TestMetadata
+}
+
+func (b *TestMetadataBuilder) Metadata(input *v1.ObjectMeta) *TestMetadataBuilder {
+	b.model.Metadata = input
+	return b
+}
+
+func (b *TestMetadataBuilder) Name(input string) *TestMetadataBuilder {
+	b.model.Name = input
+	return b
+}
+
+// Namespace sets the Namespace of Metadata.
+func (b *TestMetadataBuilder) Namespace(input string) *TestMetadataBuilder {
+	meta := v1.ObjectMeta{}
+	if b.model.Metadata != nil {
+		meta = *b.model.Metadata
+	}
+	meta.Namespace = input
+	b.model.Metadata = &meta
+	return b
+}
+
+// Labels sets the Labels of Metadata.
+func (b *TestMetadataBuilder) Labels(input map[string]string) *TestMetadataBuilder {
+	meta := v1.ObjectMeta{}
+	if b.model.Metadata != nil {
+		meta = *b.model.Metadata
+	}
+	meta.Labels = input
+	b.model.Metadata = &meta
+	return b
+}
+
+// Annotations sets the Annotations of Metadata.
+func (b *TestMetadataBuilder) Annotations(input map[string]string) *TestMetadataBuilder {
+	meta := v1.ObjectMeta{}
+	if b.model.Metadata != nil {
+		meta = *b.model.Metadata
+	}
+	meta.Annotations = input
+	b.model.Metadata = &meta
+	return b
+}
+
+func (b *TestMetadataBuilder) Build() TestMetadata {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestMetadataBuilder) BuildPtr() *TestMetadata {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestMetadataBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Metadata).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Metadata: %+v", b.model.Metadata))
+	}
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	return "TestMetadataBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestMetadataBuilder) GoString() string {
+	if b == nil {
+		return "(*TestMetadataBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestMetadataBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestMetadataBuilder) Clone() *TestMetadataBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestMetadataBuilder) fromModel(model TestMetadata) {
+	b.model = model
+}
+
+// NewTestMixinBuilder creates a builder for TestMixin.
+//
+// TestMixin gets the setters of TestB through its Spec member.
+func NewTestMixinBuilder() *TestMixinBuilder {
+	builder := &TestMixinBuilder{}
+	builder.model = TestMixin{}
+	builder.TestBBuilder = *NewTestBBuilder()
+	return builder
+}
+
+// NewTestMixinBuilderFromYAML creates a builder for TestMixin from the YAML document data.
+func NewTestMixinBuilderFromYAML(data []byte) (*TestMixinBuilder, error) {
+	builder := NewTestMixinBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestMixinBuilder struct {
+	model TestMixin
+	TestBBuilder
+}
+
+func (b *TestMixinBuilder) Spec() *TestBBuilder {
+	return &b.TestBBuilder
+}
+
+func (b *TestMixinBuilder) TestBKey(input string) *TestMixinBuilder {
+	b.TestBBuilder.TestBKey(input)
+	return b
+}
+
+func (b *TestMixinBuilder) Replicas(input int) *TestMixinBuilder {
+	b.model.Replicas = input
+	return b
+}
+
+func (b *TestMixinBuilder) Build() TestMixin {
+	b.model.Spec = b.TestBBuilder.Build()
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestMixinBuilder) BuildPtr() *TestMixin {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestMixinBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	fields = append(fields, "Spec: "+b.TestBBuilder.String())
+	if !reflect.ValueOf(&b.model.Replicas).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Replicas: %#v", b.model.Replicas))
+	}
+	return "TestMixinBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestMixinBuilder) GoString() string {
+	if b == nil {
+		return "(*TestMixinBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestMixinBuilder{model: %#v, TestBBuilder: %#v}", b.model, &b.TestBBuilder)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestMixinBuilder) Clone() *TestMixinBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.TestBBuilder = *b.TestBBuilder.Clone()
+	return &clone
+}
+
+func (b *TestMixinBuilder) fromModel(model TestMixin) {
+	b.model = model
+	b.TestBBuilder.fromModel(model.Spec)
+}
+
+// NewTestMixinForeignBuilder creates a builder for TestMixinForeign.
+func NewTestMixinForeignBuilder() *TestMixinForeignBuilder {
+	builder := &TestMixinForeignBuilder{}
+	builder.model = TestMixinForeign{}
+	return builder
+}
+
+// NewTestMixinForeignBuilderFromYAML creates a builder for TestMixinForeign from the YAML document data.
+func NewTestMixinForeignBuilderFromYAML(data []byte) (*TestMixinForeignBuilder, error) {
+	builder := NewTestMixinForeignBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestMixinForeignBuilder struct {
+	model TestMixinForeign
+}
+
+func (b *TestMixinForeignBuilder) Name(input string) *TestMixinForeignBuilder {
+	b.model.Name = input
+	return b
+}
+
+func (b *TestMixinForeignBuilder) Address(input other.Address) *TestMixinForeignBuilder {
+	b.model.Address = input
+	return b
+}
+
+func (b *TestMixinForeignBuilder) Build() TestMixinForeign {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestMixinForeignBuilder) BuildPtr() *TestMixinForeign {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestMixinForeignBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	if !reflect.ValueOf(&b.model.Address).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Address: %+v", b.model.Address))
+	}
+	return "TestMixinForeignBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestMixinForeignBuilder) GoString() string {
+	if b == nil {
+		return "(*TestMixinForeignBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestMixinForeignBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestMixinForeignBuilder) Clone() *TestMixinForeignBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestMixinForeignBuilder) fromModel(model TestMixinForeign) {
+	b.model = model
+}
+
+// NewTestMutualABuilder creates a builder for TestMutualA.
+func NewTestMutualABuilder() *TestMutualABuilder {
+	builder := &TestMutualABuilder{}
+	builder.model = TestMutualA{}
+	builder.list = []*TestMutualBBuilder{}
+	return builder
+}
+
+// NewTestMutualABuilderFromYAML creates a builder for TestMutualA from the YAML document data.
+func NewTestMutualABuilderFromYAML(data []byte) (*TestMutualABuilder, error) {
+	builder := NewTestMutualABuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestMutualABuilder struct {
+	model TestMutualA
+	list  []*TestMutualBBuilder
+}
+
+func (b *TestMutualABuilder) Key(input string) *TestMutualABuilder {
+	b.model.Key = input
+	return b
+}
+
+// SetListFrom replaces the builders of List by builders of the
+// elements of items.
+func (b *TestMutualABuilder) SetListFrom(items []TestMutualB) *TestMutualABuilder {
+	b.list = make([]*TestMutualBBuilder, 0, len(items))
+	for _, item := range items {
+		builder := NewTestMutualBBuilder()
+		builder.fromModel(item)
+		b.list = append(b.list, builder)
+	}
+	return b
+}
+
+func (b *TestMutualABuilder) AddList() *TestMutualBBuilder {
+	builder := NewTestMutualBBuilder()
+	b.list = append(b.list, builder)
+	return builder
+}
+
+// AddListWith calls build with the builder AddList appends.
+func (b *TestMutualABuilder) AddListWith(build func(*TestMutualBBuilder)) *TestMutualABuilder {
+	build(b.AddList())
+	return b
+}
+
+func (b *TestMutualABuilder) RemoveList(remove *TestMutualBBuilder) {
+	for i, val := range b.list {
+		if val == remove {
+			b.list[i] = b.list[len(b.list)-1]
+			b.list = b.list[:len(b.list)-1]
+		}
+	}
+}
+func (b *TestMutualABuilder) Build() TestMutualA {
+	b.model.List = []TestMutualB{}
+	for _, v := range b.list {
+		b.model.List = append(b.model.List, v.Build())
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestMutualABuilder) BuildPtr() *TestMutualA {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestMutualABuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Key).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Key: %#v", b.model.Key))
+	}
+	if len(b.list) > 0 {
+		fields = append(fields, fmt.Sprintf("List: %d builders", len(b.list)))
+	}
+	return "TestMutualABuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestMutualABuilder) GoString() string {
+	if b == nil {
+		return "(*TestMutualABuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestMutualABuilder{model: %#v, list: %#v}", b.model, b.list)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestMutualABuilder) Clone() *TestMutualABuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.list != nil {
+		clone.list = make([]*TestMutualBBuilder, len(b.list))
+		for k, v := range b.list {
+			clone.list[k] = v.Clone()
+		}
+	}
+	return &clone
+}
+
+func (b *TestMutualABuilder) fromModel(model TestMutualA) {
+	b.model = model
+	b.list = []*TestMutualBBuilder{}
+	for _, v := range model.List {
+		builder := NewTestMutualBBuilder()
+		builder.fromModel(v)
+		b.list = append(b.list, builder)
+	}
+}
+
+// NewTestMutualBBuilder creates a builder for TestMutualB.
+func NewTestMutualBBuilder() *TestMutualBBuilder {
+	builder := &TestMutualBBuilder{}
+	builder.model = TestMutualB{}
+	return builder
+}
+
+// NewTestMutualBBuilderFromYAML creates a builder for TestMutualB from the YAML document data.
+func NewTestMutualBBuilderFromYAML(data []byte) (*TestMutualBBuilder, error) {
+	builder := NewTestMutualBBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestMutualBBuilder struct {
+	model  TestMutualB
+	parent *TestMutualABuilder
+}
+
+func (b *TestMutualBBuilder) Key(input string) *TestMutualBBuilder {
+	b.model.Key = input
+	return b
+}
+
+func (b *TestMutualBBuilder) Parent() *TestMutualABuilder {
+	if b.parent == nil {
+		b.parent = NewTestMutualABuilder()
+	}
+	return b.parent
+}
+
+// SetParent sets Parent to a copy of the value input points to, nil
+// if input is nil.
+func (b *TestMutualBBuilder) SetParent(input *TestMutualA) *TestMutualBBuilder {
+	b.parent = nil
+	b.model.Parent = nil
+	if input != nil {
+		b.parent = NewTestMutualABuilder()
+		b.parent.fromModel(*input)
+	}
+	return b
+}
+
+func (b *TestMutualBBuilder) Build() TestMutualB {
+	if b.parent != nil {
+		parent := b.parent.Build()
+		b.model.Parent = &parent
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestMutualBBuilder) BuildPtr() *TestMutualB {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestMutualBBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Key).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Key: %#v", b.model.Key))
+	}
+	if b.parent != nil {
+		fields = append(fields, "Parent: "+b.parent.String())
+	}
+	return "TestMutualBBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestMutualBBuilder) GoString() string {
+	if b == nil {
+		return "(*TestMutualBBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestMutualBBuilder{model: %#v, parent: %#v}", b.model, b.parent)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestMutualBBuilder) Clone() *TestMutualBBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.parent = b.parent.Clone()
+	return &clone
+}
+
+func (b *TestMutualBBuilder) fromModel(model TestMutualB) {
+	b.model = model
+	b.parent = nil
+	if model.Parent != nil {
+		b.parent = NewTestMutualABuilder()
+		b.parent.fromModel(*model.Parent)
+	}
+}
+
+// NewTestMutualCBuilder creates a builder for TestMutualC.
+func NewTestMutualCBuilder() *TestMutualCBuilder {
+	builder := &TestMutualCBuilder{}
+	builder.model = TestMutualC{}
+	builder.inner = NewTestMutualDBuilder()
+	return builder
+}
+
+// NewTestMutualCBuilderFromYAML creates a builder for TestMutualC from the YAML document data.
+func NewTestMutualCBuilderFromYAML(data []byte) (*TestMutualCBuilder, error) {
+	builder := NewTestMutualCBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestMutualCBuilder struct {
+	model TestMutualC
+	inner *TestMutualDBuilder
+}
+
+func (b *TestMutualCBuilder) Inner() *TestMutualDBuilder {
+	return b.inner
+}
+
+func (b *TestMutualCBuilder) Build() TestMutualC {
+	b.model.Inner = b.inner.Build()
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestMutualCBuilder) BuildPtr() *TestMutualC {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestMutualCBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if b.inner != nil {
+		fields = append(fields, "Inner: "+b.inner.String())
+	}
+	return "TestMutualCBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestMutualCBuilder) GoString() string {
+	if b == nil {
+		return "(*TestMutualCBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestMutualCBuilder{model: %#v, inner: %#v}", b.model, b.inner)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestMutualCBuilder) Clone() *TestMutualCBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.inner = b.inner.Clone()
+	return &clone
+}
+
+func (b *TestMutualCBuilder) fromModel(model TestMutualC) {
+	b.model = model
+	b.inner.fromModel(model.Inner)
+}
+
+// NewTestMutualDBuilder creates a builder for TestMutualD.
+func NewTestMutualDBuilder() *TestMutualDBuilder {
+	builder := &TestMutualDBuilder{}
+	builder.model = TestMutualD{}
+	return builder
+}
+
+// NewTestMutualDBuilderFromYAML creates a builder for TestMutualD from the YAML document data.
+func NewTestMutualDBuilderFromYAML(data []byte) (*TestMutualDBuilder, error) {
+	builder := NewTestMutualDBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestMutualDBuilder struct {
+	model TestMutualD
+	outer *TestMutualCBuilder
+}
+
+func (b *TestMutualDBuilder) Outer() *TestMutualCBuilder {
+	if b.outer == nil {
+		b.outer = NewTestMutualCBuilder()
+	}
+	return b.outer
+}
+
+// SetOuter sets Outer to a copy of the value input points to, nil
+// if input is nil.
+func (b *TestMutualDBuilder) SetOuter(input *TestMutualC) *TestMutualDBuilder {
+	b.outer = nil
+	b.model.Outer = nil
+	if input != nil {
+		b.outer = NewTestMutualCBuilder()
+		b.outer.fromModel(*input)
+	}
+	return b
+}
+
+func (b *TestMutualDBuilder) Build() TestMutualD {
+	if b.outer != nil {
+		outer := b.outer.Build()
+		b.model.Outer = &outer
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestMutualDBuilder) BuildPtr() *TestMutualD {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestMutualDBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if b.outer != nil {
+		fields = append(fields, "Outer: "+b.outer.String())
+	}
+	return "TestMutualDBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestMutualDBuilder) GoString() string {
+	if b == nil {
+		return "(*TestMutualDBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestMutualDBuilder{model: %#v, outer: %#v}", b.model, b.outer)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestMutualDBuilder) Clone() *TestMutualDBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.outer = b.outer.Clone()
+	return &clone
+}
+
+func (b *TestMutualDBuilder) fromModel(model TestMutualD) {
+	b.model = model
+	b.outer = nil
+	if model.Outer != nil {
+		b.outer = NewTestMutualCBuilder()
+		b.outer.fromModel(*model.Outer)
+	}
+}
+
+// NewTestNamedListsBuilder creates a builder for TestNamedLists.
+func NewTestNamedListsBuilder() *TestNamedListsBuilder {
+	builder := &TestNamedListsBuilder{}
+	builder.model = TestNamedLists{}
+	return builder
+}
+
+// NewTestNamedListsBuilderFromYAML creates a builder for TestNamedLists from the YAML document data.
+func NewTestNamedListsBuilderFromYAML(data []byte) (*TestNamedListsBuilder, error) {
+	builder := NewTestNamedListsBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestNamedListsBuilder struct {
+	model    TestNamedLists
+	row      *TestRowBuilder
+	optional *TestRowBuilder
+}
+
+func (b *TestNamedListsBuilder) Row() *TestRowBuilder {
+	if b.row == nil {
+		b.row = NewTestRowBuilder()
+	}
+	return b.row
+}
+
+// SetRow replaces the list builder of Row by one of the
+// elements of input.
+func (b *TestNamedListsBuilder) SetRow(input TestRow) *TestNamedListsBuilder {
+	b.row = NewTestRowBuilder()
+	b.row.fromModel(input)
+	return b
+}
+
+func (b *TestNamedListsBuilder) Optional() *TestRowBuilder {
+	if b.optional == nil {
+		b.optional = NewTestRowBuilder()
+	}
+	return b.optional
+}
+
+// SetOptional replaces the list builder of Optional by one of the
+// elements of the list input points to, nil if input is nil.
+func (b *TestNamedListsBuilder) SetOptional(input *TestRow) *TestNamedListsBuilder {
+	b.optional = nil
+	if input == nil {
+		return b
+	}
+	b.optional = NewTestRowBuilder()
+	b.optional.fromModel(*input)
+	return b
+}
+
+func (b *TestNamedListsBuilder) Build() TestNamedLists {
+	if b.row != nil {
+		b.model.Row = b.row.Build()
+	}
+	if b.optional != nil {
+		optional := b.optional.Build()
+		b.model.Optional = &optional
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestNamedListsBuilder) BuildPtr() *TestNamedLists {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestNamedListsBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if b.row != nil {
+		fields = append(fields, fmt.Sprintf("Row: %d builders", len(b.row.items)))
+	}
+	if b.optional != nil {
+		fields = append(fields, fmt.Sprintf("Optional: %d builders", len(b.optional.items)))
+	}
+	return "TestNamedListsBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestNamedListsBuilder) GoString() string {
+	if b == nil {
+		return "(*TestNamedListsBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestNamedListsBuilder{model: %#v, row: %#v, optional: %#v}", b.model, b.row, b.optional)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestNamedListsBuilder) Clone() *TestNamedListsBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.row != nil {
+		clone.row = b.row.Clone()
+	}
+	if b.optional != nil {
+		clone.optional = b.optional.Clone()
+	}
+	return &clone
+}
+
+func (b *TestNamedListsBuilder) fromModel(model TestNamedLists) {
+	b.model = model
+	b.row = nil
+	if model.Row != nil {
+		b.row = NewTestRowBuilder()
+		b.row.fromModel(model.Row)
+	}
+	b.optional = nil
+	if model.Optional != nil {
+		b.optional = NewTestRowBuilder()
+		b.optional.fromModel(*model.Optional)
+	}
+}
+
+// NewTestNewCallErrorBuilder creates a builder for TestNewCallError.
+//
+// TestNewCallError is initialized by a method which may fail.
+func NewTestNewCallErrorBuilder() *TestNewCallErrorBuilder {
+	builder := &TestNewCallErrorBuilder{}
+	builder.model = TestNewCallError{}
+	if err := builder.model.Init(); err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// NewTestNewCallErrorBuilderFromYAML creates a builder for TestNewCallError from the YAML document data.
+func NewTestNewCallErrorBuilderFromYAML(data []byte) (*TestNewCallErrorBuilder, error) {
+	builder := NewTestNewCallErrorBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestNewCallErrorBuilder struct {
+	model TestNewCallError
+}
+
+func (b *TestNewCallErrorBuilder) ID(input string) *TestNewCallErrorBuilder {
+	b.model.ID = input
+	return b
+}
+
+func (b *TestNewCallErrorBuilder) Build() TestNewCallError {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestNewCallErrorBuilder) BuildPtr() *TestNewCallError {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestNewCallErrorBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.ID).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("ID: %#v", b.model.ID))
+	}
+	return "TestNewCallErrorBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestNewCallErrorBuilder) GoString() string {
+	if b == nil {
+		return "(*TestNewCallErrorBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestNewCallErrorBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestNewCallErrorBuilder) Clone() *TestNewCallErrorBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestNewCallErrorBuilder) fromModel(model TestNewCallError) {
+	b.model = model
+}
+
+// NewTestNewFuncBuilder creates a builder for TestNewFunc.
+//
+// TestNewFunc is created by its canonical constructor.
+func NewTestNewFuncBuilder() *TestNewFuncBuilder {
+	builder := &TestNewFuncBuilder{}
+	builder.model = *NewTestNewFunc()
+	return builder
+}
+
+// NewTestNewFuncBuilderFromYAML creates a builder for TestNewFunc from the YAML document data.
+func NewTestNewFuncBuilderFromYAML(data []byte) (*TestNewFuncBuilder, error) {
+	builder := NewTestNewFuncBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestNewFuncBuilder struct {
+	model TestNewFunc
+}
+
+func (b *TestNewFuncBuilder) Name(input string) *TestNewFuncBuilder {
+	b.model.Name = input
+	return b
+}
+
+func (b *TestNewFuncBuilder) version(input int) *TestNewFuncBuilder {
+	b.model.version = input
+	return b
+}
+
+func (b *TestNewFuncBuilder) Build() TestNewFunc {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestNewFuncBuilder) BuildPtr() *TestNewFunc {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestNewFuncBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	if !reflect.ValueOf(&b.model.version).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("version: %#v", b.model.version))
+	}
+	return "TestNewFuncBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestNewFuncBuilder) GoString() string {
+	if b == nil {
+		return "(*TestNewFuncBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestNewFuncBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestNewFuncBuilder) Clone() *TestNewFuncBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestNewFuncBuilder) fromModel(model TestNewFunc) {
+	b.model = model
+}
+
+// NewTestNewFuncErrorBuilder creates a builder for TestNewFuncError.
+//
+// TestNewFuncError is created by a constructor which may fail.
+func NewTestNewFuncErrorBuilder() *TestNewFuncErrorBuilder {
+	builder := &TestNewFuncErrorBuilder{}
+	model, err := NewTestNewFuncError()
+	if err != nil {
+		panic(err)
+	}
+	builder.model = model
+	return builder
+}
+
+// NewTestNewFuncErrorBuilderFromYAML creates a builder for TestNewFuncError from the YAML document data.
+func NewTestNewFuncErrorBuilderFromYAML(data []byte) (*TestNewFuncErrorBuilder, error) {
+	builder := NewTestNewFuncErrorBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestNewFuncErrorBuilder struct {
+	model TestNewFuncError
+}
+
+func (b *TestNewFuncErrorBuilder) Name(input string) *TestNewFuncErrorBuilder {
+	b.model.Name = input
+	return b
+}
+
+func (b *TestNewFuncErrorBuilder) Build() TestNewFuncError {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestNewFuncErrorBuilder) BuildPtr() *TestNewFuncError {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestNewFuncErrorBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	return "TestNewFuncErrorBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestNewFuncErrorBuilder) GoString() string {
+	if b == nil {
+		return "(*TestNewFuncErrorBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestNewFuncErrorBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestNewFuncErrorBuilder) Clone() *TestNewFuncErrorBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestNewFuncErrorBuilder) fromModel(model TestNewFuncError) {
+	b.model = model
+}
+
+// NewTestNodeBuilder creates a builder for TestNode.
+func NewTestNodeBuilder() *TestNodeBuilder {
+	builder := &TestNodeBuilder{}
+	builder.model = TestNode{}
+	builder.children = []*TestNodeBuilder{}
+	builder.siblings = []*TestNodeBuilder{}
+	builder.index = map[string]*TestNodeBuilder{}
+	return builder
+}
+
+// NewTestNodeBuilderFromYAML creates a builder for TestNode from the YAML document data.
+func NewTestNodeBuilderFromYAML(data []byte) (*TestNodeBuilder, error) {
+	builder := NewTestNodeBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestNodeBuilder struct {
+	model    TestNode
+	parent   *TestNodeBuilder
+	children []*TestNodeBuilder
+	siblings []*TestNodeBuilder
+	index    map[string]*TestNodeBuilder
+}
+
+func (b *TestNodeBuilder) Name(input string) *TestNodeBuilder {
+	b.model.Name = input
+	return b
+}
+
+func (b *TestNodeBuilder) Parent() *TestNodeBuilder {
+	if b.parent == nil {
+		b.parent = NewTestNodeBuilder()
+	}
+	return b.parent
+}
+
+// SetParent sets Parent to a copy of the value input points to, nil
+// if input is nil.
+func (b *TestNodeBuilder) SetParent(input *TestNode) *TestNodeBuilder {
+	b.parent = nil
+	b.model.Parent = nil
+	if input != nil {
+		b.parent = NewTestNodeBuilder()
+		b.parent.fromModel(*input)
+	}
+	return b
+}
+
+// SetChildrenFrom replaces the builders of Children by builders of the
+// elements of items.
+func (b *TestNodeBuilder) SetChildrenFrom(items []*TestNode) *TestNodeBuilder {
+	b.children = make([]*TestNodeBuilder, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		builder := NewTestNodeBuilder()
+		builder.fromModel(*item)
+		b.children = append(b.children, builder)
+	}
+	return b
+}
+
+func (b *TestNodeBuilder) AddChildren() *TestNodeBuilder {
+	builder := NewTestNodeBuilder()
+	b.children = append(b.children, builder)
+	return builder
+}
+
+// AddChildrenWith calls build with the builder AddChildren appends.
+func (b *TestNodeBuilder) AddChildrenWith(build func(*TestNodeBuilder)) *TestNodeBuilder {
+	build(b.AddChildren())
+	return b
+}
+
+func (b *TestNodeBuilder) RemoveChildren(remove *TestNodeBuilder) {
+	for i, val := range b.children {
+		if val == remove {
+			b.children[i] = b.children[len(b.children)-1]
+			b.children = b.children[:len(b.children)-1]
+		}
+	}
+}
+
+// SetSiblingsFrom replaces the builders of Siblings by builders of the
+// elements of items.
+func (b *TestNodeBuilder) SetSiblingsFrom(items []TestNode) *TestNodeBuilder {
+	b.siblings = make([]*TestNodeBuilder, 0, len(items))
+	for _, item := range items {
+		builder := NewTestNodeBuilder()
+		builder.fromModel(item)
+		b.siblings = append(b.siblings, builder)
+	}
+	return b
+}
+
+func (b *TestNodeBuilder) AddSiblings() *TestNodeBuilder {
+	builder := NewTestNodeBuilder()
+	b.siblings = append(b.siblings, builder)
+	return builder
+}
+
+// AddSiblingsWith calls build with the builder AddSiblings appends.
+func (b *TestNodeBuilder) AddSiblingsWith(build func(*TestNodeBuilder)) *TestNodeBuilder {
+	build(b.AddSiblings())
+	return b
+}
+
+func (b *TestNodeBuilder) RemoveSiblings(remove *TestNodeBuilder) {
+	for i, val := range b.siblings {
+		if val == remove {
+			b.siblings[i] = b.siblings[len(b.siblings)-1]
+			b.siblings = b.siblings[:len(b.siblings)-1]
+		}
+	}
+}
+func (b *TestNodeBuilder) Index(input map[string]TestNode) *TestNodeBuilder {
+	b.index = map[string]*TestNodeBuilder{}
+	for k, v := range input {
+		builder := NewTestNodeBuilder()
+		builder.fromModel(v)
+		b.index[k] = builder
+	}
+	return b
+}
+
+func (b *TestNodeBuilder) AddIndex(key string) *TestNodeBuilder {
+	builder := NewTestNodeBuilder()
+	b.index[key] = builder
+	return builder
+}
+
+// AddIndexWith calls build with the builder AddIndex sets for key.
+func (b *TestNodeBuilder) AddIndexWith(key string, build func(*TestNodeBuilder)) *TestNodeBuilder {
+	build(b.AddIndex(key))
+	return b
+}
+
+// RemoveIndex drops the builder of key from Index.
+func (b *TestNodeBuilder) RemoveIndex(key string) {
+	delete(b.index, key)
+}
+
+func (b *TestNodeBuilder) Build() TestNode {
+	if b.parent != nil {
+		parent := b.parent.Build()
+		b.model.Parent = &parent
+	}
+	b.model.Children = []*TestNode{}
+	for _, v := range b.children {
+		vv := v.Build()
+		b.model.Children = append(b.model.Children, &vv)
+	}
+	b.model.Siblings = []TestNode{}
+	for _, v := range b.siblings {
+		b.model.Siblings = append(b.model.Siblings, v.Build())
+	}
+	b.model.Index = map[string]TestNode{}
+	for k, v := range b.index {
+		b.model.Index[k] = v.Build()
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestNodeBuilder) BuildPtr() *TestNode {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestNodeBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	if b.parent != nil {
+		fields = append(fields, "Parent: "+b.parent.String())
+	}
+	if len(b.children) > 0 {
+		fields = append(fields, fmt.Sprintf("Children: %d builders", len(b.children)))
+	}
+	if len(b.siblings) > 0 {
+		fields = append(fields, fmt.Sprintf("Siblings: %d builders", len(b.siblings)))
+	}
+	if len(b.index) > 0 {
+		fields = append(fields, fmt.Sprintf("Index: %d builders", len(b.index)))
+	}
+	return "TestNodeBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestNodeBuilder) GoString() string {
+	if b == nil {
+		return "(*TestNodeBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestNodeBuilder{model: %#v, parent: %#v, children: %#v, siblings: %#v, index: %#v}", b.model, b.parent, b.children, b.siblings, b.index)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestNodeBuilder) Clone() *TestNodeBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.parent = b.parent.Clone()
+	if b.children != nil {
+		clone.children = make([]*TestNodeBuilder, len(b.children))
+		for k, v := range b.children {
+			clone.children[k] = v.Clone()
+		}
+	}
+	if b.siblings != nil {
+		clone.siblings = make([]*TestNodeBuilder, len(b.siblings))
+		for k, v := range b.siblings {
+			clone.siblings[k] = v.Clone()
+		}
+	}
+	if b.index != nil {
+		clone.index = make(map[string]*TestNodeBuilder, len(b.index))
+		for k, v := range b.index {
+			clone.index[k] = v.Clone()
+		}
+	}
+	return &clone
+}
+
+func (b *TestNodeBuilder) fromModel(model TestNode) {
+	b.model = model
+	b.parent = nil
+	if model.Parent != nil {
+		b.parent = NewTestNodeBuilder()
+		b.parent.fromModel(*model.Parent)
+	}
+	b.children = []*TestNodeBuilder{}
+	for _, v := range model.Children {
+		if v == nil {
+			continue
+		}
+		builder := NewTestNodeBuilder()
+		builder.fromModel(*v)
+		b.children = append(b.children, builder)
+	}
+	b.siblings = []*TestNodeBuilder{}
+	for _, v := range model.Siblings {
+		builder := NewTestNodeBuilder()
+		builder.fromModel(v)
+		b.siblings = append(b.siblings, builder)
+	}
+	b.index = map[string]*TestNodeBuilder{}
+	for k, v := range model.Index {
+		builder := NewTestNodeBuilder()
+		builder.fromModel(v)
+		b.index[k] = builder
+	}
+}
+
+// NewTestObjectBuilder creates a builder for TestObject.
+func NewTestObjectBuilder() *TestObjectBuilder {
+	builder := &TestObjectBuilder{}
+	builder.model = TestObject{}
+	builder.spec = NewTestBBuilder()
+	return builder
+}
+
+// NewTestObjectBuilderFromYAML creates a builder for TestObject from the YAML document data.
+func NewTestObjectBuilderFromYAML(data []byte) (*TestObjectBuilder, error) {
+	builder := NewTestObjectBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestObjectBuilder struct {
+	model TestObject
+	spec  *TestBBuilder
+}
+
+func (b *TestObjectBuilder) TypeMeta(input v1.TypeMeta) *TestObjectBuilder {
+	b.model.TypeMeta = input
+	return b
+}
+
+func (b *TestObjectBuilder) ObjectMeta(input v1.ObjectMeta) *TestObjectBuilder {
+	b.model.ObjectMeta = input
+	return b
+}
+
+func (b *TestObjectBuilder) Spec() *TestBBuilder {
+	return b.spec
+}
+
+// APIVersion sets the APIVersion of TypeMeta.
+func (b *TestObjectBuilder) APIVersion(input string) *TestObjectBuilder {
+	b.model.TypeMeta.APIVersion = input
+	return b
+}
+
+// Kind sets the Kind of TypeMeta.
+func (b *TestObjectBuilder) Kind(input string) *TestObjectBuilder {
+	b.model.TypeMeta.Kind = input
+	return b
+}
+
+// Name sets the Name of ObjectMeta.
+func (b *TestObjectBuilder) Name(input string) *TestObjectBuilder {
+	b.model.ObjectMeta.Name = input
+	return b
+}
+
+// Namespace sets the Namespace of ObjectMeta.
+func (b *TestObjectBuilder) Namespace(input string) *TestObjectBuilder {
+	b.model.ObjectMeta.Namespace = input
+	return b
+}
+
+// Labels sets the Labels of ObjectMeta.
+func (b *TestObjectBuilder) Labels(input map[string]string) *TestObjectBuilder {
+	b.model.ObjectMeta.Labels = input
+	return b
+}
+
+// Annotations sets the Annotations of ObjectMeta.
+func (b *TestObjectBuilder) Annotations(input map[string]string) *TestObjectBuilder {
+	b.model.ObjectMeta.Annotations = input
+	return b
+}
+
+func (b *TestObjectBuilder) Build() TestObject {
+	b.model.Spec = b.spec.Build()
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestObjectBuilder) BuildPtr() *TestObject {
+	model := b.Build()
+	return &model
+}
+
+func (b *TestObjectBuilder) BuildObject() runtime.Object {
+	model := b.Build()
+	return model.DeepCopyObject()
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestObjectBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.TypeMeta).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("TypeMeta: %+v", b.model.TypeMeta))
+	}
+	if !reflect.ValueOf(&b.model.ObjectMeta).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("ObjectMeta: %+v", b.model.ObjectMeta))
+	}
+	if b.spec != nil {
+		fields = append(fields, "Spec: "+b.spec.String())
+	}
+	return "TestObjectBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestObjectBuilder) GoString() string {
+	if b == nil {
+		return "(*TestObjectBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestObjectBuilder{model: %#v, spec: %#v}", b.model, b.spec)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestObjectBuilder) Clone() *TestObjectBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.spec = b.spec.Clone()
+	return &clone
+}
+
+func (b *TestObjectBuilder) fromModel(model TestObject) {
+	b.model = model
+	b.spec.fromModel(model.Spec)
+}
+
+// NewTestOneofBuilder creates a builder for TestOneof.
+//
+// TestOneof has groups of mutually exclusive members, each setter clearing
+// the other members of its group.
+func NewTestOneofBuilder() *TestOneofBuilder {
+	builder := &TestOneofBuilder{}
+	builder.model = TestOneof{}
+	builder.operations = []*TestBBuilder{}
+	return builder
+}
+
+// NewTestOneofBuilderFromYAML creates a builder for TestOneof from the YAML document data.
+func NewTestOneofBuilderFromYAML(data []byte) (*TestOneofBuilder, error) {
+	builder := NewTestOneofBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestOneofBuilder struct {
+	model      TestOneof
+	event      *TestBBuilder
+	operations []*TestBBuilder
+}
+
+func (b *TestOneofBuilder) Name(input string) *TestOneofBuilder {
+	b.model.Name = input
+	return b
+}
+
+func (b *TestOneofBuilder) Event() *TestBBuilder {
+	b.model.Operations = nil
+	b.operations = nil
+	b.model.Sleep = ""
+	if b.event == nil {
+		b.event = NewTestBBuilder()
+	}
+	return b.event
+}
+
+// SetEvent sets Event to a copy of the value input points to, nil
+// if input is nil.
+func (b *TestOneofBuilder) SetEvent(input *TestB) *TestOneofBuilder {
+	b.model.Operations = nil
+	b.operations = nil
+	b.model.Sleep = ""
+	b.event = nil
+	b.model.Event = nil
+	if input != nil {
+		b.event = NewTestBBuilder()
+		b.event.fromModel(*input)
+	}
+	return b
+}
+
+// SetOperationsFrom replaces the builders of Operations by builders of the
+// elements of items.
+func (b *TestOneofBuilder) SetOperationsFrom(items []TestB) *TestOneofBuilder {
+	b.model.Event = nil
+	b.event = nil
+	b.model.Sleep = ""
+	b.operations = make([]*TestBBuilder, 0, len(items))
+	for _, item := range items {
+		builder := NewTestBBuilder()
+		builder.fromModel(item)
+		b.operations = append(b.operations, builder)
+	}
+	return b
+}
+
+func (b *TestOneofBuilder) AddOperations() *TestBBuilder {
+	b.model.Event = nil
+	b.event = nil
+	b.model.Sleep = ""
+	builder := NewTestBBuilder()
+	b.operations = append(b.operations, builder)
+	return builder
+}
+
+// AddOperationsWith calls build with the builder AddOperations appends.
+func (b *TestOneofBuilder) AddOperationsWith(build func(*TestBBuilder)) *TestOneofBuilder {
+	build(b.AddOperations())
+	return b
+}
+
+func (b *TestOneofBuilder) RemoveOperations(remove *TestBBuilder) {
+	for i, val := range b.operations {
+		if val == remove {
+			b.operations[i] = b.operations[len(b.operations)-1]
+			b.operations = b.operations[:len(b.operations)-1]
+		}
+	}
+}
+func (b *TestOneofBuilder) Sleep(input string) *TestOneofBuilder {
+	b.model.Event = nil
+	b.event = nil
+	b.model.Operations = nil
+	b.operations = nil
+	b.model.Sleep = input
+	return b
+}
+
+func (b *TestOneofBuilder) Labels(input map[string]string) *TestOneofBuilder {
+	b.model.Selector = nil
+	b.model.Labels = input
+	return b
+}
+
+func (b *TestOneofBuilder) SetLabelsEntry(key string, value string) *TestOneofBuilder {
+	b.model.Selector = nil
+	if b.model.Labels == nil {
+		b.model.Labels = map[string]string{}
+	}
+	b.model.Labels[key] = value
+	return b
+}
+
+func (b *TestOneofBuilder) Selector(input fmt.Stringer) *TestOneofBuilder {
+	b.model.Labels = nil
+	b.model.Selector = input
+	return b
+}
+
+func (b *TestOneofBuilder) Build() TestOneof {
+	if b.event != nil {
+		event := b.event.Build()
+		b.model.Event = &event
+	}
+	b.model.Operations = []TestB{}
+	for _, v := range b.operations {
+		b.model.Operations = append(b.model.Operations, v.Build())
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestOneofBuilder) BuildPtr() *TestOneof {
+	model := b.Build()
+	return &model
+}
+
+// BuildSafe builds the model, and returns the errors of the validations of
+// its members.
+func (b *TestOneofBuilder) BuildSafe() (TestOneof, error) {
+	model := b.Build()
+	var errs builderErrors
+	set := 0
+	if model.Event != nil {
+		set++
+	}
+	if len(model.Operations) != 0 {
+		set++
+	}
+	if model.Sleep != "" {
+		set++
+	}
+	if set > 1 {
+		errs = append(errs, errors.New("Event, Operations, Sleep: at most one of them must be set"))
+	}
+	set = 0
+	if len(model.Labels) != 0 {
+		set++
+	}
+	if model.Selector != nil {
+		set++
+	}
+	if set > 1 {
+		errs = append(errs, errors.New("Labels, Selector: at most one of them must be set"))
+	}
+	return model, errs.err()
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestOneofBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	if b.event != nil {
+		fields = append(fields, "Event: "+b.event.String())
+	}
+	if len(b.operations) > 0 {
+		fields = append(fields, fmt.Sprintf("Operations: %d builders", len(b.operations)))
+	}
+	if !reflect.ValueOf(&b.model.Sleep).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Sleep: %#v", b.model.Sleep))
+	}
+	if !reflect.ValueOf(&b.model.Labels).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Labels: %+v", b.model.Labels))
+	}
+	if !reflect.ValueOf(&b.model.Selector).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Selector: %+v", b.model.Selector))
+	}
+	return "TestOneofBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestOneofBuilder) GoString() string {
+	if b == nil {
+		return "(*TestOneofBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestOneofBuilder{model: %#v, event: %#v, operations: %#v}", b.model, b.event, b.operations)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestOneofBuilder) Clone() *TestOneofBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.event = b.event.Clone()
+	if b.operations != nil {
+		clone.operations = make([]*TestBBuilder, len(b.operations))
+		for k, v := range b.operations {
+			clone.operations[k] = v.Clone()
+		}
+	}
+	if b.model.Labels != nil {
+		clone.model.Labels = make(map[string]string, len(b.model.Labels))
+		for k, v := range b.model.Labels {
+			clone.model.Labels[k] = v
+		}
+	}
+	return &clone
+}
+
+func (b *TestOneofBuilder) fromModel(model TestOneof) {
+	b.model = model
+	b.event = nil
+	if model.Event != nil {
+		b.event = NewTestBBuilder()
+		b.event.fromModel(*model.Event)
+	}
+	b.operations = []*TestBBuilder{}
+	for _, v := range model.Operations {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.operations = append(b.operations, builder)
+	}
+}
+
+// NewTestPrimitiveMapsBuilder creates a builder for TestPrimitiveMaps.
+//
+// TestPrimitiveMaps has maps of primitive values.
+func NewTestPrimitiveMapsBuilder() *TestPrimitiveMapsBuilder {
+	builder := &TestPrimitiveMapsBuilder{}
+	builder.model = TestPrimitiveMaps{}
+	return builder
+}
+
+// NewTestPrimitiveMapsBuilderFromYAML creates a builder for TestPrimitiveMaps from the YAML document data.
+func NewTestPrimitiveMapsBuilderFromYAML(data []byte) (*TestPrimitiveMapsBuilder, error) {
+	builder := NewTestPrimitiveMapsBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestPrimitiveMapsBuilder struct {
+	model TestPrimitiveMaps
+}
+
+func (b *TestPrimitiveMapsBuilder) Annotations(input map[string]string) *TestPrimitiveMapsBuilder {
+	b.model.Annotations = input
+	return b
+}
+
+func (b *TestPrimitiveMapsBuilder) SetAnnotationsEntry(key string, value string) *TestPrimitiveMapsBuilder {
+	if b.model.Annotations == nil {
+		b.model.Annotations = map[string]string{}
+	}
+	b.model.Annotations[key] = value
+	return b
+}
+
+func (b *TestPrimitiveMapsBuilder) Weights(input map[int]float64) *TestPrimitiveMapsBuilder {
+	b.model.Weights = input
+	return b
+}
+
+func (b *TestPrimitiveMapsBuilder) SetWeightsEntry(key int, value float64) *TestPrimitiveMapsBuilder {
+	if b.model.Weights == nil {
+		b.model.Weights = map[int]float64{}
+	}
+	b.model.Weights[key] = value
+	return b
+}
+
+func (b *TestPrimitiveMapsBuilder) Flags(input TestFlags) *TestPrimitiveMapsBuilder {
+	b.model.Flags = input
+	return b
+}
+
+func (b *TestPrimitiveMapsBuilder) SetFlagsEntry(key string, value bool) *TestPrimitiveMapsBuilder {
+	if b.model.Flags == nil {
+		b.model.Flags = TestFlags{}
+	}
+	b.model.Flags[key] = value
+	return b
+}
+
+func (b *TestPrimitiveMapsBuilder) Build() TestPrimitiveMaps {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestPrimitiveMapsBuilder) BuildPtr() *TestPrimitiveMaps {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestPrimitiveMapsBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Annotations).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Annotations: %+v", b.model.Annotations))
+	}
+	if !reflect.ValueOf(&b.model.Weights).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Weights: %+v", b.model.Weights))
+	}
+	if !reflect.ValueOf(&b.model.Flags).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Flags: %+v", b.model.Flags))
+	}
+	return "TestPrimitiveMapsBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestPrimitiveMapsBuilder) GoString() string {
+	if b == nil {
+		return "(*TestPrimitiveMapsBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestPrimitiveMapsBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestPrimitiveMapsBuilder) Clone() *TestPrimitiveMapsBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.model.Annotations != nil {
+		clone.model.Annotations = make(map[string]string, len(b.model.Annotations))
+		for k, v := range b.model.Annotations {
+			clone.model.Annotations[k] = v
+		}
+	}
+	if b.model.Weights != nil {
+		clone.model.Weights = make(map[int]float64, len(b.model.Weights))
+		for k, v := range b.model.Weights {
+			clone.model.Weights[k] = v
+		}
+	}
+	if b.model.Flags != nil {
+		clone.model.Flags = make(TestFlags, len(b.model.Flags))
+		for k, v := range b.model.Flags {
+			clone.model.Flags[k] = v
+		}
+	}
+	return &clone
+}
+
+func (b *TestPrimitiveMapsBuilder) fromModel(model TestPrimitiveMaps) {
+	b.model = model
+}
+
+// NewTestPrimitiveSlicesBuilder creates a builder for TestPrimitiveSlices.
+//
+// TestPrimitiveSlices has slices of primitive values.
+func NewTestPrimitiveSlicesBuilder() *TestPrimitiveSlicesBuilder {
+	builder := &TestPrimitiveSlicesBuilder{}
+	builder.model = TestPrimitiveSlices{}
+	return builder
+}
+
+// NewTestPrimitiveSlicesBuilderFromYAML creates a builder for TestPrimitiveSlices from the YAML document data.
+func NewTestPrimitiveSlicesBuilderFromYAML(data []byte) (*TestPrimitiveSlicesBuilder, error) {
+	builder := NewTestPrimitiveSlicesBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestPrimitiveSlicesBuilder struct {
+	model TestPrimitiveSlices
+}
+
+func (b *TestPrimitiveSlicesBuilder) Tags(input []string) *TestPrimitiveSlicesBuilder {
+	if input != nil {
+		input = append(make([]string, 0, len(input)), input...)
+	}
+	b.model.Tags = input
+	return b
+}
+
+func (b *TestPrimitiveSlicesBuilder) AddTags(items ...string) *TestPrimitiveSlicesBuilder {
+	b.model.Tags = append(b.model.Tags, items...)
+	return b
+}
+
+func (b *TestPrimitiveSlicesBuilder) AppendTags(item string) *TestPrimitiveSlicesBuilder {
+	b.model.Tags = append(b.model.Tags, item)
+	return b
+}
+
+func (b *TestPrimitiveSlicesBuilder) Ports(input []int) *TestPrimitiveSlicesBuilder {
+	if input != nil {
+		input = append(make([]int, 0, len(input)), input...)
+	}
+	b.model.Ports = input
+	return b
+}
+
+func (b *TestPrimitiveSlicesBuilder) AddPorts(items ...int) *TestPrimitiveSlicesBuilder {
+	b.model.Ports = append(b.model.Ports, items...)
+	return b
+}
+
+func (b *TestPrimitiveSlicesBuilder) AppendPorts(item int) *TestPrimitiveSlicesBuilder {
+	b.model.Ports = append(b.model.Ports, item)
+	return b
+}
+
+func (b *TestPrimitiveSlicesBuilder) Labels(input TestLabels) *TestPrimitiveSlicesBuilder {
+	if input != nil {
+		input = append(make(TestLabels, 0, len(input)), input...)
+	}
+	b.model.Labels = input
+	return b
+}
+
+func (b *TestPrimitiveSlicesBuilder) AddLabels(items ...string) *TestPrimitiveSlicesBuilder {
+	b.model.Labels = append(b.model.Labels, items...)
+	return b
+}
+
+func (b *TestPrimitiveSlicesBuilder) AppendLabels(item string) *TestPrimitiveSlicesBuilder {
+	b.model.Labels = append(b.model.Labels, item)
+	return b
+}
+
+func (b *TestPrimitiveSlicesBuilder) Data(input []byte) *TestPrimitiveSlicesBuilder {
+	b.model.Data = input
+	return b
+}
+
+func (b *TestPrimitiveSlicesBuilder) SetDataString(input string) *TestPrimitiveSlicesBuilder {
+	b.model.Data = []byte(input)
+	return b
+}
+
+func (b *TestPrimitiveSlicesBuilder) Blob(input []byte) *TestPrimitiveSlicesBuilder {
+	b.model.Blob = input
+	return b
+}
+
+func (b *TestPrimitiveSlicesBuilder) SetBlobString(input string) *TestPrimitiveSlicesBuilder {
+	b.model.Blob = []byte(input)
+	return b
+}
+
+// SetBlobBase64 sets Blob to the decoded base64 string input.
+func (b *TestPrimitiveSlicesBuilder) SetBlobBase64(input string) error {
+	decoded, err := base64.StdEncoding.DecodeString(input)
+	if err != nil {
+		return err
+	}
+	b.model.Blob = decoded
+	return nil
+}
+
+func (b *TestPrimitiveSlicesBuilder) Build() TestPrimitiveSlices {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestPrimitiveSlicesBuilder) BuildPtr() *TestPrimitiveSlices {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestPrimitiveSlicesBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Tags).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Tags: %+v", b.model.Tags))
+	}
+	if !reflect.ValueOf(&b.model.Ports).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Ports: %+v", b.model.Ports))
+	}
+	if !reflect.ValueOf(&b.model.Labels).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Labels: %+v", b.model.Labels))
+	}
+	if !reflect.ValueOf(&b.model.Data).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Data: %+v", b.model.Data))
+	}
+	if !reflect.ValueOf(&b.model.Blob).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Blob: %+v", b.model.Blob))
+	}
+	return "TestPrimitiveSlicesBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestPrimitiveSlicesBuilder) GoString() string {
+	if b == nil {
+		return "(*TestPrimitiveSlicesBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestPrimitiveSlicesBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestPrimitiveSlicesBuilder) Clone() *TestPrimitiveSlicesBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.model.Tags != nil {
+		clone.model.Tags = make([]string, len(b.model.Tags))
+		copy(clone.model.Tags, b.model.Tags)
+	}
+	if b.model.Ports != nil {
+		clone.model.Ports = make([]int, len(b.model.Ports))
+		copy(clone.model.Ports, b.model.Ports)
+	}
+	if b.model.Labels != nil {
+		clone.model.Labels = make(TestLabels, len(b.model.Labels))
+		copy(clone.model.Labels, b.model.Labels)
+	}
+	if b.model.Data != nil {
+		clone.model.Data = make([]byte, len(b.model.Data))
+		copy(clone.model.Data, b.model.Data)
+	}
+	if b.model.Blob != nil {
+		clone.model.Blob = make([]byte, len(b.model.Blob))
+		copy(clone.model.Blob, b.model.Blob)
+	}
+	return &clone
+}
+
+func (b *TestPrimitiveSlicesBuilder) fromModel(model TestPrimitiveSlices) {
+	b.model = model
+}
+
+// NewTestPromotedBuilder creates a builder for TestPromoted.
+//
+// TestPromoted embeds two structs with a Name member, its builder has no
+// Name setter like the model has no promoted Name field.
+func NewTestPromotedBuilder() *TestPromotedBuilder {
+	builder := &TestPromotedBuilder{}
+	builder.model = TestPromoted{}
+	builder.TestPromotedABuilder = *NewTestPromotedABuilder()
+	builder.TestPromotedBBuilder = *NewTestPromotedBBuilder()
+	return builder
+}
+
+// NewTestPromotedBuilderFromYAML creates a builder for TestPromoted from the YAML document data.
+func NewTestPromotedBuilderFromYAML(data []byte) (*TestPromotedBuilder, error) {
+	builder := NewTestPromotedBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestPromotedBuilder struct {
+	model TestPromoted
+	TestPromotedABuilder
+	TestPromotedBBuilder
+}
+
+func (b *TestPromotedBuilder) TestPromotedA() *TestPromotedABuilder {
+	return &b.TestPromotedABuilder
+}
+
+func (b *TestPromotedBuilder) Size(input int) *TestPromotedBuilder {
+	b.TestPromotedABuilder.Size(input)
+	return b
+}
+
+func (b *TestPromotedBuilder) TestPromotedB() *TestPromotedBBuilder {
+	return &b.TestPromotedBBuilder
+}
+
+func (b *TestPromotedBuilder) Color(input string) *TestPromotedBuilder {
+	b.TestPromotedBBuilder.Color(input)
+	return b
+}
+
+func (b *TestPromotedBuilder) Build() TestPromoted {
+	b.model.TestPromotedA = b.TestPromotedABuilder.Build()
+	b.model.TestPromotedB = b.TestPromotedBBuilder.Build()
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestPromotedBuilder) BuildPtr() *TestPromoted {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestPromotedBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	fields = append(fields, "TestPromotedA: "+b.TestPromotedABuilder.String())
+	fields = append(fields, "TestPromotedB: "+b.TestPromotedBBuilder.String())
+	return "TestPromotedBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestPromotedBuilder) GoString() string {
+	if b == nil {
+		return "(*TestPromotedBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestPromotedBuilder{model: %#v, TestPromotedABuilder: %#v, TestPromotedBBuilder: %#v}", b.model, &b.TestPromotedABuilder, &b.TestPromotedBBuilder)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestPromotedBuilder) Clone() *TestPromotedBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.TestPromotedABuilder = *b.TestPromotedABuilder.Clone()
+	clone.TestPromotedBBuilder = *b.TestPromotedBBuilder.Clone()
+	return &clone
+}
+
+func (b *TestPromotedBuilder) fromModel(model TestPromoted) {
+	b.model = model
+	b.TestPromotedABuilder.fromModel(model.TestPromotedA)
+	b.TestPromotedBBuilder.fromModel(model.TestPromotedB)
+}
+
+// NewTestPromotedABuilder creates a builder for TestPromotedA.
+func NewTestPromotedABuilder() *TestPromotedABuilder {
+	builder := &TestPromotedABuilder{}
+	builder.model = TestPromotedA{}
+	return builder
+}
+
+// NewTestPromotedABuilderFromYAML creates a builder for TestPromotedA from the YAML document data.
+func NewTestPromotedABuilderFromYAML(data []byte) (*TestPromotedABuilder, error) {
+	builder := NewTestPromotedABuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestPromotedABuilder struct {
+	model TestPromotedA
+}
+
+func (b *TestPromotedABuilder) Name(input string) *TestPromotedABuilder {
+	b.model.Name = input
+	return b
+}
+
+func (b *TestPromotedABuilder) Size(input int) *TestPromotedABuilder {
+	b.model.Size = input
+	return b
+}
+
+func (b *TestPromotedABuilder) Build() TestPromotedA {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestPromotedABuilder) BuildPtr() *TestPromotedA {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestPromotedABuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	if !reflect.ValueOf(&b.model.Size).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Size: %#v", b.model.Size))
+	}
+	return "TestPromotedABuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestPromotedABuilder) GoString() string {
+	if b == nil {
+		return "(*TestPromotedABuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestPromotedABuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestPromotedABuilder) Clone() *TestPromotedABuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestPromotedABuilder) fromModel(model TestPromotedA) {
+	b.model = model
+}
+
+// NewTestPromotedBBuilder creates a builder for TestPromotedB.
+func NewTestPromotedBBuilder() *TestPromotedBBuilder {
+	builder := &TestPromotedBBuilder{}
+	builder.model = TestPromotedB{}
+	return builder
+}
+
+// NewTestPromotedBBuilderFromYAML creates a builder for TestPromotedB from the YAML document data.
+func NewTestPromotedBBuilderFromYAML(data []byte) (*TestPromotedBBuilder, error) {
+	builder := NewTestPromotedBBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestPromotedBBuilder struct {
+	model TestPromotedB
+}
+
+func (b *TestPromotedBBuilder) Name(input string) *TestPromotedBBuilder {
+	b.model.Name = input
+	return b
+}
+
+func (b *TestPromotedBBuilder) Color(input string) *TestPromotedBBuilder {
+	b.model.Color = input
+	return b
+}
+
+func (b *TestPromotedBBuilder) Build() TestPromotedB {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestPromotedBBuilder) BuildPtr() *TestPromotedB {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestPromotedBBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	if !reflect.ValueOf(&b.model.Color).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Color: %#v", b.model.Color))
+	}
+	return "TestPromotedBBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestPromotedBBuilder) GoString() string {
+	if b == nil {
+		return "(*TestPromotedBBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestPromotedBBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestPromotedBBuilder) Clone() *TestPromotedBBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestPromotedBBuilder) fromModel(model TestPromotedB) {
+	b.model = model
+}
+
+// NewTestRequiredBuilder creates a builder for TestRequired with its required members.
+//
+// TestRequired can only be built with its key and tas.
+func NewTestRequiredBuilder(key string, tas int) *TestRequiredBuilder {
+	builder := newTestRequiredBuilder()
+	builder.model.Key = key
+	builder.model.Tas = tas
+	return builder
+}
+
+// newTestRequiredBuilder creates a builder for TestRequired without its required members.
+func newTestRequiredBuilder() *TestRequiredBuilder {
+	builder := &TestRequiredBuilder{}
+	builder.model = TestRequired{}
+	return builder
+}
+
+// NewTestRequiredBuilderFromYAML creates a builder for TestRequired from the YAML document data.
+func NewTestRequiredBuilderFromYAML(data []byte) (*TestRequiredBuilder, error) {
+	builder := newTestRequiredBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestRequiredBuilder struct {
+	model TestRequired
+}
+
+func (b *TestRequiredBuilder) Key(input string) *TestRequiredBuilder {
+	b.model.Key = input
+	return b
+}
+
+func (b *TestRequiredBuilder) Tas(input int) *TestRequiredBuilder {
+	b.model.Tas = input
+	return b
+}
+
+func (b *TestRequiredBuilder) Optional(input string) *TestRequiredBuilder {
+	b.model.Optional = input
+	return b
+}
+
+func (b *TestRequiredBuilder) Build() TestRequired {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestRequiredBuilder) BuildPtr() *TestRequired {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestRequiredBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Key).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Key: %#v", b.model.Key))
+	}
+	if !reflect.ValueOf(&b.model.Tas).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Tas: %#v", b.model.Tas))
+	}
+	if !reflect.ValueOf(&b.model.Optional).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Optional: %#v", b.model.Optional))
+	}
+	return "TestRequiredBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestRequiredBuilder) GoString() string {
+	if b == nil {
+		return "(*TestRequiredBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestRequiredBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestRequiredBuilder) Clone() *TestRequiredBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestRequiredBuilder) fromModel(model TestRequired) {
+	b.model = model
+}
+
+// NewTestRequiredParentBuilder creates a builder for TestRequiredParent.
+//
+// TestRequiredParent holds a type with required members, set as values.
+func NewTestRequiredParentBuilder() *TestRequiredParentBuilder {
+	builder := &TestRequiredParentBuilder{}
+	builder.model = TestRequiredParent{}
+	return builder
+}
+
+// NewTestRequiredParentBuilderFromYAML creates a builder for TestRequiredParent from the YAML document data.
+func NewTestRequiredParentBuilderFromYAML(data []byte) (*TestRequiredParentBuilder, error) {
+	builder := NewTestRequiredParentBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestRequiredParentBuilder struct {
+	model TestRequiredParent
+}
+
+func (b *TestRequiredParentBuilder) Child(input TestRequired) *TestRequiredParentBuilder {
+	b.model.Child = input
+	return b
+}
+
+func (b *TestRequiredParentBuilder) Children(input []*TestRequired) *TestRequiredParentBuilder {
+	b.model.Children = input
+	return b
+}
+
+func (b *TestRequiredParentBuilder) Build() TestRequiredParent {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestRequiredParentBuilder) BuildPtr() *TestRequiredParent {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestRequiredParentBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Child).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Child: %+v", b.model.Child))
+	}
+	if !reflect.ValueOf(&b.model.Children).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Children: %+v", b.model.Children))
+	}
+	return "TestRequiredParentBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestRequiredParentBuilder) GoString() string {
+	if b == nil {
+		return "(*TestRequiredParentBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestRequiredParentBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestRequiredParentBuilder) Clone() *TestRequiredParentBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.model.Children != nil {
+		clone.model.Children = make([]*TestRequired, len(b.model.Children))
+		copy(clone.model.Children, b.model.Children)
+	}
+	return &clone
+}
+
+func (b *TestRequiredParentBuilder) fromModel(model TestRequiredParent) {
+	b.model = model
+}
+
+// NewTestSlicePointersBuilder creates a builder for TestSlicePointers.
+func NewTestSlicePointersBuilder() *TestSlicePointersBuilder {
+	builder := &TestSlicePointersBuilder{}
+	builder.model = TestSlicePointers{}
+	return builder
+}
+
+// NewTestSlicePointersBuilderFromYAML creates a builder for TestSlicePointers from the YAML document data.
+func NewTestSlicePointersBuilderFromYAML(data []byte) (*TestSlicePointersBuilder, error) {
+	builder := NewTestSlicePointersBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestSlicePointersBuilder struct {
+	model        TestSlicePointers
+	items        []*TestBBuilder
+	itempointers []*TestBBuilder
+	itemmap      map[string]*TestBBuilder
+}
+
+// SetItemsFrom replaces the builders of Items by builders of the
+// elements of items.
+func (b *TestSlicePointersBuilder) SetItemsFrom(items []TestB) *TestSlicePointersBuilder {
+	b.items = make([]*TestBBuilder, 0, len(items))
+	for _, item := range items {
+		builder := NewTestBBuilder()
+		builder.fromModel(item)
+		b.items = append(b.items, builder)
+	}
+	return b
+}
+
+func (b *TestSlicePointersBuilder) AddItems() *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.items = append(b.items, builder)
+	return builder
+}
+
+// AddItemsWith calls build with the builder AddItems appends.
+func (b *TestSlicePointersBuilder) AddItemsWith(build func(*TestBBuilder)) *TestSlicePointersBuilder {
+	build(b.AddItems())
+	return b
+}
+
+func (b *TestSlicePointersBuilder) RemoveItems(remove *TestBBuilder) {
+	for i, val := range b.items {
+		if val == remove {
+			b.items[i] = b.items[len(b.items)-1]
+			b.items = b.items[:len(b.items)-1]
+		}
+	}
+}
+
+// SetItemPointersFrom replaces the builders of ItemPointers by builders of the
+// elements of items.
+func (b *TestSlicePointersBuilder) SetItemPointersFrom(items []*TestB) *TestSlicePointersBuilder {
+	b.itempointers = make([]*TestBBuilder, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		builder := NewTestBBuilder()
+		builder.fromModel(*item)
+		b.itempointers = append(b.itempointers, builder)
+	}
+	return b
+}
+
+func (b *TestSlicePointersBuilder) AddItemPointers() *TestBBuilder {
+	builder := NewTestBBuilder()
+	b.itempointers = append(b.itempointers, builder)
+	return builder
+}
+
+// AddItemPointersWith calls build with the builder AddItemPointers appends.
+func (b *TestSlicePointersBuilder) AddItemPointersWith(build func(*TestBBuilder)) *TestSlicePointersBuilder {
+	build(b.AddItemPointers())
+	return b
+}
+
+func (b *TestSlicePointersBuilder) RemoveItemPointers(remove *TestBBuilder) {
+	for i, val := range b.itempointers {
+		if val == remove {
+			b.itempointers[i] = b.itempointers[len(b.itempointers)-1]
+			b.itempointers = b.itempointers[:len(b.itempointers)-1]
+		}
+	}
+}
+func (b *TestSlicePointersBuilder) ItemMap(input *map[string]TestB) *TestSlicePointersBuilder {
+	if input == nil {
+		b.itemmap = nil
+		return b
+	}
+	b.itemmap = map[string]*TestBBuilder{}
+	for k, v := range *input {
+		builder := NewTestBBuilder()
+		builder.fromModel(v)
+		b.itemmap[k] = builder
+	}
+	return b
+}
+
+func (b *TestSlicePointersBuilder) AddItemMap(key string) *TestBBuilder {
+	if b.itemmap == nil {
+		b.itemmap = map[string]*TestBBuilder{}
+	}
+	builder := NewTestBBuilder()
+	b.itemmap[key] = builder
+	return builder
+}
+
+// AddItemMapWith calls build with the builder AddItemMap sets for key.
+func (b *TestSlicePointersBuilder) AddItemMapWith(key string, build func(*TestBBuilder)) *TestSlicePointersBuilder {
+	build(b.AddItemMap(key))
+	return b
+}
+
+// RemoveItemMap drops the builder of key from ItemMap.
+func (b *TestSlicePointersBuilder) RemoveItemMap(key string) {
+	delete(b.itemmap, key)
+}
+
+func (b *TestSlicePointersBuilder) Names(input *[]string) *TestSlicePointersBuilder {
+	b.model.Names = input
+	return b
+}
+
+func (b *TestSlicePointersBuilder) Build() TestSlicePointers {
+	if b.items != nil {
+		items := []TestB{}
+		for _, v := range b.items {
+			items = append(items, v.Build())
+		}
+		b.model.Items = &items
+	}
+	if b.itempointers != nil {
+		itempointers := []*TestB{}
+		for _, v := range b.itempointers {
+			vv := v.Build()
+			itempointers = append(itempointers, &vv)
+		}
+		b.model.ItemPointers = &itempointers
+	}
+	if b.itemmap != nil {
+		itemmap := map[string]TestB{}
+		for k, v := range b.itemmap {
+			itemmap[k] = v.Build()
+		}
+		b.model.ItemMap = &itemmap
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestSlicePointersBuilder) BuildPtr() *TestSlicePointers {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestSlicePointersBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if len(b.items) > 0 {
+		fields = append(fields, fmt.Sprintf("Items: %d builders", len(b.items)))
+	}
+	if len(b.itempointers) > 0 {
+		fields = append(fields, fmt.Sprintf("ItemPointers: %d builders", len(b.itempointers)))
+	}
+	if len(b.itemmap) > 0 {
+		fields = append(fields, fmt.Sprintf("ItemMap: %d builders", len(b.itemmap)))
+	}
+	if !reflect.ValueOf(&b.model.Names).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Names: %+v", b.model.Names))
+	}
+	return "TestSlicePointersBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestSlicePointersBuilder) GoString() string {
+	if b == nil {
+		return "(*TestSlicePointersBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestSlicePointersBuilder{model: %#v, items: %#v, itempointers: %#v, itemmap: %#v}", b.model, b.items, b.itempointers, b.itemmap)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestSlicePointersBuilder) Clone() *TestSlicePointersBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.items != nil {
+		clone.items = make([]*TestBBuilder, len(b.items))
+		for k, v := range b.items {
+			clone.items[k] = v.Clone()
+		}
+	}
+	if b.itempointers != nil {
+		clone.itempointers = make([]*TestBBuilder, len(b.itempointers))
+		for k, v := range b.itempointers {
+			clone.itempointers[k] = v.Clone()
+		}
+	}
+	if b.itemmap != nil {
+		clone.itemmap = make(map[string]*TestBBuilder, len(b.itemmap))
+		for k, v := range b.itemmap {
+			clone.itemmap[k] = v.Clone()
+		}
+	}
+	return &clone
+}
+
+func (b *TestSlicePointersBuilder) fromModel(model TestSlicePointers) {
+	b.model = model
+	b.items = nil
+	if model.Items != nil {
+		b.items = []*TestBBuilder{}
+		for _, v := range *model.Items {
+			builder := NewTestBBuilder()
+			builder.fromModel(v)
+			b.items = append(b.items, builder)
+		}
+	}
+	b.itempointers = nil
+	if model.ItemPointers != nil {
+		b.itempointers = []*TestBBuilder{}
+		for _, v := range *model.ItemPointers {
+			if v == nil {
+				continue
+			}
+			builder := NewTestBBuilder()
+			builder.fromModel(*v)
+			b.itempointers = append(b.itempointers, builder)
+		}
+	}
+	b.itemmap = nil
+	if model.ItemMap != nil {
+		b.itemmap = map[string]*TestBBuilder{}
+		for k, v := range *model.ItemMap {
+			builder := NewTestBBuilder()
+			builder.fromModel(v)
+			b.itemmap[k] = builder
+		}
+	}
+}
+
+// NewTestSliceSlicesBuilder creates a builder for TestSliceSlices.
+func NewTestSliceSlicesBuilder() *TestSliceSlicesBuilder {
+	builder := &TestSliceSlicesBuilder{}
+	builder.model = TestSliceSlices{}
+	return builder
+}
+
+// NewTestSliceSlicesBuilderFromYAML creates a builder for TestSliceSlices from the YAML document data.
+func NewTestSliceSlicesBuilderFromYAML(data []byte) (*TestSliceSlicesBuilder, error) {
+	builder := NewTestSliceSlicesBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestSliceSlicesBuilder struct {
+	model    TestSliceSlices
+	grid     []*TestBListBuilder
+	pointers []*TestBPointerListBuilder
+	cubes    []*TestBListListBuilder
+	rows     []*TestRowBuilder
+}
+
+func (b *TestSliceSlicesBuilder) Grid(input [][]TestB) *TestSliceSlicesBuilder {
+	b.grid = make([]*TestBListBuilder, 0, len(input))
+	for _, v := range input {
+		builder := &TestBListBuilder{}
+		builder.fromModel(v)
+		b.grid = append(b.grid, builder)
+	}
+	return b
+}
+
+// AddGrid appends a new list builder, building an element of
+// Grid, and returns it.
+func (b *TestSliceSlicesBuilder) AddGrid() *TestBListBuilder {
+	builder := &TestBListBuilder{}
+	b.grid = append(b.grid, builder)
+	return builder
+}
+
+func (b *TestSliceSlicesBuilder) Pointers(input [][]*TestB) *TestSliceSlicesBuilder {
+	b.pointers = make([]*TestBPointerListBuilder, 0, len(input))
+	for _, v := range input {
+		builder := &TestBPointerListBuilder{}
+		builder.fromModel(v)
+		b.pointers = append(b.pointers, builder)
+	}
+	return b
+}
+
+// AddPointers appends a new list builder, building an element of
+// Pointers, and returns it.
+func (b *TestSliceSlicesBuilder) AddPointers() *TestBPointerListBuilder {
+	builder := &TestBPointerListBuilder{}
+	b.pointers = append(b.pointers, builder)
+	return builder
+}
+
+func (b *TestSliceSlicesBuilder) Cubes(input [][][]TestB) *TestSliceSlicesBuilder {
+	b.cubes = make([]*TestBListListBuilder, 0, len(input))
+	for _, v := range input {
+		builder := &TestBListListBuilder{}
+		builder.fromModel(v)
+		b.cubes = append(b.cubes, builder)
+	}
+	return b
+}
+
+// AddCubes appends a new list builder, building an element of
+// Cubes, and returns it.
+func (b *TestSliceSlicesBuilder) AddCubes() *TestBListListBuilder {
+	builder := &TestBListListBuilder{}
+	b.cubes = append(b.cubes, builder)
+	return builder
+}
+
+func (b *TestSliceSlicesBuilder) Rows(input []TestRow) *TestSliceSlicesBuilder {
+	b.rows = make([]*TestRowBuilder, 0, len(input))
+	for _, v := range input {
+		builder := &TestRowBuilder{}
+		builder.fromModel(v)
+		b.rows = append(b.rows, builder)
+	}
+	return b
+}
+
+// AddRows appends a new list builder, building an element of
+// Rows, and returns it.
+func (b *TestSliceSlicesBuilder) AddRows() *TestRowBuilder {
+	builder := &TestRowBuilder{}
+	b.rows = append(b.rows, builder)
+	return builder
+}
+
+func (b *TestSliceSlicesBuilder) Foreign(input [][]other.Address) *TestSliceSlicesBuilder {
+	b.model.Foreign = input
+	return b
+}
+
+func (b *TestSliceSlicesBuilder) Build() TestSliceSlices {
+	b.model.Grid = make([][]TestB, 0, len(b.grid))
+	for _, v := range b.grid {
+		b.model.Grid = append(b.model.Grid, v.Build())
+	}
+	b.model.Pointers = make([][]*TestB, 0, len(b.pointers))
+	for _, v := range b.pointers {
+		b.model.Pointers = append(b.model.Pointers, v.Build())
+	}
+	b.model.Cubes = make([][][]TestB, 0, len(b.cubes))
+	for _, v := range b.cubes {
+		b.model.Cubes = append(b.model.Cubes, v.Build())
+	}
+	b.model.Rows = make([]TestRow, 0, len(b.rows))
+	for _, v := range b.rows {
+		b.model.Rows = append(b.model.Rows, v.Build())
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestSliceSlicesBuilder) BuildPtr() *TestSliceSlices {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestSliceSlicesBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if len(b.grid) > 0 {
+		fields = append(fields, fmt.Sprintf("Grid: %d lists of builders", len(b.grid)))
+	}
+	if len(b.pointers) > 0 {
+		fields = append(fields, fmt.Sprintf("Pointers: %d lists of builders", len(b.pointers)))
+	}
+	if len(b.cubes) > 0 {
+		fields = append(fields, fmt.Sprintf("Cubes: %d lists of builders", len(b.cubes)))
+	}
+	if len(b.rows) > 0 {
+		fields = append(fields, fmt.Sprintf("Rows: %d lists of builders", len(b.rows)))
+	}
+	if !reflect.ValueOf(&b.model.Foreign).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Foreign: %+v", b.model.Foreign))
+	}
+	return "TestSliceSlicesBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestSliceSlicesBuilder) GoString() string {
+	if b == nil {
+		return "(*TestSliceSlicesBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestSliceSlicesBuilder{model: %#v, grid: %#v, pointers: %#v, cubes: %#v, rows: %#v}", b.model, b.grid, b.pointers, b.cubes, b.rows)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestSliceSlicesBuilder) Clone() *TestSliceSlicesBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.grid != nil {
+		clone.grid = make([]*TestBListBuilder, len(b.grid))
+		for k, v := range b.grid {
+			clone.grid[k] = v.Clone()
+		}
+	}
+	if b.pointers != nil {
+		clone.pointers = make([]*TestBPointerListBuilder, len(b.pointers))
+		for k, v := range b.pointers {
+			clone.pointers[k] = v.Clone()
+		}
+	}
+	if b.cubes != nil {
+		clone.cubes = make([]*TestBListListBuilder, len(b.cubes))
+		for k, v := range b.cubes {
+			clone.cubes[k] = v.Clone()
+		}
+	}
+	if b.rows != nil {
+		clone.rows = make([]*TestRowBuilder, len(b.rows))
+		for k, v := range b.rows {
+			clone.rows[k] = v.Clone()
+		}
+	}
+	if b.model.Foreign != nil {
+		clone.model.Foreign = make([][]other.Address, len(b.model.Foreign))
+		copy(clone.model.Foreign, b.model.Foreign)
+	}
+	return &clone
+}
+
+func (b *TestSliceSlicesBuilder) fromModel(model TestSliceSlices) {
+	b.model = model
+	b.grid = make([]*TestBListBuilder, 0, len(model.Grid))
+	for _, v := range model.Grid {
+		builder := &TestBListBuilder{}
+		builder.fromModel(v)
+		b.grid = append(b.grid, builder)
+	}
+	b.pointers = make([]*TestBPointerListBuilder, 0, len(model.Pointers))
+	for _, v := range model.Pointers {
+		builder := &TestBPointerListBuilder{}
+		builder.fromModel(v)
+		b.pointers = append(b.pointers, builder)
+	}
+	b.cubes = make([]*TestBListListBuilder, 0, len(model.Cubes))
+	for _, v := range model.Cubes {
+		builder := &TestBListListBuilder{}
+		builder.fromModel(v)
+		b.cubes = append(b.cubes, builder)
+	}
+	b.rows = make([]*TestRowBuilder, 0, len(model.Rows))
+	for _, v := range model.Rows {
+		builder := &TestRowBuilder{}
+		builder.fromModel(v)
+		b.rows = append(b.rows, builder)
+	}
+}
+
+// NewTestStartBuilder creates a builder for TestStart.
+//
+// TestStart is a "string or object" union like those of the Serverless
+// Workflow model, decoded from the name of a state or from an object.
+func NewTestStartBuilder() *TestStartBuilder {
+	builder := &TestStartBuilder{}
+	builder.model = TestStart{}
+	return builder
+}
+
+// NewTestStartBuilderFromYAML creates a builder for TestStart from the YAML document data.
+func NewTestStartBuilderFromYAML(data []byte) (*TestStartBuilder, error) {
+	builder := NewTestStartBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestStartBuilder struct {
+	model    TestStart
+	schedule *TestBBuilder
+}
+
+func (b *TestStartBuilder) StateName(input string) *TestStartBuilder {
+	b.model.StateName = input
+	return b
+}
+
+func (b *TestStartBuilder) Schedule() *TestBBuilder {
+	if b.schedule == nil {
+		b.schedule = NewTestBBuilder()
+	}
+	return b.schedule
+}
+
+// SetSchedule sets Schedule to a copy of the value input points to, nil
+// if input is nil.
+func (b *TestStartBuilder) SetSchedule(input *TestB) *TestStartBuilder {
+	b.schedule = nil
+	b.model.Schedule = nil
+	if input != nil {
+		b.schedule = NewTestBBuilder()
+		b.schedule.fromModel(*input)
+	}
+	return b
+}
+
+func (b *TestStartBuilder) Build() TestStart {
+	if b.schedule != nil {
+		schedule := b.schedule.Build()
+		b.model.Schedule = &schedule
+	}
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestStartBuilder) BuildPtr() *TestStart {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestStartBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.StateName).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("StateName: %#v", b.model.StateName))
+	}
+	if b.schedule != nil {
+		fields = append(fields, "Schedule: "+b.schedule.String())
+	}
+	return "TestStartBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestStartBuilder) GoString() string {
+	if b == nil {
+		return "(*TestStartBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestStartBuilder{model: %#v, schedule: %#v}", b.model, b.schedule)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestStartBuilder) Clone() *TestStartBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.schedule = b.schedule.Clone()
+	return &clone
+}
+
+func (b *TestStartBuilder) fromModel(model TestStart) {
+	b.model = model
+	b.schedule = nil
+	if model.Schedule != nil {
+		b.schedule = NewTestBBuilder()
+		b.schedule.fromModel(*model.Schedule)
+	}
+}
+
+// NewTestStructValidatedBuilder creates a builder for TestStructValidated.
+//
+// TestStructValidated carries the validate struct tags of
+// github.com/go-playground/validator.
+func NewTestStructValidatedBuilder() *TestStructValidatedBuilder {
+	builder := &TestStructValidatedBuilder{}
+	builder.model = TestStructValidated{}
+	return builder
+}
+
+// NewTestStructValidatedBuilderFromYAML creates a builder for TestStructValidated from the YAML document data.
+func NewTestStructValidatedBuilderFromYAML(data []byte) (*TestStructValidatedBuilder, error) {
+	builder := NewTestStructValidatedBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestStructValidatedBuilder struct {
+	model TestStructValidated
+}
+
+func (b *TestStructValidatedBuilder) Email(input string) *TestStructValidatedBuilder {
+	b.model.Email = input
+	return b
+}
+
+func (b *TestStructValidatedBuilder) Age(input int) *TestStructValidatedBuilder {
+	b.model.Age = input
+	return b
+}
+
+func (b *TestStructValidatedBuilder) Build() TestStructValidated {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestStructValidatedBuilder) BuildPtr() *TestStructValidated {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestStructValidatedBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Email).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Email: %#v", b.model.Email))
+	}
+	if !reflect.ValueOf(&b.model.Age).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Age: %#v", b.model.Age))
+	}
+	return "TestStructValidatedBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestStructValidatedBuilder) GoString() string {
+	if b == nil {
+		return "(*TestStructValidatedBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestStructValidatedBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestStructValidatedBuilder) Clone() *TestStructValidatedBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestStructValidatedBuilder) fromModel(model TestStructValidated) {
+	b.model = model
+}
+
+// NewTestUnsupportedBuilder creates a builder for TestUnsupported.
+//
+// TestUnsupported has members the builder reports instead of setting.
+func NewTestUnsupportedBuilder() *TestUnsupportedBuilder {
+	builder := &TestUnsupportedBuilder{}
+	builder.model = TestUnsupported{}
+	return builder
+}
+
+// NewTestUnsupportedBuilderFromYAML creates a builder for TestUnsupported from the YAML document data.
+func NewTestUnsupportedBuilderFromYAML(data []byte) (*TestUnsupportedBuilder, error) {
+	builder := NewTestUnsupportedBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestUnsupportedBuilder struct {
+	model TestUnsupported
+}
+
+func (b *TestUnsupportedBuilder) Key(input string) *TestUnsupportedBuilder {
+	b.model.Key = input
+	return b
+}
+
+func (b *TestUnsupportedBuilder) Any(input interface{}) *TestUnsupportedBuilder {
+	b.model.Any = input
+	return b
+}
+
+func (b *TestUnsupportedBuilder) Build() TestUnsupported {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestUnsupportedBuilder) BuildPtr() *TestUnsupported {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestUnsupportedBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Key).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Key: %#v", b.model.Key))
+	}
+	if !reflect.ValueOf(&b.model.Any).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Any: %+v", b.model.Any))
+	}
+	if !reflect.ValueOf(&b.model.Fixed).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Fixed: %+v", b.model.Fixed))
+	}
+	if b.model.Callback != nil {
+		fields = append(fields, "Callback: <func>")
+	}
+	if !reflect.ValueOf(&b.model.Signals).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Signals: %+v", b.model.Signals))
+	}
+	if !reflect.ValueOf(&b.model.Listeners).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Listeners: %+v", b.model.Listeners))
+	}
+	return "TestUnsupportedBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestUnsupportedBuilder) GoString() string {
+	if b == nil {
+		return "(*TestUnsupportedBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestUnsupportedBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestUnsupportedBuilder) Clone() *TestUnsupportedBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestUnsupportedBuilder) fromModel(model TestUnsupported) {
+	b.model = model
+}
+
+// NewTestValidatedBuilder creates a builder for TestValidated.
+//
+// TestValidated has members checked by BuildSafe.
+func NewTestValidatedBuilder() *TestValidatedBuilder {
+	builder := &TestValidatedBuilder{}
+	builder.model = TestValidated{}
+	return builder
+}
+
+// NewTestValidatedBuilderFromYAML creates a builder for TestValidated from the YAML document data.
+func NewTestValidatedBuilderFromYAML(data []byte) (*TestValidatedBuilder, error) {
+	builder := NewTestValidatedBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestValidatedBuilder struct {
+	model TestValidated
+}
+
+func (b *TestValidatedBuilder) Name(input string) *TestValidatedBuilder {
+	b.model.Name = input
+	return b
+}
+
+func (b *TestValidatedBuilder) Replicas(input int) *TestValidatedBuilder {
+	b.model.Replicas = input
+	return b
+}
+
+func (b *TestValidatedBuilder) Tags(input []string) *TestValidatedBuilder {
+	if input != nil {
+		input = append(make([]string, 0, len(input)), input...)
+	}
+	b.model.Tags = input
+	return b
+}
+
+func (b *TestValidatedBuilder) AddTags(items ...string) *TestValidatedBuilder {
+	b.model.Tags = append(b.model.Tags, items...)
+	return b
+}
+
+func (b *TestValidatedBuilder) AppendTags(item string) *TestValidatedBuilder {
+	b.model.Tags = append(b.model.Tags, item)
+	return b
+}
+
+func (b *TestValidatedBuilder) Ratio(input *float64) *TestValidatedBuilder {
+	b.model.Ratio = input
+	return b
+}
+
+func (b *TestValidatedBuilder) Notes(input string) *TestValidatedBuilder {
+	b.model.Notes = input
+	return b
+}
+
+func (b *TestValidatedBuilder) Build() TestValidated {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestValidatedBuilder) BuildPtr() *TestValidated {
+	model := b.Build()
+	return &model
+}
+
+var testValidatedNamePattern = regexp.MustCompile("^[a-z][a-z0-9-]*$")
+
+// BuildSafe builds the model, and returns the errors of the validations of
+// its members.
+func (b *TestValidatedBuilder) BuildSafe() (TestValidated, error) {
+	model := b.Build()
+	var errs builderErrors
+	if len(model.Name) == 0 {
+		errs = append(errs, errors.New("Name: must not be empty"))
+	}
+	if !testValidatedNamePattern.MatchString(model.Name) {
+		errs = append(errs, errors.New("Name: must match ^[a-z][a-z0-9-]*$"))
+	}
+	if model.Replicas < 1 {
+		errs = append(errs, errors.New("Replicas: must be at least 1"))
+	}
+	if model.Replicas > 10 {
+		errs = append(errs, errors.New("Replicas: must be at most 10"))
+	}
+	if len(model.Tags) > 3 {
+		errs = append(errs, errors.New("Tags: must have a length of at most 3"))
+	}
+	if model.Ratio == nil {
+		errs = append(errs, errors.New("Ratio: must not be empty"))
+	}
+	if model.Ratio != nil {
+		if *model.Ratio < 0.5 {
+			errs = append(errs, errors.New("Ratio: must be at least 0.5"))
+		}
+	}
+	return model, errs.err()
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestValidatedBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.Name).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Name: %#v", b.model.Name))
+	}
+	if !reflect.ValueOf(&b.model.Replicas).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Replicas: %#v", b.model.Replicas))
+	}
+	if !reflect.ValueOf(&b.model.Tags).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Tags: %+v", b.model.Tags))
+	}
+	if !reflect.ValueOf(&b.model.Ratio).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Ratio: %+v", b.model.Ratio))
+	}
+	if !reflect.ValueOf(&b.model.Notes).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("Notes: %#v", b.model.Notes))
+	}
+	return "TestValidatedBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestValidatedBuilder) GoString() string {
+	if b == nil {
+		return "(*TestValidatedBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestValidatedBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestValidatedBuilder) Clone() *TestValidatedBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	if b.model.Tags != nil {
+		clone.model.Tags = make([]string, len(b.model.Tags))
+		copy(clone.model.Tags, b.model.Tags)
+	}
+	return &clone
+}
+
+func (b *TestValidatedBuilder) fromModel(model TestValidated) {
+	b.model = model
+}
+
+// NewTestWorkflowBuilder creates a builder for TestWorkflow.
+func NewTestWorkflowBuilder() *TestWorkflowBuilder {
+	builder := &TestWorkflowBuilder{}
+	builder.model = TestWorkflow{}
+	builder.init = NewTestStartBuilder()
+	return builder
+}
+
+// NewTestWorkflowBuilderFromYAML creates a builder for TestWorkflow from the YAML document data.
+func NewTestWorkflowBuilderFromYAML(data []byte) (*TestWorkflowBuilder, error) {
+	builder := NewTestWorkflowBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestWorkflowBuilder struct {
+	model TestWorkflow
+	start *TestStartBuilder
+	init  *TestStartBuilder
+}
+
+func (b *TestWorkflowBuilder) ID(input string) *TestWorkflowBuilder {
+	b.model.ID = input
+	return b
+}
+
+func (b *TestWorkflowBuilder) Start() *TestStartBuilder {
+	if b.start == nil {
+		b.start = NewTestStartBuilder()
+	}
+	return b.start
+}
+
+// SetStart sets Start to a copy of the value input points to, nil
+// if input is nil.
+func (b *TestWorkflowBuilder) SetStart(input *TestStart) *TestWorkflowBuilder {
+	b.start = nil
+	b.model.Start = nil
+	if input != nil {
+		b.start = NewTestStartBuilder()
+		b.start.fromModel(*input)
+	}
+	return b
+}
+
+func (b *TestWorkflowBuilder) Init() *TestStartBuilder {
+	return b.init
+}
+
+func (b *TestWorkflowBuilder) Build() TestWorkflow {
+	if b.start != nil {
+		start := b.start.Build()
+		b.model.Start = &start
+	}
+	b.model.Init = b.init.Build()
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestWorkflowBuilder) BuildPtr() *TestWorkflow {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestWorkflowBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	if !reflect.ValueOf(&b.model.ID).Elem().IsZero() {
+		fields = append(fields, fmt.Sprintf("ID: %#v", b.model.ID))
+	}
+	if b.start != nil {
+		fields = append(fields, "Start: "+b.start.String())
+	}
+	if b.init != nil {
+		fields = append(fields, "Init: "+b.init.String())
+	}
+	return "TestWorkflowBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestWorkflowBuilder) GoString() string {
+	if b == nil {
+		return "(*TestWorkflowBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestWorkflowBuilder{model: %#v, start: %#v, init: %#v}", b.model, b.start, b.init)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestWorkflowBuilder) Clone() *TestWorkflowBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	clone.start = b.start.Clone()
+	clone.init = b.init.Clone()
+	return &clone
+}
+
+func (b *TestWorkflowBuilder) fromModel(model TestWorkflow) {
+	b.model = model
+	b.start = nil
+	if model.Start != nil {
+		b.start = NewTestStartBuilder()
+		b.start.fromModel(*model.Start)
+	}
+	b.init.fromModel(model.Init)
+}
+
+// NewTestZoneMapBuilder creates a builder for TestZoneMap.
+func NewTestZoneMapBuilder() *TestZoneMapBuilder {
+	builder := &TestZoneMapBuilder{}
+	builder.model = TestZoneMap{}
+	return builder
+}
+
+// NewTestZoneMapBuilderFromYAML creates a builder for TestZoneMap from the YAML document data.
+func NewTestZoneMapBuilderFromYAML(data []byte) (*TestZoneMapBuilder, error) {
+	builder := NewTestZoneMapBuilder()
+	model := builder.Build()
+	if err := yaml.Unmarshal(data, &model); err != nil {
+		return nil, err
+	}
+	builder.fromModel(model)
+	return builder, nil
+}
+
+type TestZoneMapBuilder struct {
+	model TestZoneMap
+}
+
+func (b *TestZoneMapBuilder) Build() TestZoneMap {
+	return b.model
+}
+
+// BuildPtr returns a pointer to the model built by Build.
+func (b *TestZoneMapBuilder) BuildPtr() *TestZoneMap {
+	model := b.Build()
+	return &model
+}
+
+// String summarizes the members set on the builder, for debugging.
+func (b *TestZoneMapBuilder) String() string {
+	if b == nil {
+		return "<nil>"
+	}
+	var fields []string
+	return "TestZoneMapBuilder{" + strings.Join(fields, ", ") + "}"
+}
+
+// GoString formats the builder with its nested builders, for %#v.
+func (b *TestZoneMapBuilder) GoString() string {
+	if b == nil {
+		return "(*TestZoneMapBuilder)(nil)"
+	}
+	return fmt.Sprintf("&TestZoneMapBuilder{model: %#v}", b.model)
+}
+
+// Clone returns a copy of the builder, its nested builders cloned, to
+// set the copies independently.
+func (b *TestZoneMapBuilder) Clone() *TestZoneMapBuilder {
+	if b == nil {
+		return nil
+	}
+	clone := *b
+	return &clone
+}
+
+func (b *TestZoneMapBuilder) fromModel(model TestZoneMap) {
+	b.model = model
 }